@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ApiTokenPrefix makes API tokens distinguishable from JWTs in the Authorization header and easy to spot by secret scanners
+const ApiTokenPrefix = "cs_"
+
+// ClientKeyPrefix distinguishes API keys of configuration clients from user API tokens
+const ClientKeyPrefix = "csk_"
+
+func GenerateApiToken() (token string, displayPrefix string, err error) {
+	return generateSecret(ApiTokenPrefix)
+}
+
+// GenerateClientKey generates an API key for a configuration client credential
+func GenerateClientKey() (key string, displayPrefix string, err error) {
+	return generateSecret(ClientKeyPrefix)
+}
+
+func generateSecret(prefix string) (token string, displayPrefix string, err error) {
+	bytes := make([]byte, 24)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", err
+	}
+
+	token = prefix + hex.EncodeToString(bytes)
+
+	return token, token[:len(prefix)+6], nil
+}
+
+// HashApiToken hashes the token for storage. Tokens have enough entropy that a fast hash is sufficient and allows lookup by hash.
+func HashApiToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// GetApiToken returns the API token from the Authorization header, if the request is authenticated with one
+func GetApiToken(c echo.Context) (string, bool) {
+	token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+	if !ok || !strings.HasPrefix(token, ApiTokenPrefix) {
+		return "", false
+	}
+
+	return token, true
+}
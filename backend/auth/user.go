@@ -10,6 +10,7 @@ type User struct {
 	IsAuthenticated      bool   `json:"isAuthenticated" validate:"required"`
 	ChangesetID          uint   `json:"-"`
 	IsGlobalAdmin        bool   `json:"isGlobalAdmin" validate:"required"`
+	CanRevealSecrets     bool   `json:"canRevealSecrets" validate:"required"`
 	permissionCollection *PermissionCollection
 }
 
@@ -26,6 +26,12 @@ func (u *UserBuilder) WithBasicInfo(id uint, username string, isGlobalAdmin bool
 	return u
 }
 
+// WithRevealSecrets allows the user to see secret values, global administrators can always see them
+func (u *UserBuilder) WithRevealSecrets(revealSecrets bool) *UserBuilder {
+	u.user.CanRevealSecrets = revealSecrets || u.user.IsGlobalAdmin
+	return u
+}
+
 func (u *UserBuilder) WithChangesetID(changesetID uint) *UserBuilder {
 	u.user.ChangesetID = changesetID
 	return u
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// redactedData is what the server returns instead of secret values the caller is not allowed to reveal
+const redactedData = "********"
+
+// listFlag collects the values of a flag that can be repeated
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func main() {
+	var services listFlag
+	var variation listFlag
+
+	serverURL := flag.String("url", envOrDefault("CONFIG_SERVICE_URL", "http://localhost:1323"), "config service URL")
+	apiKey := flag.String("api-key", os.Getenv("CONFIG_SERVICE_API_KEY"), "client API key")
+	token := flag.String("token", os.Getenv("CONFIG_SERVICE_TOKEN"), "API token of a user, used instead of the client API key")
+	format := flag.String("format", "env", "export format: yaml, env, properties or json")
+	output := flag.String("out", "", "file to write the configuration to, defaults to stdout")
+	changesetID := flag.Uint("changeset", 0, "changeset ID, defaults to the last applied changeset")
+	mode := flag.String("mode", "production", "configuration mode, empty to include unpublished service versions")
+	timeout := flag.Duration("timeout", 30*time.Second, "request timeout")
+	flag.Var(&services, "service", "service version in format service:version, can be repeated")
+	flag.Var(&variation, "variation", "variation in format property:value, can be repeated")
+	flag.Parse()
+
+	if len(services) == 0 {
+		log.Fatal("at least one -service is required")
+	}
+
+	query := url.Values{}
+	query.Set("format", *format)
+	query["services[]"] = services
+	query["variation[]"] = variation
+
+	if *changesetID != 0 {
+		query.Set("changesetId", fmt.Sprint(*changesetID))
+	}
+
+	if *mode != "" {
+		query.Set("mode", *mode)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(*serverURL, "/")+"/api/configuration/export?"+query.Encode(), nil)
+	if err != nil {
+		log.Fatalf("failed to create request: %v", err)
+	}
+
+	if *token != "" {
+		req.Header.Set("Authorization", "Bearer "+*token)
+	} else if *apiKey != "" {
+		req.Header.Set("X-Api-Key", *apiKey)
+	}
+
+	client := &http.Client{Timeout: *timeout}
+	res, err := client.Do(req)
+	if err != nil {
+		log.Fatalf("failed to export configuration: %v", err)
+	}
+
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		log.Fatalf("failed to read configuration: %v", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		var httpError struct {
+			Message string `json:"message"`
+		}
+
+		if err := json.Unmarshal(data, &httpError); err != nil || httpError.Message == "" {
+			httpError.Message = string(data)
+		}
+
+		log.Fatalf("failed to export configuration: %s: %s", res.Status, httpError.Message)
+	}
+
+	// The server refuses to export secrets the caller cannot reveal, older servers redact them instead
+	if bytes.Contains(data, []byte(redactedData)) {
+		log.Fatalf("failed to export configuration: it contains redacted secret values, use an API key or token that is allowed to reveal secrets")
+	}
+
+	if *output == "" {
+		if _, err := os.Stdout.Write(data); err != nil {
+			log.Fatalf("failed to write configuration: %v", err)
+		}
+
+		return
+	}
+
+	if err := writeFile(*output, data); err != nil {
+		log.Fatalf("failed to write configuration: %v", err)
+	}
+
+	log.Printf("Configuration written to %s", *output)
+}
+
+func envOrDefault(name string, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+
+	return defaultValue
+}
+
+// writeFile replaces the file atomically, so a service starting during a deploy never reads a partially written file.
+// The file can contain secret values, so only the owner can read it.
+func writeFile(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Chmod(0o600); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
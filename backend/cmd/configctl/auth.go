@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/necroskillz/config-service/auth"
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/handler"
+	"github.com/necroskillz/config-service/services/membership"
+	"golang.org/x/term"
+)
+
+func runLogin(args []string) error {
+	flags := flag.NewFlagSet("login", flag.ExitOnError)
+	newClient := clientFlags(flags)
+	username := flags.String("username", "", "username")
+	passwordStdin := flags.Bool("password-stdin", false, "read the password from stdin")
+	flags.Parse(args)
+
+	reader := bufio.NewReader(os.Stdin)
+
+	if *username == "" {
+		fmt.Fprint(os.Stderr, "Username: ")
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+
+		*username = strings.TrimSpace(line)
+	}
+
+	password, err := readPassword(reader, *passwordStdin)
+	if err != nil {
+		return err
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	c.token = ""
+	c.stored = nil
+
+	var tokens handler.TokensResponse
+	if err := c.do(http.MethodPost, "/auth/login", nil, handler.LoginRequest{Username: *username, Password: password}, &tokens); err != nil {
+		return err
+	}
+
+	if err := saveCredentials(&credentials{URL: c.baseURL, AccessToken: tokens.AccessToken, RefreshToken: tokens.RefreshToken}); err != nil {
+		return fmt.Errorf("failed to save credentials: %w", err)
+	}
+
+	fmt.Printf("Logged in to %s as %s\n", c.baseURL, *username)
+
+	return nil
+}
+
+func readPassword(reader *bufio.Reader, fromStdin bool) (string, error) {
+	if !fromStdin && term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Fprint(os.Stderr, "Password: ")
+		password, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Fprintln(os.Stderr)
+
+		return string(password), err
+	}
+
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func runLogout(args []string) error {
+	flags := flag.NewFlagSet("logout", flag.ExitOnError)
+	flags.Parse(args)
+
+	return removeCredentials()
+}
+
+func runWhoami(args []string) error {
+	flags := flag.NewFlagSet("whoami", flag.ExitOnError)
+	newClient := clientFlags(flags)
+	output := outputFlag(flags)
+	flags.Parse(args)
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	user, err := currentUser(c)
+	if err != nil {
+		return err
+	}
+
+	t := newTable("ID", "USERNAME", "GLOBAL ADMIN")
+	t.add(fmt.Sprint(user.ID), user.Username, fmt.Sprint(user.IsGlobalAdmin))
+
+	return t.print(*output, user)
+}
+
+func currentUser(c *client) (auth.User, error) {
+	var user auth.User
+	if err := c.do(http.MethodGet, "/auth/user", nil, nil, &user); err != nil {
+		return auth.User{}, err
+	}
+
+	if !user.IsAuthenticated {
+		return auth.User{}, fmt.Errorf("not logged in, run configctl login or provide an API token")
+	}
+
+	return user, nil
+}
+
+func runToken(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("expected subcommand: list, create or revoke")
+	}
+
+	flags := flag.NewFlagSet("token "+args[0], flag.ExitOnError)
+	newClient := clientFlags(flags)
+
+	switch args[0] {
+	case "list":
+		output := outputFlag(flags)
+		flags.Parse(args[1:])
+
+		c, user, err := clientWithUser(newClient)
+		if err != nil {
+			return err
+		}
+
+		var tokens []membership.ApiTokenDto
+		if err := c.do(http.MethodGet, fmt.Sprintf("/membership/users/%d/tokens", user.ID), nil, nil, &tokens); err != nil {
+			return err
+		}
+
+		t := newTable("ID", "NAME", "PREFIX", "SCOPE", "EXPIRES", "LAST USED", "REVOKED")
+		for _, token := range tokens {
+			t.add(fmt.Sprint(token.ID), token.Name, token.TokenPrefix, string(token.Scope), formatTime(token.ExpiresAt), formatTime(token.LastUsedAt), formatTime(token.RevokedAt))
+		}
+
+		return t.print(*output, tokens)
+	case "create":
+		name := flags.String("name", "", "token name")
+		scope := flags.String("scope", string(db.ApiTokenScopeRead), "token scope: read or write")
+		expiresIn := flags.Duration("expires-in", 0, "token lifetime, the token does not expire if not set")
+		flags.Parse(args[1:])
+
+		c, user, err := clientWithUser(newClient)
+		if err != nil {
+			return err
+		}
+
+		request := handler.CreateApiTokenRequest{Name: *name, Scope: db.ApiTokenScope(*scope)}
+		if *expiresIn > 0 {
+			expiresAt := time.Now().Add(*expiresIn)
+			request.ExpiresAt = &expiresAt
+		}
+
+		var token membership.CreatedApiTokenDto
+		if err := c.do(http.MethodPost, fmt.Sprintf("/membership/users/%d/tokens", user.ID), nil, request, &token); err != nil {
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, "Store the token now, it cannot be displayed again.")
+		fmt.Println(token.Token)
+
+		return nil
+	case "revoke":
+		id := flags.Uint("id", 0, "token ID")
+		flags.Parse(args[1:])
+
+		c, user, err := clientWithUser(newClient)
+		if err != nil {
+			return err
+		}
+
+		return c.do(http.MethodDelete, fmt.Sprintf("/membership/users/%d/tokens/%d", user.ID, *id), nil, nil, nil)
+	default:
+		return fmt.Errorf("unknown subcommand %s, expected list, create or revoke", args[0])
+	}
+}
+
+func clientWithUser(newClient func() (*client, error)) (*client, auth.User, error) {
+	c, err := newClient()
+	if err != nil {
+		return nil, auth.User{}, err
+	}
+
+	user, err := currentUser(c)
+	if err != nil {
+		return nil, auth.User{}, err
+	}
+
+	return c, user, nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+
+	return t.Local().Format(time.DateTime)
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/necroskillz/config-service/services/feature"
+	"github.com/necroskillz/config-service/services/key"
+	"github.com/necroskillz/config-service/services/service"
+)
+
+func runServices(args []string) error {
+	flags := flag.NewFlagSet("services", flag.ExitOnError)
+	newClient := clientFlags(flags)
+	output := outputFlag(flags)
+	flags.Parse(args)
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	var services []service.ServiceDto
+	if err := c.do(http.MethodGet, "/services", nil, nil, &services); err != nil {
+		return err
+	}
+
+	t := newTable("NAME", "VERSIONS", "DESCRIPTION")
+	for _, s := range services {
+		versions := make([]string, len(s.Versions))
+		for i, v := range s.Versions {
+			versions[i] = fmt.Sprint(v.Version)
+			if !v.Published {
+				versions[i] += "*"
+			}
+		}
+
+		t.add(s.Name, strings.Join(versions, ","), s.Description)
+	}
+
+	return t.print(*output, services)
+}
+
+func runFeatures(args []string) error {
+	flags := flag.NewFlagSet("features", flag.ExitOnError)
+	newClient := clientFlags(flags)
+	output := outputFlag(flags)
+	serviceVersion := flags.String("service", "", "service version in format service:version")
+	flags.Parse(args)
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	sv, err := c.findServiceVersion(*serviceVersion)
+	if err != nil {
+		return err
+	}
+
+	var features []feature.FeatureVersionItemDto
+	if err := c.do(http.MethodGet, fmt.Sprintf("/services/%d/features", sv.ID), nil, nil, &features); err != nil {
+		return err
+	}
+
+	t := newTable("NAME", "VERSION", "DESCRIPTION")
+	for _, f := range features {
+		t.add(f.Name, fmt.Sprint(f.Version), f.Description)
+	}
+
+	return t.print(*output, features)
+}
+
+func runKeys(args []string) error {
+	flags := flag.NewFlagSet("keys", flag.ExitOnError)
+	newClient := clientFlags(flags)
+	output := outputFlag(flags)
+	serviceVersion := flags.String("service", "", "service version in format service:version")
+	featureName := flags.String("feature", "", "feature name")
+	flags.Parse(args)
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	sv, err := c.findServiceVersion(*serviceVersion)
+	if err != nil {
+		return err
+	}
+
+	f, err := c.findFeature(sv.ID, *featureName)
+	if err != nil {
+		return err
+	}
+
+	var keys []key.KeyItemDto
+	if err := c.do(http.MethodGet, fmt.Sprintf("/services/%d/features/%d/keys", sv.ID, f.ID), nil, nil, &keys); err != nil {
+		return err
+	}
+
+	t := newTable("NAME", "TYPE", "DESCRIPTION")
+	for _, k := range keys {
+		t.add(k.Name, k.ValueTypeName, k.Description)
+	}
+
+	return t.print(*output, keys)
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/necroskillz/config-service/handler"
+	"github.com/necroskillz/config-service/services/changeset"
+	"github.com/necroskillz/config-service/services/core"
+	"github.com/necroskillz/config-service/util/ptr"
+)
+
+// changesetIDOrCurrent returns the ID from the flag, or the open changeset of the user if it is not set
+func changesetIDOrCurrent(c *client, id uint) (uint, error) {
+	if id != 0 {
+		return id, nil
+	}
+
+	return c.currentChangesetID(false)
+}
+
+func runChangeset(args []string) error {
+	flags := flag.NewFlagSet("changeset", flag.ExitOnError)
+	newClient := clientFlags(flags)
+	output := outputFlag(flags)
+	id := flags.Uint("id", 0, "changeset ID, defaults to the open changeset")
+	flags.Parse(args)
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	changesetID, err := changesetIDOrCurrent(c, *id)
+	if err != nil {
+		return err
+	}
+
+	var cs changeset.ChangesetDto
+	if err := c.do(http.MethodGet, fmt.Sprintf("/changesets/%d", changesetID), nil, nil, &cs); err != nil {
+		return err
+	}
+
+	properties, err := c.variationProperties()
+	if err != nil {
+		return err
+	}
+
+	if *output == outputTable {
+		fmt.Printf("Changeset %d by %s is %s\n\n", cs.ID, cs.UserName, cs.State)
+	}
+
+	t := newTable("ID", "CHANGE", "SERVICE", "FEATURE", "KEY", "VARIATION", "OLD", "NEW")
+	for _, change := range cs.Changes {
+		t.add(
+			fmt.Sprint(change.ID),
+			fmt.Sprintf("%s %s", change.Type, change.Kind),
+			fmt.Sprintf("%s:%d", change.ServiceName, change.ServiceVersion),
+			formatOptional(change.FeatureName),
+			formatOptional(change.KeyName),
+			formatVariation(change.Variation, properties),
+			formatData(change.OldVariationValueData),
+			formatData(change.NewVariationValueData),
+		)
+	}
+
+	return t.print(*output, cs)
+}
+
+func runCommit(args []string) error {
+	flags := flag.NewFlagSet("commit", flag.ExitOnError)
+	newClient := clientFlags(flags)
+	message := flags.String("message", "", "comment of the commit")
+	flags.Parse(args)
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	changesetID, err := c.commitCurrentChangeset(*message)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Changeset %d committed\n", changesetID)
+
+	return nil
+}
+
+func runApply(args []string) error {
+	flags := flag.NewFlagSet("apply", flag.ExitOnError)
+	newClient := clientFlags(flags)
+	id := flags.Uint("id", 0, "changeset ID, defaults to the open changeset")
+	message := flags.String("message", "", "comment of the apply")
+	flags.Parse(args)
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	changesetID, err := changesetIDOrCurrent(c, *id)
+	if err != nil {
+		return err
+	}
+
+	if err := c.do(http.MethodPut, fmt.Sprintf("/changesets/%d/apply", changesetID), nil, handler.OptionalCommentRequest{Comment: ptr.To(*message, ptr.NilIfZero())}, nil); err != nil {
+		return err
+	}
+
+	fmt.Printf("Changeset %d applied\n", changesetID)
+
+	return nil
+}
+
+func runHistory(args []string) error {
+	flags := flag.NewFlagSet("history", flag.ExitOnError)
+	newClient := clientFlags(flags)
+	output := outputFlag(flags)
+	serviceVersion := flags.String("service", "", "only show changes of the service version in format service:version")
+	featureName := flags.String("feature", "", "only show changes of the feature, requires -service")
+	keyName := flags.String("key", "", "only show changes of the key")
+	page := flags.Int("page", 1, "page")
+	pageSize := flags.Int("page-size", 20, "page size")
+	flags.Parse(args)
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	query.Set("page", fmt.Sprint(*page))
+	query.Set("pageSize", fmt.Sprint(*pageSize))
+
+	if *keyName != "" {
+		query.Set("keyName", *keyName)
+	}
+
+	if *serviceVersion != "" {
+		sv, err := c.findServiceVersion(*serviceVersion)
+		if err != nil {
+			return err
+		}
+
+		query.Set("serviceVersionId", fmt.Sprint(sv.ID))
+
+		if *featureName != "" {
+			f, err := c.findFeature(sv.ID, *featureName)
+			if err != nil {
+				return err
+			}
+
+			query.Set("featureVersionId", fmt.Sprint(f.ID))
+		}
+	} else if *featureName != "" {
+		return fmt.Errorf("-feature requires -service")
+	}
+
+	var history core.PaginatedResult[changeset.ChangeHistoryItemDto]
+	if err := c.do(http.MethodGet, "/change-history", query, nil, &history); err != nil {
+		return err
+	}
+
+	properties, err := c.variationProperties()
+	if err != nil {
+		return err
+	}
+
+	t := newTable("CHANGESET", "APPLIED", "USER", "CHANGE", "SERVICE", "FEATURE", "KEY", "VARIATION", "OLD", "NEW")
+	for _, item := range history.Items {
+		t.add(
+			fmt.Sprint(item.ChangesetID),
+			formatTime(&item.AppliedAt),
+			item.UserName,
+			fmt.Sprintf("%s %s", item.Type, item.Kind),
+			fmt.Sprintf("%s:%d", item.ServiceName, item.ServiceVersion),
+			formatOptional(item.FeatureName),
+			formatOptional(item.KeyName),
+			formatVariation(item.Variation, properties),
+			formatData(item.OldVariationValueData),
+			formatData(item.NewVariationValueData),
+		)
+	}
+
+	if err := t.print(*output, history); err != nil {
+		return err
+	}
+
+	if *output == outputTable && history.TotalCount > *page**pageSize {
+		fmt.Printf("\nShowing page %d of %d changes, use -page to see more\n", *page, history.TotalCount)
+	}
+
+	return nil
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/necroskillz/config-service/handler"
+	"github.com/necroskillz/config-service/services/feature"
+	"github.com/necroskillz/config-service/services/key"
+	"github.com/necroskillz/config-service/services/service"
+	"github.com/necroskillz/config-service/services/variationproperty"
+	"github.com/necroskillz/config-service/util/ptr"
+)
+
+type client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	// stored are the credentials saved by login, the access token is refreshed with them when it expires
+	stored *credentials
+}
+
+// clientFlags registers the flags shared by all commands and returns a function creating the client after the flags are parsed.
+// The token flag takes precedence over the credentials stored by login.
+func clientFlags(flags *flag.FlagSet) func() (*client, error) {
+	serverURL := flags.String("url", os.Getenv("CONFIG_SERVICE_URL"), "config service URL, defaults to the URL used by login or http://localhost:1323")
+	token := flags.String("token", os.Getenv("CONFIG_SERVICE_TOKEN"), "API token, defaults to the credentials stored by login")
+	timeout := flags.Duration("timeout", 30*time.Second, "request timeout")
+
+	return func() (*client, error) {
+		c := &client{
+			token:      *token,
+			httpClient: &http.Client{Timeout: *timeout},
+		}
+
+		baseURL := *serverURL
+
+		if c.token == "" {
+			stored, err := loadCredentials()
+			if err != nil {
+				return nil, fmt.Errorf("failed to load credentials: %w", err)
+			}
+
+			if stored != nil && (baseURL == "" || strings.TrimSuffix(baseURL, "/") == stored.URL) {
+				c.stored = stored
+				c.token = stored.AccessToken
+				baseURL = stored.URL
+			}
+		}
+
+		if baseURL == "" {
+			baseURL = "http://localhost:1323"
+		}
+
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+
+		return c, nil
+	}
+}
+
+// do sends the request with the body encoded as JSON and decodes the response into result, if it is not nil
+func (c *client) do(method string, path string, query url.Values, body any, result any) error {
+	res, data, err := c.send(method, path, query, body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode == http.StatusUnauthorized && c.stored != nil {
+		if err := c.refresh(); err != nil {
+			return fmt.Errorf("session expired, run configctl login: %w", err)
+		}
+
+		res, data, err = c.send(method, path, query, body)
+		if err != nil {
+			return err
+		}
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		var httpError struct {
+			Message string `json:"message"`
+		}
+
+		if err := json.Unmarshal(data, &httpError); err != nil || httpError.Message == "" {
+			httpError.Message = string(data)
+		}
+
+		return fmt.Errorf("%s %s: %s: %s", method, path, res.Status, httpError.Message)
+	}
+
+	if result == nil || len(data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(data, result)
+}
+
+func (c *client) send(method string, path string, query url.Values, body any) (*http.Response, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	requestURL := c.baseURL + "/api" + path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(method, requestURL, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, data, nil
+}
+
+// refresh exchanges the stored refresh token for new tokens and saves them
+func (c *client) refresh() error {
+	var tokens handler.TokensResponse
+
+	c.token = ""
+	if err := c.doWithoutRefresh(http.MethodPost, "/auth/refresh_token", handler.RefreshTokenRequest{RefreshToken: c.stored.RefreshToken}, &tokens); err != nil {
+		return err
+	}
+
+	c.stored.AccessToken = tokens.AccessToken
+	c.stored.RefreshToken = tokens.RefreshToken
+	c.token = tokens.AccessToken
+
+	return saveCredentials(c.stored)
+}
+
+func (c *client) doWithoutRefresh(method string, path string, body any, result any) error {
+	stored := c.stored
+	c.stored = nil
+	defer func() { c.stored = stored }()
+
+	return c.do(method, path, nil, body, result)
+}
+
+// findServiceVersion returns the service version in format service:version
+func (c *client) findServiceVersion(specifier string) (service.ServiceVersionInfoDto, error) {
+	name, versionString, ok := strings.Cut(specifier, ":")
+	if !ok {
+		return service.ServiceVersionInfoDto{}, fmt.Errorf("invalid service version %s, expected format service:version", specifier)
+	}
+
+	var version int
+	if _, err := fmt.Sscan(versionString, &version); err != nil {
+		return service.ServiceVersionInfoDto{}, fmt.Errorf("invalid service version %s, expected format service:version", specifier)
+	}
+
+	var services []service.ServiceDto
+	if err := c.do(http.MethodGet, "/services", nil, nil, &services); err != nil {
+		return service.ServiceVersionInfoDto{}, err
+	}
+
+	for _, s := range services {
+		if s.Name != name {
+			continue
+		}
+
+		for _, v := range s.Versions {
+			if v.Version == version {
+				return v, nil
+			}
+		}
+	}
+
+	return service.ServiceVersionInfoDto{}, fmt.Errorf("service version %s not found", specifier)
+}
+
+func (c *client) findFeature(serviceVersionID uint, name string) (feature.FeatureVersionItemDto, error) {
+	var features []feature.FeatureVersionItemDto
+	if err := c.do(http.MethodGet, fmt.Sprintf("/services/%d/features", serviceVersionID), nil, nil, &features); err != nil {
+		return feature.FeatureVersionItemDto{}, err
+	}
+
+	for _, f := range features {
+		if f.Name == name {
+			return f, nil
+		}
+	}
+
+	return feature.FeatureVersionItemDto{}, fmt.Errorf("feature %s not found", name)
+}
+
+func (c *client) findKey(serviceVersionID uint, featureVersionID uint, name string) (key.KeyItemDto, error) {
+	var keys []key.KeyItemDto
+	if err := c.do(http.MethodGet, fmt.Sprintf("/services/%d/features/%d/keys", serviceVersionID, featureVersionID), nil, nil, &keys); err != nil {
+		return key.KeyItemDto{}, err
+	}
+
+	for _, k := range keys {
+		if k.Name == name {
+			return k, nil
+		}
+	}
+
+	return key.KeyItemDto{}, fmt.Errorf("key %s not found", name)
+}
+
+// variationProperties returns the variation property names by their IDs
+func (c *client) variationProperties() (map[uint]string, error) {
+	var properties []variationproperty.VariationPropertyItemDto
+	if err := c.do(http.MethodGet, "/variation-properties", nil, nil, &properties); err != nil {
+		return nil, err
+	}
+
+	names := make(map[uint]string, len(properties))
+	for _, property := range properties {
+		names[property.ID] = property.Name
+	}
+
+	return names, nil
+}
+
+// parseVariation converts variation in format property=value to the map of property IDs used by the API
+func (c *client) parseVariation(variation []string) (map[uint]string, error) {
+	result := make(map[uint]string, len(variation))
+	if len(variation) == 0 {
+		return result, nil
+	}
+
+	properties, err := c.variationProperties()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]uint, len(properties))
+	for id, name := range properties {
+		ids[name] = id
+	}
+
+	for _, v := range variation {
+		name, value, ok := strings.Cut(v, "=")
+		if !ok || name == "" || value == "" {
+			return nil, fmt.Errorf("invalid variation %s, expected format property=value", v)
+		}
+
+		id, ok := ids[name]
+		if !ok {
+			return nil, fmt.Errorf("variation property %s not found", name)
+		}
+
+		if _, ok := result[id]; ok {
+			return nil, fmt.Errorf("variation property %s is specified more than once", name)
+		}
+
+		result[id] = value
+	}
+
+	return result, nil
+}
+
+func (c *client) commitCurrentChangeset(comment string) (uint, error) {
+	changesetID, err := c.currentChangesetID(true)
+	if err != nil {
+		return 0, err
+	}
+
+	return changesetID, c.do(http.MethodPut, fmt.Sprintf("/changesets/%d/commit", changesetID), nil, handler.OptionalCommentRequest{Comment: ptr.To(comment, ptr.NilIfZero())}, nil)
+}
+
+// currentChangesetID returns the ID of the open changeset of the user, requireChanges fails if it has no changes
+func (c *client) currentChangesetID(requireChanges bool) (uint, error) {
+	var info handler.ChangesetInfoResponse
+	if err := c.do(http.MethodGet, "/changesets/current", nil, nil, &info); err != nil {
+		return 0, err
+	}
+
+	if info.ID == 0 || (requireChanges && info.NumberOfChanges == 0) {
+		return 0, fmt.Errorf("there is no open changeset with changes")
+	}
+
+	return info.ID, nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// listFlag collects the values of a flag that can be repeated
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+type command struct {
+	name        string
+	description string
+	run         func(args []string) error
+}
+
+var commands = []command{
+	{name: "login", description: "log in and store the credentials", run: runLogin},
+	{name: "logout", description: "remove the stored credentials", run: runLogout},
+	{name: "whoami", description: "show the current user", run: runWhoami},
+	{name: "token", description: "list, create or revoke API tokens of the current user", run: runToken},
+	{name: "services", description: "list services", run: runServices},
+	{name: "features", description: "list features of a service version", run: runFeatures},
+	{name: "keys", description: "list keys of a feature", run: runKeys},
+	{name: "get", description: "show values of a key", run: runGet},
+	{name: "set", description: "create or update a value of a key in the open changeset", run: runSet},
+	{name: "changeset", description: "show the changes of the open or another changeset", run: runChangeset},
+	{name: "commit", description: "commit the open changeset", run: runCommit},
+	{name: "apply", description: "apply the open or another changeset", run: runApply},
+	{name: "history", description: "show applied changes", run: runHistory},
+	{name: "sync", description: "reconcile a service version with a manifest directory", run: runSync},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: configctl <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+
+	for _, command := range commands {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", command.name, command.description)
+	}
+
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Run configctl <command> -h for the flags of a command.")
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	for _, command := range commands {
+		if command.name == os.Args[1] {
+			if err := command.run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "configctl %s: %v\n", command.name, err)
+				os.Exit(1)
+			}
+
+			return
+		}
+	}
+
+	if os.Args[1] != "-h" && os.Args[1] != "help" {
+		fmt.Fprintf(os.Stderr, "configctl: unknown command %s\n\n", os.Args[1])
+	}
+
+	usage()
+	os.Exit(2)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+type outputFormat string
+
+const (
+	outputTable outputFormat = "table"
+	outputJson  outputFormat = "json"
+)
+
+func outputFlag(flags *flag.FlagSet) *outputFormat {
+	format := outputTable
+	flags.Func("o", "output format: table or json", func(value string) error {
+		switch outputFormat(value) {
+		case outputTable, outputJson:
+			format = outputFormat(value)
+			return nil
+		default:
+			return fmt.Errorf("unsupported output format %s", value)
+		}
+	})
+
+	return &format
+}
+
+// table collects rows printed with aligned columns
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+func newTable(header ...string) *table {
+	return &table{header: header}
+}
+
+func (t *table) add(columns ...string) {
+	t.rows = append(t.rows, columns)
+}
+
+// print writes the value as JSON or the table, the JSON output contains all fields returned by the API
+func (t *table) print(format outputFormat, value any) error {
+	if format == outputJson {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(value)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(t.header, "\t"))
+
+	for _, row := range t.rows {
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+
+	return writer.Flush()
+}
+
+// formatVariation formats the variation as property=value pairs ordered by property name
+func formatVariation(variation map[uint]string, properties map[uint]string) string {
+	if len(variation) == 0 {
+		return "-"
+	}
+
+	parts := make([]string, 0, len(variation))
+	for id, value := range variation {
+		name, ok := properties[id]
+		if !ok {
+			name = fmt.Sprint(id)
+		}
+
+		parts = append(parts, name+"="+value)
+	}
+
+	slices.Sort(parts)
+
+	return strings.Join(parts, ",")
+}
+
+// formatData shortens the data to a single line that fits a table column
+func formatData(data *string) string {
+	if data == nil {
+		return "-"
+	}
+
+	value := []rune(strings.Join(strings.Fields(*data), " "))
+	if len(value) > 60 {
+		return string(value[:57]) + "..."
+	}
+
+	return string(value)
+}
+
+func formatOptional[T any](value *T) string {
+	if value == nil {
+		return "-"
+	}
+
+	return fmt.Sprint(*value)
+}
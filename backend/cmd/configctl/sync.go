@@ -0,0 +1,210 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/necroskillz/config-service/services/manifest"
+)
+
+// errDrift is returned by sync in check mode when the service version differs from the manifest
+var errDrift = errors.New("service version is out of sync with the manifest")
+
+func runSync(args []string) error {
+	flags := flag.NewFlagSet("sync", flag.ExitOnError)
+	newClient := clientFlags(flags)
+	serviceVersion := flags.String("service", "", "service version in format service:version")
+	dir := flags.String("dir", ".", "directory with the manifest files, all .yaml, .yml and .json files in it and its subdirectories are read")
+	check := flags.Bool("check", false, "only report the differences and fail if there are any")
+	prune := flags.Bool("prune", false, "delete the keys and values and unlink the features that are not in the manifest")
+	commit := flags.Bool("commit", false, "commit the changeset after the changes are made, together with any other changes already in it")
+	message := flags.String("message", "", "comment of the commit")
+	flags.Parse(args)
+
+	if *serviceVersion == "" {
+		return fmt.Errorf("-service is required")
+	}
+
+	m, err := loadManifest(*dir)
+	if err != nil {
+		return err
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	sv, err := c.findServiceVersion(*serviceVersion)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/services/%d/import", sv.ID)
+	query := url.Values{}
+	if *prune {
+		query.Set("prune", "true")
+	}
+
+	dryRunQuery := maps.Clone(query)
+	dryRunQuery.Set("dryRun", "true")
+
+	var plan manifest.ImportResultDto
+	if err := c.do(http.MethodPost, path, dryRunQuery, m, &plan); err != nil {
+		return err
+	}
+
+	if len(plan.Changes) == 0 && len(plan.Drift) == 0 {
+		fmt.Printf("%s is in sync\n", *serviceVersion)
+		return nil
+	}
+
+	for _, change := range plan.Changes {
+		fmt.Println(formatChange(change))
+	}
+
+	for _, drift := range plan.Drift {
+		fmt.Println(formatDrift(drift))
+	}
+
+	if *check {
+		return errDrift
+	}
+
+	if len(plan.Drift) > 0 {
+		fmt.Println("Features, keys and values marked with ! are not in the manifest and are left as they are, use -prune to remove them")
+	}
+
+	if len(plan.Changes) == 0 {
+		return nil
+	}
+
+	var result manifest.ImportResultDto
+	if err := c.do(http.MethodPost, path, query, m, &result); err != nil {
+		return err
+	}
+
+	fmt.Printf("%d changes added to the changeset\n", len(result.Changes))
+
+	if *commit {
+		changesetID, err := c.commitCurrentChangeset(*message)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Changeset %d committed\n", changesetID)
+	}
+
+	return nil
+}
+
+// loadManifest reads all manifest files in the directory into a single manifest, files are read in lexical order
+func loadManifest(dir string) (manifest.Manifest, error) {
+	combined := manifest.Manifest{Features: []manifest.FeatureManifest{}}
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !slices.Contains([]string{".yaml", ".yml", ".json"}, filepath.Ext(path)) {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		m, err := manifest.Parse(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		combined.Features = append(combined.Features, m.Features...)
+
+		return nil
+	})
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	if len(combined.Features) == 0 {
+		return manifest.Manifest{}, fmt.Errorf("no features found in %s", dir)
+	}
+
+	return combined, nil
+}
+
+func formatName(feature string, key *string, variation map[string]string) string {
+	name := feature
+	if key != nil {
+		name += "." + *key
+	}
+
+	if len(variation) > 0 {
+		parts := make([]string, 0, len(variation))
+		for _, property := range slices.Sorted(maps.Keys(variation)) {
+			parts = append(parts, property+"="+variation[property])
+		}
+
+		name += " [" + strings.Join(parts, ",") + "]"
+	}
+
+	return name
+}
+
+func formatChange(change manifest.ImportChangeDto) string {
+	name := formatName(change.Feature, change.Key, change.Variation)
+
+	switch change.Kind {
+	case manifest.ImportChangeKindCreateFeature:
+		return fmt.Sprintf("+ feature %s", name)
+	case manifest.ImportChangeKindCreateKey:
+		return fmt.Sprintf("+ key %s = %s", name, *change.NewData)
+	case manifest.ImportChangeKindCreateValue:
+		return fmt.Sprintf("+ value %s = %s", name, *change.NewData)
+	case manifest.ImportChangeKindUpdateFeature:
+		return fmt.Sprintf("~ feature %s description", name)
+	case manifest.ImportChangeKindUpdateKey:
+		return fmt.Sprintf("~ key %s description and validators", name)
+	case manifest.ImportChangeKindUnlinkFeature:
+		return fmt.Sprintf("- feature %s", name)
+	case manifest.ImportChangeKindDeleteKey:
+		return fmt.Sprintf("- key %s", name)
+	case manifest.ImportChangeKindDeleteValue:
+		return fmt.Sprintf("- value %s = %s", name, *change.OldData)
+	default:
+		return fmt.Sprintf("~ value %s: %s -> %s", name, *change.OldData, *change.NewData)
+	}
+}
+
+func formatDrift(drift manifest.DriftDto) string {
+	name := formatName(drift.Feature, drift.Key, drift.Variation)
+
+	switch drift.Kind {
+	case manifest.DriftKindUnmanagedFeature:
+		return fmt.Sprintf("! feature %s is not in the manifest", name)
+	case manifest.DriftKindUnmanagedKey:
+		return fmt.Sprintf("! key %s is not in the manifest", name)
+	default:
+		return fmt.Sprintf("! value %s = %s is not in the manifest", name, *drift.Data)
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/necroskillz/config-service/handler"
+	"github.com/necroskillz/config-service/services/core"
+	"github.com/necroskillz/config-service/services/value"
+)
+
+// keyTarget identifies a key by the names given in the flags
+type keyTarget struct {
+	serviceVersion *string
+	feature        *string
+	key            *string
+}
+
+func keyTargetFlags(flags *flag.FlagSet) keyTarget {
+	return keyTarget{
+		serviceVersion: flags.String("service", "", "service version in format service:version"),
+		feature:        flags.String("feature", "", "feature name"),
+		key:            flags.String("key", "", "key name"),
+	}
+}
+
+// valuesPath resolves the names and returns the API path of the values of the key
+func (t keyTarget) valuesPath(c *client) (string, error) {
+	sv, err := c.findServiceVersion(*t.serviceVersion)
+	if err != nil {
+		return "", err
+	}
+
+	f, err := c.findFeature(sv.ID, *t.feature)
+	if err != nil {
+		return "", err
+	}
+
+	k, err := c.findKey(sv.ID, f.ID, *t.key)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("/services/%d/features/%d/keys/%d/values", sv.ID, f.ID, k.ID), nil
+}
+
+func runGet(args []string) error {
+	flags := flag.NewFlagSet("get", flag.ExitOnError)
+	newClient := clientFlags(flags)
+	output := outputFlag(flags)
+	target := keyTargetFlags(flags)
+	var variation listFlag
+	flags.Var(&variation, "variation", "only show the value with exactly this variation, in format property=value, can be repeated")
+	flags.Parse(args)
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	path, err := target.valuesPath(c)
+	if err != nil {
+		return err
+	}
+
+	var values []value.VariationValueDto
+	if err := c.do(http.MethodGet, path, nil, nil, &values); err != nil {
+		return err
+	}
+
+	if len(variation) > 0 {
+		filter, err := c.parseVariation(variation)
+		if err != nil {
+			return err
+		}
+
+		matching := []value.VariationValueDto{}
+		for _, v := range values {
+			if maps.Equal(v.Variation, filter) {
+				matching = append(matching, v)
+			}
+		}
+
+		if len(matching) == 0 {
+			return fmt.Errorf("no value with variation %s", strings.Join(variation, ","))
+		}
+
+		values = matching
+	}
+
+	properties, err := c.variationProperties()
+	if err != nil {
+		return err
+	}
+
+	t := newTable("ID", "VARIATION", "ROLLOUT", "DATA")
+	for _, v := range values {
+		t.add(fmt.Sprint(v.ID), formatVariation(v.Variation, properties), formatRollout(v.Rollout), formatData(&v.Data))
+	}
+
+	return t.print(*output, values)
+}
+
+func formatRollout(rollout *core.Rollout) string {
+	if rollout == nil {
+		return "-"
+	}
+
+	return fmt.Sprintf("%d%% by %s", rollout.Percentage, rollout.Attribute)
+}
+
+// parseRollout parses a rollout in format percentage:attribute
+func parseRollout(rollout string) (*core.Rollout, error) {
+	if rollout == "" {
+		return nil, nil
+	}
+
+	percentage, attribute, ok := strings.Cut(rollout, ":")
+	if !ok || attribute == "" {
+		return nil, fmt.Errorf("invalid rollout %s, expected format percentage:attribute", rollout)
+	}
+
+	p, err := strconv.Atoi(strings.TrimSuffix(percentage, "%"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid rollout percentage %s", percentage)
+	}
+
+	return &core.Rollout{Percentage: p, Attribute: attribute}, nil
+}
+
+// findValue returns the value of the variation to update. A variation can have a rolled out value besides the value
+// it falls back to, the rolled out one is returned if rolledOut is set. Otherwise the rolled out value is returned only
+// if it is the only value of the variation, so its data can be changed without changing the rollout.
+func findValue(values []value.VariationValueDto, variation map[uint]string, rolledOut bool) *value.VariationValueDto {
+	var found *value.VariationValueDto
+
+	for i, v := range values {
+		if !maps.Equal(v.Variation, variation) {
+			continue
+		}
+
+		if (v.Rollout != nil) == rolledOut {
+			return &values[i]
+		}
+
+		if !rolledOut {
+			found = &values[i]
+		}
+	}
+
+	return found
+}
+
+func runSet(args []string) error {
+	flags := flag.NewFlagSet("set", flag.ExitOnError)
+	newClient := clientFlags(flags)
+	target := keyTargetFlags(flags)
+	data := flags.String("data", "", "value data")
+	rolloutFlag := flags.String("rollout", "", "set the rolled out value of the variation, in format percentage:attribute, the existing rollout is kept if not provided")
+	var variation listFlag
+	flags.Var(&variation, "variation", "variation of the value in format property=value, can be repeated, the default value is set if not provided")
+	flags.Parse(args)
+
+	rollout, err := parseRollout(*rolloutFlag)
+	if err != nil {
+		return err
+	}
+
+	c, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	path, err := target.valuesPath(c)
+	if err != nil {
+		return err
+	}
+
+	valueVariation, err := c.parseVariation(variation)
+	if err != nil {
+		return err
+	}
+
+	var values []value.VariationValueDto
+	if err := c.do(http.MethodGet, path, nil, nil, &values); err != nil {
+		return err
+	}
+
+	request := handler.ValueRequest{Data: *data, Variation: valueVariation, Rollout: rollout}
+
+	if v := findValue(values, valueVariation, rollout != nil); v != nil {
+		if request.Rollout == nil {
+			request.Rollout = v.Rollout
+		}
+
+		if v.Data == *data && v.Rollout.Equal(request.Rollout) {
+			fmt.Println("Value is unchanged")
+			return nil
+		}
+
+		if err := c.do(http.MethodPut, fmt.Sprintf("%s/%d", path, v.ID), nil, request, nil); err != nil {
+			return err
+		}
+
+		fmt.Println("Value updated in the changeset")
+
+		return nil
+	}
+
+	if err := c.do(http.MethodPost, path, nil, request, nil); err != nil {
+		return err
+	}
+
+	fmt.Println("Value created in the changeset")
+
+	return nil
+}
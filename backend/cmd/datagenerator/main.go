@@ -65,7 +65,11 @@ func main() {
 		log.Fatalf("failed to create metrics: %v", err)
 	}
 
-	m := testdata.NewManager(dbpool, cache, metrics)
+	m, err := testdata.NewManager(dbpool, cache, metrics)
+	if err != nil {
+		log.Fatalf("failed to initialize data generator: %v", err)
+	}
+
 	if err := m.Run(ctx, testdata.WithSeed(*seed), testdata.WithIterationCount(*iterations)); err != nil {
 		log.Fatalf("failed to run data generator: %v", err)
 	}
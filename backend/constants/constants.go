@@ -5,6 +5,7 @@ type ContextKey string
 const (
 	UserSessionKey      string     = "user-sessions"
 	UserKey             string     = "user"
+	ClientGrantKey      string     = "clientGrant"
 	UserIdKey           string     = "user_id"
 	AuthenticatedKey    string     = "authenticated"
 	ChangesetCreatedKey string     = "changesetCreated"
@@ -475,6 +475,7 @@ SELECT
     fv.version AS feature_version,
     k.id AS key_id,
     k.name AS key_name,
+    vt.kind AS value_type_kind,
     nv.id AS new_variation_value_id,
     nv.data AS new_variation_value_data,
     ov.id AS old_variation_value_id,
@@ -491,6 +492,7 @@ FROM
     LEFT JOIN feature_versions fv ON fv.id = csc.feature_version_id
     LEFT JOIN features f ON f.id = fv.feature_id
     LEFT JOIN keys k ON k.id = csc.key_id
+    LEFT JOIN value_types vt ON vt.id = k.value_type_id
     LEFT JOIN variation_values nv ON nv.id = csc.new_variation_value_id
     LEFT JOIN variation_values ov ON ov.id = csc.old_variation_value_id
     LEFT JOIN variation_contexts vc ON vc.id = COALESCE(nv.variation_context_id, ov.variation_context_id)
@@ -544,6 +546,7 @@ type GetChangeHistoryRow struct {
 	FeatureVersion           *int
 	KeyID                    *uint
 	KeyName                  *string
+	ValueTypeKind            NullValueTypeKind
 	NewVariationValueID      *uint
 	NewVariationValueData    *string
 	OldVariationValueID      *uint
@@ -593,6 +596,7 @@ func (q *Queries) GetChangeHistory(ctx context.Context, arg GetChangeHistoryPara
 			&i.FeatureVersion,
 			&i.KeyID,
 			&i.KeyName,
+			&i.ValueTypeKind,
 			&i.NewVariationValueID,
 			&i.NewVariationValueData,
 			&i.OldVariationValueID,
@@ -615,6 +619,8 @@ SELECT
     cs.id,
     cs.state,
     cs.applied_at,
+    cs.apply_at,
+    cs.scheduled_by_user_id,
     u.id AS user_id,
     u.name AS user_name
 FROM
@@ -626,11 +632,13 @@ LIMIT 1
 `
 
 type GetChangesetRow struct {
-	ID        uint
-	State     ChangesetState
-	AppliedAt *time.Time
-	UserID    uint
-	UserName  string
+	ID                uint
+	State             ChangesetState
+	AppliedAt         *time.Time
+	ApplyAt           *time.Time
+	ScheduledByUserID *uint
+	UserID            uint
+	UserName          string
 }
 
 func (q *Queries) GetChangeset(ctx context.Context, changesetID uint) (GetChangesetRow, error) {
@@ -640,6 +648,8 @@ func (q *Queries) GetChangeset(ctx context.Context, changesetID uint) (GetChange
 		&i.ID,
 		&i.State,
 		&i.AppliedAt,
+		&i.ApplyAt,
+		&i.ScheduledByUserID,
 		&i.UserID,
 		&i.UserName,
 	)
@@ -699,6 +709,71 @@ func (q *Queries) GetChangesetActions(ctx context.Context, changesetID uint) ([]
 	return items, nil
 }
 
+const getChangesetApprovals = `-- name: GetChangesetApprovals :many
+SELECT
+    ca.id,
+    ca.created_at,
+    u.id AS user_id,
+    u.name AS user_name,
+    ARRAY (
+        SELECT
+            ugm.user_group_id
+        FROM
+            user_group_memberships ugm
+        WHERE
+            ugm.user_id = u.id)::bigint[] AS user_group_ids
+FROM
+    changeset_actions ca
+    JOIN users u ON u.id = ca.user_id
+WHERE
+    ca.changeset_id = $1
+    AND ca.type = 'approve'
+    AND ca.id > COALESCE((
+        SELECT
+            MAX(lca.id)
+        FROM
+            changeset_actions lca
+        WHERE
+            lca.changeset_id = $1
+            AND lca.type IN ('commit', 'reopen')), 0)
+ORDER BY
+    ca.id
+`
+
+type GetChangesetApprovalsRow struct {
+	ID           uint
+	CreatedAt    time.Time
+	UserID       uint
+	UserName     string
+	UserGroupIds []uint
+}
+
+func (q *Queries) GetChangesetApprovals(ctx context.Context, changesetID uint) ([]GetChangesetApprovalsRow, error) {
+	rows, err := q.db.Query(ctx, getChangesetApprovals, changesetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetChangesetApprovalsRow
+	for rows.Next() {
+		var i GetChangesetApprovalsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UserID,
+			&i.UserName,
+			&i.UserGroupIds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getChangesetChange = `-- name: GetChangesetChange :one
 SELECT
     csc.id, csc.created_at, csc.changeset_id, csc.type, csc.kind, csc.feature_version_id, csc.previous_feature_version_id, csc.service_version_id, csc.previous_service_version_id, csc.feature_version_service_version_id, csc.key_id, csc.new_variation_value_id, csc.old_variation_value_id,
@@ -808,10 +883,15 @@ SELECT
     k.name AS key_name,
     k.valid_to AS key_valid_to,
     k.validators_updated_at AS key_validators_updated_at,
+    vt.kind AS value_type_kind,
     nv.id AS new_variation_value_id,
     nv.data AS new_variation_value_data,
+    nv.rollout_percentage AS new_variation_value_rollout_percentage,
+    nv.rollout_attribute AS new_variation_value_rollout_attribute,
     ov.id AS old_variation_value_id,
     ov.data AS old_variation_value_data,
+    ov.rollout_percentage AS old_variation_value_rollout_percentage,
+    ov.rollout_attribute AS old_variation_value_rollout_attribute,
     ov.valid_to AS old_variation_value_valid_to,
     vc.id AS variation_context_id,
     fvsv.id AS feature_version_service_version_id,
@@ -830,6 +910,7 @@ FROM
     LEFT JOIN feature_versions fv ON fv.id = csc.feature_version_id
     LEFT JOIN features f ON f.id = fv.feature_id
     LEFT JOIN keys k ON k.id = csc.key_id
+    LEFT JOIN value_types vt ON vt.id = k.value_type_id
     LEFT JOIN variation_values nv ON nv.id = csc.new_variation_value_id
     LEFT JOIN variation_values ov ON ov.id = csc.old_variation_value_id
     LEFT JOIN variation_contexts vc ON vc.id = COALESCE(nv.variation_context_id, ov.variation_context_id)
@@ -873,10 +954,15 @@ type GetChangesetChangesRow struct {
 	KeyName                                *string
 	KeyValidTo                             *time.Time
 	KeyValidatorsUpdatedAt                 *time.Time
+	ValueTypeKind                          NullValueTypeKind
 	NewVariationValueID                    *uint
 	NewVariationValueData                  *string
+	NewVariationValueRolloutPercentage     *int
+	NewVariationValueRolloutAttribute      *string
 	OldVariationValueID                    *uint
 	OldVariationValueData                  *string
+	OldVariationValueRolloutPercentage     *int
+	OldVariationValueRolloutAttribute      *string
 	OldVariationValueValidTo               *time.Time
 	VariationContextID                     *uint
 	FeatureVersionServiceVersionID         *uint
@@ -919,10 +1005,15 @@ func (q *Queries) GetChangesetChanges(ctx context.Context, changesetID uint) ([]
 			&i.KeyName,
 			&i.KeyValidTo,
 			&i.KeyValidatorsUpdatedAt,
+			&i.ValueTypeKind,
 			&i.NewVariationValueID,
 			&i.NewVariationValueData,
+			&i.NewVariationValueRolloutPercentage,
+			&i.NewVariationValueRolloutAttribute,
 			&i.OldVariationValueID,
 			&i.OldVariationValueData,
+			&i.OldVariationValueRolloutPercentage,
+			&i.OldVariationValueRolloutAttribute,
 			&i.OldVariationValueValidTo,
 			&i.VariationContextID,
 			&i.FeatureVersionServiceVersionID,
@@ -1101,7 +1192,9 @@ SELECT
     csc.id,
     csc.type,
     vv.id AS variation_value_id,
-    vv.data AS variation_value_data
+    vv.data AS variation_value_data,
+    vv.rollout_percentage AS variation_value_rollout_percentage,
+    vv.rollout_attribute AS variation_value_rollout_attribute
 FROM
     changeset_changes csc
     JOIN variation_values vv ON vv.id = csc.old_variation_value_id
@@ -1119,10 +1212,12 @@ type GetDeleteChangeForVariationContextIDParams struct {
 }
 
 type GetDeleteChangeForVariationContextIDRow struct {
-	ID                 uint
-	Type               ChangesetChangeType
-	VariationValueID   uint
-	VariationValueData string
+	ID                              uint
+	Type                            ChangesetChangeType
+	VariationValueID                uint
+	VariationValueData              string
+	VariationValueRolloutPercentage *int
+	VariationValueRolloutAttribute  *string
 }
 
 func (q *Queries) GetDeleteChangeForVariationContextID(ctx context.Context, arg GetDeleteChangeForVariationContextIDParams) (GetDeleteChangeForVariationContextIDRow, error) {
@@ -1133,13 +1228,53 @@ func (q *Queries) GetDeleteChangeForVariationContextID(ctx context.Context, arg
 		&i.Type,
 		&i.VariationValueID,
 		&i.VariationValueData,
+		&i.VariationValueRolloutPercentage,
+		&i.VariationValueRolloutAttribute,
 	)
 	return i, err
 }
 
+const getDueScheduledChangesets = `-- name: GetDueScheduledChangesets :many
+SELECT
+    cs.id,
+    cs.scheduled_by_user_id::bigint AS scheduled_by_user_id
+FROM
+    changesets cs
+WHERE
+    cs.state = 'scheduled'
+    AND cs.apply_at <= $1
+ORDER BY
+    cs.apply_at
+`
+
+type GetDueScheduledChangesetsRow struct {
+	ID                uint
+	ScheduledByUserID uint
+}
+
+func (q *Queries) GetDueScheduledChangesets(ctx context.Context, now *time.Time) ([]GetDueScheduledChangesetsRow, error) {
+	rows, err := q.db.Query(ctx, getDueScheduledChangesets, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDueScheduledChangesetsRow
+	for rows.Next() {
+		var i GetDueScheduledChangesetsRow
+		if err := rows.Scan(&i.ID, &i.ScheduledByUserID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLastAppliedChangeset = `-- name: GetLastAppliedChangeset :one
 SELECT
-    id, created_at, updated_at, user_id, state, applied_at
+    id, created_at, updated_at, user_id, state, applied_at, apply_at, scheduled_by_user_id
 FROM
     changesets
 WHERE
@@ -1159,6 +1294,37 @@ func (q *Queries) GetLastAppliedChangeset(ctx context.Context) (Changeset, error
 		&i.UserID,
 		&i.State,
 		&i.AppliedAt,
+		&i.ApplyAt,
+		&i.ScheduledByUserID,
+	)
+	return i, err
+}
+
+const getLastAppliedChangesetAsOf = `-- name: GetLastAppliedChangesetAsOf :one
+SELECT
+    id, created_at, updated_at, user_id, state, applied_at, apply_at, scheduled_by_user_id
+FROM
+    changesets
+WHERE
+    applied_at IS NOT NULL
+    AND applied_at <= $1::timestamptz
+ORDER BY
+    applied_at DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLastAppliedChangesetAsOf(ctx context.Context, asOf time.Time) (Changeset, error) {
+	row := q.db.QueryRow(ctx, getLastAppliedChangesetAsOf, asOf)
+	var i Changeset
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UserID,
+		&i.State,
+		&i.AppliedAt,
+		&i.ApplyAt,
+		&i.ScheduledByUserID,
 	)
 	return i, err
 }
@@ -1176,6 +1342,8 @@ GROUP BY
     cs.id
 HAVING
     COUNT(csc.id) > 0
+ORDER BY
+    cs.applied_at
 LIMIT 100
 `
 
@@ -1308,6 +1476,29 @@ func (q *Queries) LockChangesetForUpdate(ctx context.Context, changesetID uint)
 	return id, err
 }
 
+const scheduleChangeset = `-- name: ScheduleChangeset :exec
+UPDATE
+    changesets
+SET
+    state = 'scheduled',
+    apply_at = $1,
+    scheduled_by_user_id = $2,
+    updated_at = now()
+WHERE
+    id = $3
+`
+
+type ScheduleChangesetParams struct {
+	ApplyAt           *time.Time
+	ScheduledByUserID *uint
+	ChangesetID       uint
+}
+
+func (q *Queries) ScheduleChangeset(ctx context.Context, arg ScheduleChangesetParams) error {
+	_, err := q.db.Exec(ctx, scheduleChangeset, arg.ApplyAt, arg.ScheduledByUserID, arg.ChangesetID)
+	return err
+}
+
 const setChangesetState = `-- name: SetChangesetState :exec
 UPDATE
     changesets
@@ -1329,3 +1520,20 @@ func (q *Queries) SetChangesetState(ctx context.Context, arg SetChangesetStatePa
 	_, err := q.db.Exec(ctx, setChangesetState, arg.State, arg.AppliedAt, arg.ChangesetID)
 	return err
 }
+
+const unscheduleChangeset = `-- name: UnscheduleChangeset :exec
+UPDATE
+    changesets
+SET
+    state = 'committed',
+    apply_at = NULL,
+    scheduled_by_user_id = NULL,
+    updated_at = now()
+WHERE
+    id = $1
+`
+
+func (q *Queries) UnscheduleChangeset(ctx context.Context, changesetID uint) error {
+	_, err := q.db.Exec(ctx, unscheduleChangeset, changesetID)
+	return err
+}
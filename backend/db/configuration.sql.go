@@ -19,7 +19,10 @@ SELECT
     k.name AS key_name,
     vt.kind AS value_type,
     vv.data AS data,
-    vv.variation_context_id
+    vv.variation_context_id,
+    vv.rollout_percentage,
+    vv.rollout_attribute,
+    ev.parameter AS enum_values
 FROM
     variation_values vv
     JOIN keys k ON k.id = vv.key_id
@@ -29,6 +32,8 @@ FROM
     JOIN service_versions sv ON sv.id = fvsv.service_version_id
     JOIN value_types vt ON vt.id = k.value_type_id
     JOIN services s ON s.id = sv.service_id
+    LEFT JOIN value_validators ev ON ev.key_id = k.id
+        AND ev.validator_type = 'enum'
 WHERE
     sv.id = ANY ($1::bigint[])
     AND CASE WHEN $2 = TRUE THEN
@@ -71,6 +76,9 @@ type GetConfigurationRow struct {
 	ValueType          ValueTypeKind
 	Data               string
 	VariationContextID uint
+	RolloutPercentage  *int
+	RolloutAttribute   *string
+	EnumValues         *string
 }
 
 func (q *Queries) GetConfiguration(ctx context.Context, arg GetConfigurationParams) ([]GetConfigurationRow, error) {
@@ -96,6 +104,9 @@ func (q *Queries) GetConfiguration(ctx context.Context, arg GetConfigurationPara
 			&i.ValueType,
 			&i.Data,
 			&i.VariationContextID,
+			&i.RolloutPercentage,
+			&i.RolloutAttribute,
+			&i.EnumValues,
 		); err != nil {
 			return nil, err
 		}
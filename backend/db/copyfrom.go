@@ -216,6 +216,8 @@ func (r iteratorForCreateVariationValues) Values() ([]interface{}, error) {
 		r.rows[0].KeyID,
 		r.rows[0].VariationContextID,
 		r.rows[0].Data,
+		r.rows[0].RolloutPercentage,
+		r.rows[0].RolloutAttribute,
 	}, nil
 }
 
@@ -224,5 +226,5 @@ func (r iteratorForCreateVariationValues) Err() error {
 }
 
 func (q *Queries) CreateVariationValues(ctx context.Context, arg []CreateVariationValuesParams) (int64, error) {
-	return q.db.CopyFrom(ctx, []string{"variation_values"}, []string{"key_id", "variation_context_id", "data"}, &iteratorForCreateVariationValues{rows: arg})
+	return q.db.CopyFrom(ctx, []string{"variation_values"}, []string{"key_id", "variation_context_id", "data", "rollout_percentage", "rollout_attribute"}, &iteratorForCreateVariationValues{rows: arg})
 }
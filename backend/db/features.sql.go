@@ -514,6 +514,8 @@ const getFeatureVersionValuesData = `-- name: GetFeatureVersionValuesData :many
 SELECT
     vv.data,
     vv.variation_context_id,
+    vv.rollout_percentage,
+    vv.rollout_attribute,
     k.id AS key_id,
     k.name AS key_name,
     k.value_type_id AS key_value_type_id,
@@ -535,6 +537,8 @@ type GetFeatureVersionValuesDataParams struct {
 type GetFeatureVersionValuesDataRow struct {
 	Data               string
 	VariationContextID uint
+	RolloutPercentage  *int
+	RolloutAttribute   *string
 	KeyID              uint
 	KeyName            string
 	KeyValueTypeID     uint
@@ -553,6 +557,8 @@ func (q *Queries) GetFeatureVersionValuesData(ctx context.Context, arg GetFeatur
 		if err := rows.Scan(
 			&i.Data,
 			&i.VariationContextID,
+			&i.RolloutPercentage,
+			&i.RolloutAttribute,
 			&i.KeyID,
 			&i.KeyName,
 			&i.KeyValueTypeID,
@@ -69,9 +69,23 @@ type CreatePermissionsParams struct {
 	VariationContextID *uint
 }
 
+const createServiceAccount = `-- name: CreateServiceAccount :one
+INSERT INTO users(name, password, global_administrator, service_account, created_at)
+    VALUES ($1, '', FALSE, TRUE, now())
+RETURNING
+    id
+`
+
+func (q *Queries) CreateServiceAccount(ctx context.Context, name string) (uint, error) {
+	row := q.db.QueryRow(ctx, createServiceAccount, name)
+	var id uint
+	err := row.Scan(&id)
+	return id, err
+}
+
 const createUser = `-- name: CreateUser :one
-INSERT INTO users(name, password, global_administrator, created_at)
-    VALUES ($1, $2, $3, now())
+INSERT INTO users(name, password, global_administrator, reveal_secrets, created_at)
+    VALUES ($1, $2, $3, $4, now())
 RETURNING
     id
 `
@@ -80,10 +94,16 @@ type CreateUserParams struct {
 	Name                string
 	Password            string
 	GlobalAdministrator bool
+	RevealSecrets       bool
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (uint, error) {
-	row := q.db.QueryRow(ctx, createUser, arg.Name, arg.Password, arg.GlobalAdministrator)
+	row := q.db.QueryRow(ctx, createUser,
+		arg.Name,
+		arg.Password,
+		arg.GlobalAdministrator,
+		arg.RevealSecrets,
+	)
 	var id uint
 	err := row.Scan(&id)
 	return id, err
@@ -531,7 +551,7 @@ func (q *Queries) GetPermissionsForMembershipObject(ctx context.Context, arg Get
 
 const getUserByID = `-- name: GetUserByID :one
 SELECT
-    id, created_at, updated_at, deleted_at, name, password, global_administrator
+    id, created_at, updated_at, deleted_at, name, password, global_administrator, service_account, reveal_secrets
 FROM
     users
 WHERE
@@ -550,13 +570,15 @@ func (q *Queries) GetUserByID(ctx context.Context, userID uint) (User, error) {
 		&i.Name,
 		&i.Password,
 		&i.GlobalAdministrator,
+		&i.ServiceAccount,
+		&i.RevealSecrets,
 	)
 	return i, err
 }
 
 const getUserByName = `-- name: GetUserByName :one
 SELECT
-    id, created_at, updated_at, deleted_at, name, password, global_administrator
+    id, created_at, updated_at, deleted_at, name, password, global_administrator, service_account, reveal_secrets
 FROM
     users
 WHERE
@@ -576,6 +598,8 @@ func (q *Queries) GetUserByName(ctx context.Context, name string) (User, error)
 		&i.Name,
 		&i.Password,
 		&i.GlobalAdministrator,
+		&i.ServiceAccount,
+		&i.RevealSecrets,
 	)
 	return i, err
 }
@@ -741,17 +765,19 @@ UPDATE
     users
 SET
     global_administrator = $1,
+    reveal_secrets = $2,
     updated_at = now()
 WHERE
-    id = $2
+    id = $3
 `
 
 type UpdateUserParams struct {
 	GlobalAdministrator bool
+	RevealSecrets       bool
 	ID                  uint
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
-	_, err := q.db.Exec(ctx, updateUser, arg.GlobalAdministrator, arg.ID)
+	_, err := q.db.Exec(ctx, updateUser, arg.GlobalAdministrator, arg.RevealSecrets, arg.ID)
 	return err
 }
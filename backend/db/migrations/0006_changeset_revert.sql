@@ -0,0 +1,24 @@
+-- migrate:up
+ALTER TYPE changeset_action_type ADD VALUE 'revert';
+
+-- migrate:down
+ALTER TYPE changeset_action_type RENAME TO changeset_action_type_old;
+
+CREATE TYPE changeset_action_type AS ENUM(
+    'apply',
+    'discard',
+    'stash',
+    'commit',
+    'reopen',
+    'comment'
+);
+
+DELETE FROM changeset_actions
+WHERE type = 'revert';
+
+ALTER TABLE changeset_actions
+    ALTER COLUMN type TYPE changeset_action_type
+    USING type::text::changeset_action_type;
+
+DROP TYPE changeset_action_type_old;
+
@@ -0,0 +1,73 @@
+-- migrate:up
+ALTER TYPE changeset_state ADD VALUE 'scheduled';
+
+ALTER TYPE changeset_action_type ADD VALUE 'schedule';
+
+ALTER TYPE changeset_action_type ADD VALUE 'unschedule';
+
+ALTER TYPE changeset_action_type ADD VALUE 'schedule_failed';
+
+ALTER TABLE changesets
+    ADD COLUMN apply_at timestamp with time zone,
+    ADD COLUMN scheduled_by_user_id bigint REFERENCES users(id);
+
+CREATE INDEX idx_changesets_apply_at ON changesets(apply_at);
+
+-- migrate:down
+DROP INDEX idx_changesets_apply_at;
+
+ALTER TABLE changesets
+    DROP COLUMN scheduled_by_user_id,
+    DROP COLUMN apply_at;
+
+UPDATE
+    changesets
+SET
+    state = 'committed'
+WHERE
+    state = 'scheduled';
+
+DELETE FROM changeset_actions
+WHERE type IN ('schedule', 'unschedule', 'schedule_failed');
+
+DROP INDEX idx_changesets_one_open_per_user;
+
+ALTER TYPE changeset_state RENAME TO changeset_state_old;
+
+CREATE TYPE changeset_state AS ENUM(
+    'open',
+    'committed',
+    'applied',
+    'rejected',
+    'discarded',
+    'stashed'
+);
+
+ALTER TABLE changesets
+    ALTER COLUMN state TYPE changeset_state
+    USING state::text::changeset_state;
+
+DROP TYPE changeset_state_old;
+
+CREATE UNIQUE INDEX idx_changesets_one_open_per_user ON changesets(user_id)
+WHERE
+    state = 'open';
+
+ALTER TYPE changeset_action_type RENAME TO changeset_action_type_old;
+
+CREATE TYPE changeset_action_type AS ENUM(
+    'apply',
+    'discard',
+    'stash',
+    'commit',
+    'reopen',
+    'comment',
+    'revert'
+);
+
+ALTER TABLE changeset_actions
+    ALTER COLUMN type TYPE changeset_action_type
+    USING type::text::changeset_action_type;
+
+DROP TYPE changeset_action_type_old;
+
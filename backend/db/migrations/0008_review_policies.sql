@@ -0,0 +1,39 @@
+-- migrate:up
+ALTER TYPE changeset_action_type ADD VALUE 'approve';
+
+CREATE TABLE service_review_policies(
+    service_id bigint PRIMARY KEY REFERENCES services(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    required_approvals integer NOT NULL,
+    allow_self_approval boolean NOT NULL DEFAULT FALSE,
+    required_user_group_id bigint REFERENCES user_groups(id) ON DELETE SET NULL
+);
+
+-- migrate:down
+DROP TABLE service_review_policies;
+
+DELETE FROM changeset_actions
+WHERE type = 'approve';
+
+ALTER TYPE changeset_action_type RENAME TO changeset_action_type_old;
+
+CREATE TYPE changeset_action_type AS ENUM(
+    'apply',
+    'discard',
+    'stash',
+    'commit',
+    'reopen',
+    'comment',
+    'revert',
+    'schedule',
+    'unschedule',
+    'schedule_failed'
+);
+
+ALTER TABLE changeset_actions
+    ALTER COLUMN type TYPE changeset_action_type
+    USING type::text::changeset_action_type;
+
+DROP TYPE changeset_action_type_old;
+
@@ -0,0 +1,58 @@
+-- migrate:up
+CREATE TYPE webhook_event_type AS ENUM(
+    'changeset_committed',
+    'changeset_applied',
+    'changeset_stashed',
+    'changeset_commented'
+);
+
+CREATE TYPE webhook_delivery_status AS ENUM(
+    'pending',
+    'succeeded',
+    'failed'
+);
+
+CREATE TABLE webhooks(
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    service_id bigint NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+    url text NOT NULL,
+    secret text NOT NULL,
+    events webhook_event_type[] NOT NULL,
+    enabled boolean NOT NULL DEFAULT TRUE
+);
+
+CREATE INDEX idx_webhooks_service_id ON webhooks(service_id);
+
+CREATE TABLE webhook_deliveries(
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    webhook_id bigint NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    changeset_id bigint NOT NULL REFERENCES changesets(id) ON DELETE CASCADE,
+    event webhook_event_type NOT NULL,
+    payload jsonb NOT NULL,
+    status webhook_delivery_status NOT NULL DEFAULT 'pending',
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamp with time zone,
+    last_attempt_at timestamp with time zone,
+    response_status integer,
+    last_error text
+);
+
+CREATE INDEX idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id);
+
+CREATE INDEX idx_webhook_deliveries_next_attempt_at ON webhook_deliveries(next_attempt_at)
+WHERE
+    status = 'pending';
+
+-- migrate:down
+DROP TABLE webhook_deliveries;
+
+DROP TABLE webhooks;
+
+DROP TYPE webhook_delivery_status;
+
+DROP TYPE webhook_event_type;
+
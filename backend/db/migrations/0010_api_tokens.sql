@@ -0,0 +1,32 @@
+-- migrate:up
+CREATE TYPE api_token_scope AS ENUM(
+    'read',
+    'write'
+);
+
+ALTER TABLE users
+    ADD COLUMN service_account boolean NOT NULL DEFAULT FALSE;
+
+CREATE TABLE api_tokens(
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name text NOT NULL,
+    token_hash text NOT NULL UNIQUE,
+    token_prefix text NOT NULL,
+    scope api_token_scope NOT NULL,
+    expires_at timestamp with time zone,
+    last_used_at timestamp with time zone,
+    revoked_at timestamp with time zone
+);
+
+CREATE INDEX idx_api_tokens_user_id ON api_tokens(user_id);
+
+-- migrate:down
+DROP TABLE api_tokens;
+
+ALTER TABLE users
+    DROP COLUMN service_account;
+
+DROP TYPE api_token_scope;
+
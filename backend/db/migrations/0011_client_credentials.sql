@@ -0,0 +1,31 @@
+-- migrate:up
+CREATE TYPE client_credential_kind AS ENUM(
+    'api_key',
+    'certificate'
+);
+
+CREATE TABLE client_credentials(
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    name text NOT NULL UNIQUE,
+    kind client_credential_kind NOT NULL,
+    key_hash text UNIQUE,
+    key_prefix text,
+    certificate_subject text UNIQUE,
+    CHECK ((kind = 'api_key' AND key_hash IS NOT NULL) OR (kind = 'certificate' AND certificate_subject IS NOT NULL))
+);
+
+CREATE TABLE client_credential_services(
+    client_credential_id bigint NOT NULL REFERENCES client_credentials(id) ON DELETE CASCADE,
+    service_id bigint NOT NULL REFERENCES services(id) ON DELETE CASCADE,
+    PRIMARY KEY (client_credential_id, service_id)
+);
+
+-- migrate:down
+DROP TABLE client_credential_services;
+
+DROP TABLE client_credentials;
+
+DROP TYPE client_credential_kind;
+
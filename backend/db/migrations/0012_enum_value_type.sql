@@ -0,0 +1,23 @@
+-- migrate:up transaction:false
+ALTER TYPE value_type_kind ADD VALUE IF NOT EXISTS 'enum';
+
+ALTER TYPE value_validator_type ADD VALUE IF NOT EXISTS 'enum';
+
+DO $$
+DECLARE
+    enum_type_id bigint;
+BEGIN
+    INSERT INTO value_types(kind, name) VALUES ('enum', 'Enum')
+    RETURNING id INTO enum_type_id;
+
+    INSERT INTO value_validators(value_type_id, validator_type, parameter, error_text) VALUES
+        (enum_type_id, 'required', NULL, NULL);
+END $$;
+
+-- migrate:down
+-- enum values cannot be removed from a type, only the value type is deleted
+DELETE FROM value_validators
+WHERE value_type_id IN (SELECT id FROM value_types WHERE kind = 'enum');
+
+DELETE FROM value_types
+WHERE kind = 'enum';
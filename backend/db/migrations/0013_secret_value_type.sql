@@ -0,0 +1,27 @@
+-- migrate:up transaction:false
+ALTER TYPE value_type_kind ADD VALUE IF NOT EXISTS 'secret';
+
+ALTER TABLE users
+    ADD COLUMN reveal_secrets boolean DEFAULT false NOT NULL;
+
+DO $$
+DECLARE
+    secret_type_id bigint;
+BEGIN
+    INSERT INTO value_types(kind, name) VALUES ('secret', 'Secret')
+    RETURNING id INTO secret_type_id;
+
+    INSERT INTO value_validators(value_type_id, validator_type, parameter, error_text) VALUES
+        (secret_type_id, 'required', NULL, NULL);
+END $$;
+
+-- migrate:down
+-- enum values cannot be removed from a type, only the value type is deleted
+DELETE FROM value_validators
+WHERE value_type_id IN (SELECT id FROM value_types WHERE kind = 'secret');
+
+DELETE FROM value_types
+WHERE kind = 'secret';
+
+ALTER TABLE users
+    DROP COLUMN reveal_secrets;
@@ -0,0 +1,52 @@
+-- migrate:up transaction:false
+ALTER TYPE value_type_kind ADD VALUE IF NOT EXISTS 'string_list';
+ALTER TYPE value_type_kind ADD VALUE IF NOT EXISTS 'integer_list';
+ALTER TYPE value_type_kind ADD VALUE IF NOT EXISTS 'duration';
+ALTER TYPE value_type_kind ADD VALUE IF NOT EXISTS 'url';
+
+ALTER TYPE value_validator_type ADD VALUE IF NOT EXISTS 'valid_string_list';
+ALTER TYPE value_validator_type ADD VALUE IF NOT EXISTS 'valid_integer_list';
+ALTER TYPE value_validator_type ADD VALUE IF NOT EXISTS 'valid_duration';
+ALTER TYPE value_validator_type ADD VALUE IF NOT EXISTS 'valid_url';
+ALTER TYPE value_validator_type ADD VALUE IF NOT EXISTS 'min_items';
+ALTER TYPE value_validator_type ADD VALUE IF NOT EXISTS 'max_items';
+ALTER TYPE value_validator_type ADD VALUE IF NOT EXISTS 'min_duration';
+ALTER TYPE value_validator_type ADD VALUE IF NOT EXISTS 'max_duration';
+
+DO $$
+DECLARE
+    string_list_type_id bigint;
+    integer_list_type_id bigint;
+    duration_type_id bigint;
+    url_type_id bigint;
+BEGIN
+    INSERT INTO value_types(kind, name) VALUES ('string_list', 'String List')
+    RETURNING id INTO string_list_type_id;
+
+    INSERT INTO value_types(kind, name) VALUES ('integer_list', 'Integer List')
+    RETURNING id INTO integer_list_type_id;
+
+    INSERT INTO value_types(kind, name) VALUES ('duration', 'Duration')
+    RETURNING id INTO duration_type_id;
+
+    INSERT INTO value_types(kind, name) VALUES ('url', 'URL')
+    RETURNING id INTO url_type_id;
+
+    INSERT INTO value_validators(value_type_id, validator_type, parameter, error_text) VALUES
+        (string_list_type_id, 'required', NULL, NULL),
+        (string_list_type_id, 'valid_string_list', NULL, 'Value must be a JSON array of strings'),
+        (integer_list_type_id, 'required', NULL, NULL),
+        (integer_list_type_id, 'valid_integer_list', NULL, 'Value must be a JSON array of integers'),
+        (duration_type_id, 'required', NULL, NULL),
+        (duration_type_id, 'valid_duration', NULL, 'Value must be a duration, e.g. 1h30m'),
+        (url_type_id, 'required', NULL, NULL),
+        (url_type_id, 'valid_url', NULL, 'Value must be an absolute URL');
+END $$;
+
+-- migrate:down
+-- enum values cannot be removed from a type, only the value types are deleted
+DELETE FROM value_validators
+WHERE value_type_id IN (SELECT id FROM value_types WHERE kind IN ('string_list', 'integer_list', 'duration', 'url'));
+
+DELETE FROM value_types
+WHERE kind IN ('string_list', 'integer_list', 'duration', 'url');
@@ -0,0 +1,7 @@
+-- migrate:up transaction:false
+ALTER TYPE value_validator_type ADD VALUE IF NOT EXISTS 'cel';
+
+-- migrate:down
+-- enum values cannot be removed from a type, only the validators using it are deleted
+DELETE FROM value_validators
+WHERE validator_type = 'cel';
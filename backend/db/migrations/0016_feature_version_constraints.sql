@@ -0,0 +1,14 @@
+-- migrate:up
+CREATE TABLE feature_version_constraints(
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    feature_version_id bigint NOT NULL REFERENCES feature_versions(id) ON DELETE CASCADE,
+    expression text NOT NULL,
+    error_text text
+);
+
+CREATE INDEX idx_feature_version_constraints_feature_version_id ON feature_version_constraints(feature_version_id);
+
+-- migrate:down
+DROP TABLE feature_version_constraints;
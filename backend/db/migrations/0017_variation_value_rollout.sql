@@ -0,0 +1,11 @@
+-- migrate:up
+ALTER TABLE variation_values
+    ADD COLUMN rollout_percentage integer,
+    ADD COLUMN rollout_attribute text,
+    ADD CONSTRAINT variation_values_rollout_check CHECK ((rollout_percentage IS NULL AND rollout_attribute IS NULL) OR (rollout_percentage BETWEEN 0 AND 100 AND rollout_attribute IS NOT NULL));
+
+-- migrate:down
+ALTER TABLE variation_values
+    DROP CONSTRAINT variation_values_rollout_check,
+    DROP COLUMN rollout_percentage,
+    DROP COLUMN rollout_attribute;
@@ -10,15 +10,62 @@ import (
 	"time"
 )
 
+type ApiTokenScope string
+
+const (
+	ApiTokenScopeRead  ApiTokenScope = "read"
+	ApiTokenScopeWrite ApiTokenScope = "write"
+)
+
+func (e *ApiTokenScope) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ApiTokenScope(s)
+	case string:
+		*e = ApiTokenScope(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ApiTokenScope: %T", src)
+	}
+	return nil
+}
+
+type NullApiTokenScope struct {
+	ApiTokenScope ApiTokenScope
+	Valid         bool // Valid is true if ApiTokenScope is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullApiTokenScope) Scan(value interface{}) error {
+	if value == nil {
+		ns.ApiTokenScope, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ApiTokenScope.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullApiTokenScope) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ApiTokenScope), nil
+}
+
 type ChangesetActionType string
 
 const (
-	ChangesetActionTypeApply   ChangesetActionType = "apply"
-	ChangesetActionTypeDiscard ChangesetActionType = "discard"
-	ChangesetActionTypeStash   ChangesetActionType = "stash"
-	ChangesetActionTypeCommit  ChangesetActionType = "commit"
-	ChangesetActionTypeReopen  ChangesetActionType = "reopen"
-	ChangesetActionTypeComment ChangesetActionType = "comment"
+	ChangesetActionTypeApply          ChangesetActionType = "apply"
+	ChangesetActionTypeDiscard        ChangesetActionType = "discard"
+	ChangesetActionTypeStash          ChangesetActionType = "stash"
+	ChangesetActionTypeCommit         ChangesetActionType = "commit"
+	ChangesetActionTypeReopen         ChangesetActionType = "reopen"
+	ChangesetActionTypeComment        ChangesetActionType = "comment"
+	ChangesetActionTypeRevert         ChangesetActionType = "revert"
+	ChangesetActionTypeSchedule       ChangesetActionType = "schedule"
+	ChangesetActionTypeUnschedule     ChangesetActionType = "unschedule"
+	ChangesetActionTypeScheduleFailed ChangesetActionType = "schedule_failed"
+	ChangesetActionTypeApprove        ChangesetActionType = "approve"
 )
 
 func (e *ChangesetActionType) Scan(src interface{}) error {
@@ -153,6 +200,7 @@ const (
 	ChangesetStateRejected  ChangesetState = "rejected"
 	ChangesetStateDiscarded ChangesetState = "discarded"
 	ChangesetStateStashed   ChangesetState = "stashed"
+	ChangesetStateScheduled ChangesetState = "scheduled"
 )
 
 func (e *ChangesetState) Scan(src interface{}) error {
@@ -190,6 +238,48 @@ func (ns NullChangesetState) Value() (driver.Value, error) {
 	return string(ns.ChangesetState), nil
 }
 
+type ClientCredentialKind string
+
+const (
+	ClientCredentialKindApiKey      ClientCredentialKind = "api_key"
+	ClientCredentialKindCertificate ClientCredentialKind = "certificate"
+)
+
+func (e *ClientCredentialKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ClientCredentialKind(s)
+	case string:
+		*e = ClientCredentialKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ClientCredentialKind: %T", src)
+	}
+	return nil
+}
+
+type NullClientCredentialKind struct {
+	ClientCredentialKind ClientCredentialKind
+	Valid                bool // Valid is true if ClientCredentialKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullClientCredentialKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.ClientCredentialKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ClientCredentialKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullClientCredentialKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ClientCredentialKind), nil
+}
+
 type PermissionKind string
 
 const (
@@ -279,11 +369,17 @@ func (ns NullPermissionLevel) Value() (driver.Value, error) {
 type ValueTypeKind string
 
 const (
-	ValueTypeKindString  ValueTypeKind = "string"
-	ValueTypeKindInteger ValueTypeKind = "integer"
-	ValueTypeKindDecimal ValueTypeKind = "decimal"
-	ValueTypeKindBoolean ValueTypeKind = "boolean"
-	ValueTypeKindJson    ValueTypeKind = "json"
+	ValueTypeKindString      ValueTypeKind = "string"
+	ValueTypeKindInteger     ValueTypeKind = "integer"
+	ValueTypeKindDecimal     ValueTypeKind = "decimal"
+	ValueTypeKindBoolean     ValueTypeKind = "boolean"
+	ValueTypeKindJson        ValueTypeKind = "json"
+	ValueTypeKindEnum        ValueTypeKind = "enum"
+	ValueTypeKindSecret      ValueTypeKind = "secret"
+	ValueTypeKindStringList  ValueTypeKind = "string_list"
+	ValueTypeKindIntegerList ValueTypeKind = "integer_list"
+	ValueTypeKindDuration    ValueTypeKind = "duration"
+	ValueTypeKindUrl         ValueTypeKind = "url"
 )
 
 func (e *ValueTypeKind) Scan(src interface{}) error {
@@ -324,19 +420,29 @@ func (ns NullValueTypeKind) Value() (driver.Value, error) {
 type ValueValidatorType string
 
 const (
-	ValueValidatorTypeRequired     ValueValidatorType = "required"
-	ValueValidatorTypeMinLength    ValueValidatorType = "min_length"
-	ValueValidatorTypeMaxLength    ValueValidatorType = "max_length"
-	ValueValidatorTypeMin          ValueValidatorType = "min"
-	ValueValidatorTypeMax          ValueValidatorType = "max"
-	ValueValidatorTypeMinDecimal   ValueValidatorType = "min_decimal"
-	ValueValidatorTypeMaxDecimal   ValueValidatorType = "max_decimal"
-	ValueValidatorTypeRegex        ValueValidatorType = "regex"
-	ValueValidatorTypeJsonSchema   ValueValidatorType = "json_schema"
-	ValueValidatorTypeValidJson    ValueValidatorType = "valid_json"
-	ValueValidatorTypeValidInteger ValueValidatorType = "valid_integer"
-	ValueValidatorTypeValidDecimal ValueValidatorType = "valid_decimal"
-	ValueValidatorTypeValidRegex   ValueValidatorType = "valid_regex"
+	ValueValidatorTypeRequired         ValueValidatorType = "required"
+	ValueValidatorTypeMinLength        ValueValidatorType = "min_length"
+	ValueValidatorTypeMaxLength        ValueValidatorType = "max_length"
+	ValueValidatorTypeMin              ValueValidatorType = "min"
+	ValueValidatorTypeMax              ValueValidatorType = "max"
+	ValueValidatorTypeMinDecimal       ValueValidatorType = "min_decimal"
+	ValueValidatorTypeMaxDecimal       ValueValidatorType = "max_decimal"
+	ValueValidatorTypeRegex            ValueValidatorType = "regex"
+	ValueValidatorTypeJsonSchema       ValueValidatorType = "json_schema"
+	ValueValidatorTypeValidJson        ValueValidatorType = "valid_json"
+	ValueValidatorTypeValidInteger     ValueValidatorType = "valid_integer"
+	ValueValidatorTypeValidDecimal     ValueValidatorType = "valid_decimal"
+	ValueValidatorTypeValidRegex       ValueValidatorType = "valid_regex"
+	ValueValidatorTypeEnum             ValueValidatorType = "enum"
+	ValueValidatorTypeValidStringList  ValueValidatorType = "valid_string_list"
+	ValueValidatorTypeValidIntegerList ValueValidatorType = "valid_integer_list"
+	ValueValidatorTypeValidDuration    ValueValidatorType = "valid_duration"
+	ValueValidatorTypeValidUrl         ValueValidatorType = "valid_url"
+	ValueValidatorTypeMinItems         ValueValidatorType = "min_items"
+	ValueValidatorTypeMaxItems         ValueValidatorType = "max_items"
+	ValueValidatorTypeMinDuration      ValueValidatorType = "min_duration"
+	ValueValidatorTypeMaxDuration      ValueValidatorType = "max_duration"
+	ValueValidatorTypeCel              ValueValidatorType = "cel"
 )
 
 func (e *ValueValidatorType) Scan(src interface{}) error {
@@ -374,13 +480,115 @@ func (ns NullValueValidatorType) Value() (driver.Value, error) {
 	return string(ns.ValueValidatorType), nil
 }
 
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+func (e *WebhookDeliveryStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebhookDeliveryStatus(s)
+	case string:
+		*e = WebhookDeliveryStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebhookDeliveryStatus: %T", src)
+	}
+	return nil
+}
+
+type NullWebhookDeliveryStatus struct {
+	WebhookDeliveryStatus WebhookDeliveryStatus
+	Valid                 bool // Valid is true if WebhookDeliveryStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebhookDeliveryStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebhookDeliveryStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebhookDeliveryStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebhookDeliveryStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebhookDeliveryStatus), nil
+}
+
+type WebhookEventType string
+
+const (
+	WebhookEventTypeChangesetCommitted WebhookEventType = "changeset_committed"
+	WebhookEventTypeChangesetApplied   WebhookEventType = "changeset_applied"
+	WebhookEventTypeChangesetStashed   WebhookEventType = "changeset_stashed"
+	WebhookEventTypeChangesetCommented WebhookEventType = "changeset_commented"
+)
+
+func (e *WebhookEventType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = WebhookEventType(s)
+	case string:
+		*e = WebhookEventType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for WebhookEventType: %T", src)
+	}
+	return nil
+}
+
+type NullWebhookEventType struct {
+	WebhookEventType WebhookEventType
+	Valid            bool // Valid is true if WebhookEventType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullWebhookEventType) Scan(value interface{}) error {
+	if value == nil {
+		ns.WebhookEventType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.WebhookEventType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullWebhookEventType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.WebhookEventType), nil
+}
+
+type ApiToken struct {
+	ID          uint
+	CreatedAt   time.Time
+	UserID      uint
+	Name        string
+	TokenHash   string
+	TokenPrefix string
+	Scope       ApiTokenScope
+	ExpiresAt   *time.Time
+	LastUsedAt  *time.Time
+	RevokedAt   *time.Time
+}
+
 type Changeset struct {
-	ID        uint
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	UserID    uint
-	State     ChangesetState
-	AppliedAt *time.Time
+	ID                uint
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	UserID            uint
+	State             ChangesetState
+	AppliedAt         *time.Time
+	ApplyAt           *time.Time
+	ScheduledByUserID *uint
 }
 
 type ChangesetAction struct {
@@ -408,6 +616,22 @@ type ChangesetChange struct {
 	OldVariationValueID            *uint
 }
 
+type ClientCredential struct {
+	ID                 uint
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	Name               string
+	Kind               ClientCredentialKind
+	KeyHash            *string
+	KeyPrefix          *string
+	CertificateSubject *string
+}
+
+type ClientCredentialService struct {
+	ClientCredentialID uint
+	ServiceID          uint
+}
+
 type Feature struct {
 	ID          uint
 	CreatedAt   time.Time
@@ -426,6 +650,15 @@ type FeatureVersion struct {
 	FeatureID uint
 }
 
+type FeatureVersionConstraint struct {
+	ID               uint
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	FeatureVersionID uint
+	Expression       string
+	ErrorText        *string
+}
+
 type FeatureVersionServiceVersion struct {
 	ID               uint
 	CreatedAt        time.Time
@@ -470,6 +703,15 @@ type Service struct {
 	ServiceTypeID uint
 }
 
+type ServiceReviewPolicy struct {
+	ServiceID           uint
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	RequiredApprovals   int
+	AllowSelfApproval   bool
+	RequiredUserGroupID *uint
+}
+
 type ServiceType struct {
 	ID        uint
 	CreatedAt time.Time
@@ -504,6 +746,8 @@ type User struct {
 	Name                string
 	Password            string
 	GlobalAdministrator bool
+	ServiceAccount      bool
+	RevealSecrets       bool
 }
 
 type UserGroup struct {
@@ -570,4 +814,33 @@ type VariationValue struct {
 	KeyID              uint
 	VariationContextID uint
 	Data               string
+	RolloutPercentage  *int
+	RolloutAttribute   *string
+}
+
+type Webhook struct {
+	ID        uint
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ServiceID uint
+	Url       string
+	Secret    string
+	Events    []WebhookEventType
+	Enabled   bool
+}
+
+type WebhookDelivery struct {
+	ID             uint
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	WebhookID      uint
+	ChangesetID    uint
+	Event          WebhookEventType
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  *time.Time
+	LastAttemptAt  *time.Time
+	ResponseStatus *int
+	LastError      *string
 }
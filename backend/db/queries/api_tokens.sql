@@ -0,0 +1,66 @@
+-- name: GetApiTokens :many
+SELECT
+    id,
+    created_at,
+    name,
+    token_prefix,
+    scope,
+    expires_at,
+    last_used_at,
+    revoked_at
+FROM
+    api_tokens
+WHERE
+    user_id = @user_id
+ORDER BY
+    id DESC;
+
+-- name: GetApiToken :one
+SELECT
+    *
+FROM
+    api_tokens
+WHERE
+    id = @token_id
+LIMIT 1;
+
+-- name: GetActiveApiTokenByHash :one
+SELECT
+    at.id,
+    at.user_id,
+    at.scope
+FROM
+    api_tokens at
+    JOIN users u ON u.id = at.user_id
+WHERE
+    at.token_hash = @token_hash
+    AND at.revoked_at IS NULL
+    AND (at.expires_at IS NULL
+        OR at.expires_at > now())
+    AND u.deleted_at IS NULL
+LIMIT 1;
+
+-- name: CreateApiToken :one
+INSERT INTO api_tokens(user_id, name, token_hash, token_prefix, scope, expires_at)
+    VALUES (@user_id, @name, @token_hash, @token_prefix, @scope, sqlc.narg('expires_at'))
+RETURNING
+    id;
+
+-- name: RevokeApiToken :exec
+UPDATE
+    api_tokens
+SET
+    revoked_at = now()
+WHERE
+    id = @token_id
+    AND revoked_at IS NULL;
+
+-- name: TouchApiToken :exec
+UPDATE
+    api_tokens
+SET
+    last_used_at = now()
+WHERE
+    id = @token_id
+    AND (last_used_at IS NULL
+        OR last_used_at < now() - interval '1 minute');
@@ -128,6 +128,8 @@ SELECT
     cs.id,
     cs.state,
     cs.applied_at,
+    cs.apply_at,
+    cs.scheduled_by_user_id,
     u.id AS user_id,
     u.name AS user_name
 FROM
@@ -156,6 +158,40 @@ SET
 WHERE
     id = @changeset_id;
 
+-- name: ScheduleChangeset :exec
+UPDATE
+    changesets
+SET
+    state = 'scheduled',
+    apply_at = @apply_at,
+    scheduled_by_user_id = @scheduled_by_user_id,
+    updated_at = now()
+WHERE
+    id = @changeset_id;
+
+-- name: UnscheduleChangeset :exec
+UPDATE
+    changesets
+SET
+    state = 'committed',
+    apply_at = NULL,
+    scheduled_by_user_id = NULL,
+    updated_at = now()
+WHERE
+    id = @changeset_id;
+
+-- name: GetDueScheduledChangesets :many
+SELECT
+    cs.id,
+    cs.scheduled_by_user_id::bigint AS scheduled_by_user_id
+FROM
+    changesets cs
+WHERE
+    cs.state = 'scheduled'
+    AND cs.apply_at <= @now
+ORDER BY
+    cs.apply_at;
+
 -- name: AddChangesetAction :exec
 INSERT INTO changeset_actions(changeset_id, user_id, type, comment)
     VALUES (@changeset_id, @user_id, @type, sqlc.narg('comment'));
@@ -176,6 +212,36 @@ WHERE
 ORDER BY
     ca.id;
 
+-- name: GetChangesetApprovals :many
+SELECT
+    ca.id,
+    ca.created_at,
+    u.id AS user_id,
+    u.name AS user_name,
+    ARRAY (
+        SELECT
+            ugm.user_group_id
+        FROM
+            user_group_memberships ugm
+        WHERE
+            ugm.user_id = u.id)::bigint[] AS user_group_ids
+FROM
+    changeset_actions ca
+    JOIN users u ON u.id = ca.user_id
+WHERE
+    ca.changeset_id = @changeset_id
+    AND ca.type = 'approve'
+    AND ca.id > COALESCE((
+        SELECT
+            MAX(lca.id)
+        FROM
+            changeset_actions lca
+        WHERE
+            lca.changeset_id = @changeset_id
+            AND lca.type IN ('commit', 'reopen')), 0)
+ORDER BY
+    ca.id;
+
 -- name: GetChangesetChanges :many
 WITH links AS (
     SELECT
@@ -234,10 +300,15 @@ SELECT
     k.name AS key_name,
     k.valid_to AS key_valid_to,
     k.validators_updated_at AS key_validators_updated_at,
+    vt.kind AS value_type_kind,
     nv.id AS new_variation_value_id,
     nv.data AS new_variation_value_data,
+    nv.rollout_percentage AS new_variation_value_rollout_percentage,
+    nv.rollout_attribute AS new_variation_value_rollout_attribute,
     ov.id AS old_variation_value_id,
     ov.data AS old_variation_value_data,
+    ov.rollout_percentage AS old_variation_value_rollout_percentage,
+    ov.rollout_attribute AS old_variation_value_rollout_attribute,
     ov.valid_to AS old_variation_value_valid_to,
     vc.id AS variation_context_id,
     fvsv.id AS feature_version_service_version_id,
@@ -256,6 +327,7 @@ FROM
     LEFT JOIN feature_versions fv ON fv.id = csc.feature_version_id
     LEFT JOIN features f ON f.id = fv.feature_id
     LEFT JOIN keys k ON k.id = csc.key_id
+    LEFT JOIN value_types vt ON vt.id = k.value_type_id
     LEFT JOIN variation_values nv ON nv.id = csc.new_variation_value_id
     LEFT JOIN variation_values ov ON ov.id = csc.old_variation_value_id
     LEFT JOIN variation_contexts vc ON vc.id = COALESCE(nv.variation_context_id, ov.variation_context_id)
@@ -378,7 +450,9 @@ SELECT
     csc.id,
     csc.type,
     vv.id AS variation_value_id,
-    vv.data AS variation_value_data
+    vv.data AS variation_value_data,
+    vv.rollout_percentage AS variation_value_rollout_percentage,
+    vv.rollout_attribute AS variation_value_rollout_attribute
 FROM
     changeset_changes csc
     JOIN variation_values vv ON vv.id = csc.old_variation_value_id
@@ -463,6 +537,8 @@ GROUP BY
     cs.id
 HAVING
     COUNT(csc.id) > 0
+ORDER BY
+    cs.applied_at
 LIMIT 100;
 
 -- name: GetLastAppliedChangeset :one
@@ -476,6 +552,18 @@ ORDER BY
     applied_at DESC
 LIMIT 1;
 
+-- name: GetLastAppliedChangesetAsOf :one
+SELECT
+    *
+FROM
+    changesets
+WHERE
+    applied_at IS NOT NULL
+    AND applied_at <= @as_of::timestamptz
+ORDER BY
+    applied_at DESC
+LIMIT 1;
+
 -- name: GetChangeHistory :many
 SELECT
     csc.id,
@@ -497,6 +585,7 @@ SELECT
     fv.version AS feature_version,
     k.id AS key_id,
     k.name AS key_name,
+    vt.kind AS value_type_kind,
     nv.id AS new_variation_value_id,
     nv.data AS new_variation_value_data,
     ov.id AS old_variation_value_id,
@@ -513,6 +602,7 @@ FROM
     LEFT JOIN feature_versions fv ON fv.id = csc.feature_version_id
     LEFT JOIN features f ON f.id = fv.feature_id
     LEFT JOIN keys k ON k.id = csc.key_id
+    LEFT JOIN value_types vt ON vt.id = k.value_type_id
     LEFT JOIN variation_values nv ON nv.id = csc.new_variation_value_id
     LEFT JOIN variation_values ov ON ov.id = csc.old_variation_value_id
     LEFT JOIN variation_contexts vc ON vc.id = COALESCE(nv.variation_context_id, ov.variation_context_id)
@@ -0,0 +1,107 @@
+-- name: GetClientCredentials :many
+SELECT
+    cc.id,
+    cc.created_at,
+    cc.updated_at,
+    cc.name,
+    cc.kind,
+    cc.key_prefix,
+    cc.certificate_subject,
+    COALESCE(array_agg(s.id ORDER BY s.name) FILTER (WHERE s.id IS NOT NULL), '{}')::bigint[] AS service_ids,
+    COALESCE(array_agg(s.name ORDER BY s.name) FILTER (WHERE s.id IS NOT NULL), '{}')::text[] AS service_names
+FROM
+    client_credentials cc
+    LEFT JOIN client_credential_services ccs ON ccs.client_credential_id = cc.id
+    LEFT JOIN services s ON s.id = ccs.service_id
+GROUP BY
+    cc.id
+ORDER BY
+    cc.name;
+
+-- name: GetClientCredential :one
+SELECT
+    cc.id,
+    cc.created_at,
+    cc.updated_at,
+    cc.name,
+    cc.kind,
+    cc.key_prefix,
+    cc.certificate_subject,
+    COALESCE(array_agg(s.id ORDER BY s.name) FILTER (WHERE s.id IS NOT NULL), '{}')::bigint[] AS service_ids,
+    COALESCE(array_agg(s.name ORDER BY s.name) FILTER (WHERE s.id IS NOT NULL), '{}')::text[] AS service_names
+FROM
+    client_credentials cc
+    LEFT JOIN client_credential_services ccs ON ccs.client_credential_id = cc.id
+    LEFT JOIN services s ON s.id = ccs.service_id
+WHERE
+    cc.id = @client_credential_id
+GROUP BY
+    cc.id;
+
+-- name: GetClientCredentialByName :one
+SELECT
+    id
+FROM
+    client_credentials
+WHERE
+    name = @name
+LIMIT 1;
+
+-- name: GetClientCredentialGrantByKeyHash :one
+SELECT
+    cc.id,
+    cc.name,
+    COALESCE(array_agg(s.name) FILTER (WHERE s.id IS NOT NULL), '{}')::text[] AS service_names
+FROM
+    client_credentials cc
+    LEFT JOIN client_credential_services ccs ON ccs.client_credential_id = cc.id
+    LEFT JOIN services s ON s.id = ccs.service_id
+WHERE
+    cc.kind = 'api_key'
+    AND cc.key_hash = @key_hash
+GROUP BY
+    cc.id;
+
+-- name: GetClientCredentialGrantByCertificateSubject :one
+SELECT
+    cc.id,
+    cc.name,
+    COALESCE(array_agg(s.name) FILTER (WHERE s.id IS NOT NULL), '{}')::text[] AS service_names
+FROM
+    client_credentials cc
+    LEFT JOIN client_credential_services ccs ON ccs.client_credential_id = cc.id
+    LEFT JOIN services s ON s.id = ccs.service_id
+WHERE
+    cc.kind = 'certificate'
+    AND cc.certificate_subject = @certificate_subject
+GROUP BY
+    cc.id;
+
+-- name: CreateClientCredential :one
+INSERT INTO client_credentials(name, kind, key_hash, key_prefix, certificate_subject)
+    VALUES (@name, @kind, sqlc.narg('key_hash'), sqlc.narg('key_prefix'), sqlc.narg('certificate_subject'))
+RETURNING
+    id;
+
+-- name: UpdateClientCredential :exec
+UPDATE
+    client_credentials
+SET
+    name = @name,
+    updated_at = now()
+WHERE
+    id = @client_credential_id;
+
+-- name: DeleteClientCredentialServices :exec
+DELETE FROM client_credential_services
+WHERE client_credential_id = @client_credential_id;
+
+-- name: AddClientCredentialServices :exec
+INSERT INTO client_credential_services(client_credential_id, service_id)
+SELECT
+    @client_credential_id::bigint,
+    unnest(@service_ids::bigint[]);
+
+-- name: DeleteClientCredential :exec
+DELETE FROM client_credentials
+WHERE id = @client_credential_id;
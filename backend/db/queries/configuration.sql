@@ -7,7 +7,10 @@ SELECT
     k.name AS key_name,
     vt.kind AS value_type,
     vv.data AS data,
-    vv.variation_context_id
+    vv.variation_context_id,
+    vv.rollout_percentage,
+    vv.rollout_attribute,
+    ev.parameter AS enum_values
 FROM
     variation_values vv
     JOIN keys k ON k.id = vv.key_id
@@ -17,6 +20,8 @@ FROM
     JOIN service_versions sv ON sv.id = fvsv.service_version_id
     JOIN value_types vt ON vt.id = k.value_type_id
     JOIN services s ON s.id = sv.service_id
+    LEFT JOIN value_validators ev ON ev.key_id = k.id
+        AND ev.validator_type = 'enum'
 WHERE
     sv.id = ANY (@service_version_ids::bigint[])
     AND CASE WHEN @is_applied = TRUE THEN
@@ -0,0 +1,51 @@
+-- name: GetFeatureVersionConstraints :many
+SELECT
+    *
+FROM
+    feature_version_constraints
+WHERE
+    feature_version_id = @feature_version_id
+ORDER BY
+    id;
+
+-- name: GetFeatureVersionConstraint :one
+SELECT
+    *
+FROM
+    feature_version_constraints
+WHERE
+    id = @constraint_id
+LIMIT 1;
+
+-- name: CreateFeatureVersionConstraint :one
+INSERT INTO feature_version_constraints(feature_version_id, expression, error_text)
+    VALUES (@feature_version_id, @expression, sqlc.narg('error_text'))
+RETURNING
+    id;
+
+-- name: UpdateFeatureVersionConstraint :exec
+UPDATE
+    feature_version_constraints
+SET
+    expression = @expression,
+    error_text = sqlc.narg('error_text'),
+    updated_at = now()
+WHERE
+    id = @constraint_id;
+
+-- name: DeleteFeatureVersionConstraint :exec
+DELETE FROM feature_version_constraints
+WHERE id = @constraint_id;
+
+-- name: CopyFeatureVersionConstraints :exec
+INSERT INTO feature_version_constraints(feature_version_id, expression, error_text)
+SELECT
+    @new_feature_version_id::bigint,
+    expression,
+    error_text
+FROM
+    feature_version_constraints
+WHERE
+    feature_version_id = @feature_version_id
+ORDER BY
+    id;
@@ -271,6 +271,8 @@ WHERE id = @feature_id;
 SELECT
     vv.data,
     vv.variation_context_id,
+    vv.rollout_percentage,
+    vv.rollout_attribute,
     k.id AS key_id,
     k.name AS key_name,
     k.value_type_id AS key_value_type_id,
@@ -185,8 +185,14 @@ SELECT
     LIMIT sqlc.arg('limit')::integer OFFSET sqlc.arg('offset')::integer;
 
 -- name: CreateUser :one
-INSERT INTO users(name, password, global_administrator, created_at)
-    VALUES (@name, @password, @global_administrator, now())
+INSERT INTO users(name, password, global_administrator, reveal_secrets, created_at)
+    VALUES (@name, @password, @global_administrator, @reveal_secrets, now())
+RETURNING
+    id;
+
+-- name: CreateServiceAccount :one
+INSERT INTO users(name, password, global_administrator, service_account, created_at)
+    VALUES (@name, '', FALSE, TRUE, now())
 RETURNING
     id;
 
@@ -199,6 +205,7 @@ UPDATE
     users
 SET
     global_administrator = @global_administrator,
+    reveal_secrets = @reveal_secrets,
     updated_at = now()
 WHERE
     id = @id;
@@ -0,0 +1,44 @@
+-- name: GetServiceReviewPolicy :one
+SELECT
+    srp.service_id,
+    srp.required_approvals,
+    srp.allow_self_approval,
+    srp.required_user_group_id,
+    ug.name AS required_user_group_name
+FROM
+    service_review_policies srp
+    LEFT JOIN user_groups ug ON ug.id = srp.required_user_group_id
+WHERE
+    srp.service_id = @service_id
+LIMIT 1;
+
+-- name: GetServiceReviewPolicies :many
+SELECT
+    srp.service_id,
+    s.name AS service_name,
+    srp.required_approvals,
+    srp.allow_self_approval,
+    srp.required_user_group_id,
+    ug.name AS required_user_group_name
+FROM
+    service_review_policies srp
+    JOIN services s ON s.id = srp.service_id
+    LEFT JOIN user_groups ug ON ug.id = srp.required_user_group_id
+WHERE
+    srp.service_id = ANY (@service_ids::bigint[])
+ORDER BY
+    s.name;
+
+-- name: UpsertServiceReviewPolicy :exec
+INSERT INTO service_review_policies(service_id, required_approvals, allow_self_approval, required_user_group_id)
+    VALUES (@service_id, @required_approvals, @allow_self_approval, sqlc.narg('required_user_group_id'))
+ON CONFLICT (service_id)
+    DO UPDATE SET
+        required_approvals = EXCLUDED.required_approvals,
+        allow_self_approval = EXCLUDED.allow_self_approval,
+        required_user_group_id = EXCLUDED.required_user_group_id,
+        updated_at = now();
+
+-- name: DeleteServiceReviewPolicy :exec
+DELETE FROM service_review_policies
+WHERE service_id = @service_id;
@@ -3,21 +3,23 @@ DELETE FROM variation_values
 WHERE id = @variation_value_id;
 
 -- name: CreateVariationValue :one
-INSERT INTO variation_values(key_id, variation_context_id, data)
-    VALUES (@key_id, @variation_context_id, @data)
+INSERT INTO variation_values(key_id, variation_context_id, data, rollout_percentage, rollout_attribute)
+    VALUES (@key_id, @variation_context_id, @data, @rollout_percentage, @rollout_attribute)
 RETURNING
     id;
 
 -- name: CreateVariationValues :copyfrom
-INSERT INTO variation_values(key_id, variation_context_id, data)
-    VALUES ($1, $2, $3);
+INSERT INTO variation_values(key_id, variation_context_id, data, rollout_percentage, rollout_attribute)
+    VALUES ($1, $2, $3, $4, $5);
 
 -- name: UpdateVariationValue :exec
 UPDATE
     variation_values
 SET
     data = @data,
-    variation_context_id = @variation_context_id
+    variation_context_id = @variation_context_id,
+    rollout_percentage = @rollout_percentage,
+    rollout_attribute = @rollout_attribute
 WHERE
     id = @variation_value_id;
 
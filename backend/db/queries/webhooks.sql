@@ -0,0 +1,140 @@
+-- name: GetWebhooks :many
+SELECT
+    w.id,
+    w.created_at,
+    w.url,
+    w.events::text[] AS events,
+    w.enabled
+FROM
+    webhooks w
+WHERE
+    w.service_id = @service_id
+ORDER BY
+    w.id;
+
+-- name: GetWebhook :one
+SELECT
+    w.id,
+    w.created_at,
+    w.service_id,
+    w.url,
+    w.events::text[] AS events,
+    w.enabled
+FROM
+    webhooks w
+WHERE
+    w.id = @webhook_id
+LIMIT 1;
+
+-- name: CreateWebhook :one
+INSERT INTO webhooks(service_id, url, secret, events, enabled)
+    VALUES (@service_id, @url, @secret, @events::text[]::webhook_event_type[], @enabled)
+RETURNING
+    id;
+
+-- name: UpdateWebhook :exec
+UPDATE
+    webhooks
+SET
+    url = @url,
+    secret = COALESCE(sqlc.narg('secret'), secret),
+    events = @events::text[]::webhook_event_type[],
+    enabled = @enabled,
+    updated_at = now()
+WHERE
+    id = @webhook_id;
+
+-- name: DeleteWebhook :exec
+DELETE FROM webhooks
+WHERE id = @webhook_id;
+
+-- name: EnqueueWebhookDeliveries :exec
+INSERT INTO webhook_deliveries(webhook_id, changeset_id, event, payload, next_attempt_at)
+SELECT
+    w.id,
+    @changeset_id,
+    @event,
+    @payload::jsonb || jsonb_build_object('service', s.name),
+    now()
+FROM
+    webhooks w
+    JOIN services s ON s.id = w.service_id
+WHERE
+    w.enabled
+    AND @event = ANY (w.events)
+    AND w.service_id IN (
+        SELECT
+            sv.service_id
+        FROM
+            changeset_changes csc
+            JOIN service_versions sv ON sv.id = csc.service_version_id
+        WHERE
+            csc.changeset_id = @changeset_id);
+
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE
+    webhook_deliveries wd
+SET
+    attempts = wd.attempts + 1,
+    last_attempt_at = sqlc.arg('now')::timestamptz,
+    next_attempt_at = sqlc.arg('lease_until')::timestamptz,
+    updated_at = now()
+FROM
+    webhooks w
+WHERE
+    w.id = wd.webhook_id
+    AND wd.id IN (
+        SELECT
+            id
+        FROM
+            webhook_deliveries
+        WHERE
+            status = 'pending'
+            AND next_attempt_at <= sqlc.arg('now')::timestamptz
+        ORDER BY
+            next_attempt_at
+        LIMIT sqlc.arg('limit')::integer
+        FOR UPDATE
+            SKIP LOCKED)
+RETURNING
+    wd.id,
+    wd.event,
+    wd.payload,
+    wd.attempts,
+    w.url,
+    w.secret;
+
+-- name: SetWebhookDeliveryResult :exec
+UPDATE
+    webhook_deliveries
+SET
+    status = @status,
+    next_attempt_at = sqlc.narg('next_attempt_at'),
+    response_status = sqlc.narg('response_status'),
+    last_error = sqlc.narg('last_error'),
+    updated_at = now()
+WHERE
+    id = @delivery_id;
+
+-- name: GetWebhookDeliveries :many
+SELECT
+    wd.id,
+    wd.created_at,
+    wd.changeset_id,
+    wd.event,
+    wd.payload,
+    wd.status,
+    wd.attempts,
+    wd.next_attempt_at,
+    wd.last_attempt_at,
+    wd.response_status,
+    wd.last_error,
+    COUNT(*) OVER ()::integer AS total_count
+FROM
+    webhook_deliveries wd
+WHERE
+    wd.webhook_id = @webhook_id
+ORDER BY
+    wd.id DESC
+LIMIT sqlc.arg('limit')::integer OFFSET sqlc.arg('offset')::integer;
+
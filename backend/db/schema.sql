@@ -10,6 +10,16 @@ SET xmloption = content;
 SET client_min_messages = warning;
 SET row_security = off;
 
+--
+-- Name: api_token_scope; Type: TYPE; Schema: public; Owner: -
+--
+
+CREATE TYPE public.api_token_scope AS ENUM (
+    'read',
+    'write'
+);
+
+
 --
 -- Name: changeset_action_type; Type: TYPE; Schema: public; Owner: -
 --
@@ -20,7 +30,12 @@ CREATE TYPE public.changeset_action_type AS ENUM (
     'stash',
     'commit',
     'reopen',
-    'comment'
+    'comment',
+    'revert',
+    'schedule',
+    'unschedule',
+    'schedule_failed',
+    'approve'
 );
 
 
@@ -58,7 +73,18 @@ CREATE TYPE public.changeset_state AS ENUM (
     'applied',
     'rejected',
     'discarded',
-    'stashed'
+    'stashed',
+    'scheduled'
+);
+
+
+--
+-- Name: client_credential_kind; Type: TYPE; Schema: public; Owner: -
+--
+
+CREATE TYPE public.client_credential_kind AS ENUM (
+    'api_key',
+    'certificate'
 );
 
 
@@ -93,7 +119,13 @@ CREATE TYPE public.value_type_kind AS ENUM (
     'integer',
     'decimal',
     'boolean',
-    'json'
+    'json',
+    'enum',
+    'secret',
+    'string_list',
+    'integer_list',
+    'duration',
+    'url'
 );
 
 
@@ -114,7 +146,40 @@ CREATE TYPE public.value_validator_type AS ENUM (
     'valid_json',
     'valid_integer',
     'valid_decimal',
-    'valid_regex'
+    'valid_regex',
+    'enum',
+    'valid_string_list',
+    'valid_integer_list',
+    'valid_duration',
+    'valid_url',
+    'min_items',
+    'max_items',
+    'min_duration',
+    'max_duration',
+    'cel'
+);
+
+
+--
+-- Name: webhook_delivery_status; Type: TYPE; Schema: public; Owner: -
+--
+
+CREATE TYPE public.webhook_delivery_status AS ENUM (
+    'pending',
+    'succeeded',
+    'failed'
+);
+
+
+--
+-- Name: webhook_event_type; Type: TYPE; Schema: public; Owner: -
+--
+
+CREATE TYPE public.webhook_event_type AS ENUM (
+    'changeset_committed',
+    'changeset_applied',
+    'changeset_stashed',
+    'changeset_commented'
 );
 
 
@@ -282,6 +347,43 @@ SET default_tablespace = '';
 
 SET default_table_access_method = heap;
 
+--
+-- Name: api_tokens; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.api_tokens (
+    id bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    user_id bigint NOT NULL,
+    name text NOT NULL,
+    token_hash text NOT NULL,
+    token_prefix text NOT NULL,
+    scope public.api_token_scope NOT NULL,
+    expires_at timestamp with time zone,
+    last_used_at timestamp with time zone,
+    revoked_at timestamp with time zone
+);
+
+
+--
+-- Name: api_tokens_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.api_tokens_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: api_tokens_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.api_tokens_id_seq OWNED BY public.api_tokens.id;
+
+
 --
 -- Name: changeset_actions; Type: TABLE; Schema: public; Owner: -
 --
@@ -365,7 +467,9 @@ CREATE TABLE public.changesets (
     updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
     user_id bigint NOT NULL,
     state public.changeset_state NOT NULL,
-    applied_at timestamp with time zone
+    applied_at timestamp with time zone,
+    apply_at timestamp with time zone,
+    scheduled_by_user_id bigint
 );
 
 
@@ -388,6 +492,85 @@ CREATE SEQUENCE public.changesets_id_seq
 ALTER SEQUENCE public.changesets_id_seq OWNED BY public.changesets.id;
 
 
+--
+-- Name: client_credential_services; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.client_credential_services (
+    client_credential_id bigint NOT NULL,
+    service_id bigint NOT NULL
+);
+
+
+--
+-- Name: client_credentials; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.client_credentials (
+    id bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    name text NOT NULL,
+    kind public.client_credential_kind NOT NULL,
+    key_hash text,
+    key_prefix text,
+    certificate_subject text,
+    CONSTRAINT client_credentials_check CHECK ((((kind = 'api_key'::public.client_credential_kind) AND (key_hash IS NOT NULL)) OR ((kind = 'certificate'::public.client_credential_kind) AND (certificate_subject IS NOT NULL))))
+);
+
+
+--
+-- Name: client_credentials_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.client_credentials_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: client_credentials_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.client_credentials_id_seq OWNED BY public.client_credentials.id;
+
+
+--
+-- Name: feature_version_constraints; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.feature_version_constraints (
+    id bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    feature_version_id bigint NOT NULL,
+    expression text NOT NULL,
+    error_text text
+);
+
+
+--
+-- Name: feature_version_constraints_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.feature_version_constraints_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: feature_version_constraints_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.feature_version_constraints_id_seq OWNED BY public.feature_version_constraints.id;
+
+
 --
 -- Name: feature_version_service_versions; Type: TABLE; Schema: public; Owner: -
 --
@@ -571,6 +754,20 @@ CREATE TABLE public.schema_migrations (
 );
 
 
+--
+-- Name: service_review_policies; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.service_review_policies (
+    service_id bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    required_approvals integer NOT NULL,
+    allow_self_approval boolean DEFAULT false NOT NULL,
+    required_user_group_id bigint
+);
+
+
 --
 -- Name: service_type_variation_properties; Type: TABLE; Schema: public; Owner: -
 --
@@ -756,7 +953,9 @@ CREATE TABLE public.users (
     deleted_at timestamp with time zone,
     name text NOT NULL,
     password text NOT NULL,
-    global_administrator boolean DEFAULT false NOT NULL
+    global_administrator boolean DEFAULT false NOT NULL,
+    service_account boolean DEFAULT false NOT NULL,
+    reveal_secrets boolean DEFAULT false NOT NULL
 );
 
 
@@ -958,7 +1157,10 @@ CREATE TABLE public.variation_values (
     valid_to timestamp with time zone,
     key_id bigint NOT NULL,
     variation_context_id bigint NOT NULL,
-    data text NOT NULL
+    data text NOT NULL,
+    rollout_percentage integer,
+    rollout_attribute text,
+    CONSTRAINT variation_values_rollout_check CHECK ((((rollout_percentage IS NULL) AND (rollout_attribute IS NULL)) OR (((rollout_percentage >= 0) AND (rollout_percentage <= 100)) AND (rollout_attribute IS NOT NULL))))
 );
 
 
@@ -981,6 +1183,88 @@ CREATE SEQUENCE public.variation_values_id_seq
 ALTER SEQUENCE public.variation_values_id_seq OWNED BY public.variation_values.id;
 
 
+--
+-- Name: webhook_deliveries; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.webhook_deliveries (
+    id bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    webhook_id bigint NOT NULL,
+    changeset_id bigint NOT NULL,
+    event public.webhook_event_type NOT NULL,
+    payload jsonb NOT NULL,
+    status public.webhook_delivery_status DEFAULT 'pending'::public.webhook_delivery_status NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    next_attempt_at timestamp with time zone,
+    last_attempt_at timestamp with time zone,
+    response_status integer,
+    last_error text
+);
+
+
+--
+-- Name: webhook_deliveries_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.webhook_deliveries_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: webhook_deliveries_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.webhook_deliveries_id_seq OWNED BY public.webhook_deliveries.id;
+
+
+--
+-- Name: webhooks; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.webhooks (
+    id bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    service_id bigint NOT NULL,
+    url text NOT NULL,
+    secret text NOT NULL,
+    events public.webhook_event_type[] NOT NULL,
+    enabled boolean DEFAULT true NOT NULL
+);
+
+
+--
+-- Name: webhooks_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.webhooks_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: webhooks_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.webhooks_id_seq OWNED BY public.webhooks.id;
+
+
+--
+-- Name: api_tokens id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.api_tokens ALTER COLUMN id SET DEFAULT nextval('public.api_tokens_id_seq'::regclass);
+
+
 --
 -- Name: changeset_actions id; Type: DEFAULT; Schema: public; Owner: -
 --
@@ -1002,6 +1286,20 @@ ALTER TABLE ONLY public.changeset_changes ALTER COLUMN id SET DEFAULT nextval('p
 ALTER TABLE ONLY public.changesets ALTER COLUMN id SET DEFAULT nextval('public.changesets_id_seq'::regclass);
 
 
+--
+-- Name: client_credentials id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.client_credentials ALTER COLUMN id SET DEFAULT nextval('public.client_credentials_id_seq'::regclass);
+
+
+--
+-- Name: feature_version_constraints id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.feature_version_constraints ALTER COLUMN id SET DEFAULT nextval('public.feature_version_constraints_id_seq'::regclass);
+
+
 --
 -- Name: feature_version_service_versions id; Type: DEFAULT; Schema: public; Owner: -
 --
@@ -1121,6 +1419,36 @@ ALTER TABLE ONLY public.variation_property_values ALTER COLUMN id SET DEFAULT ne
 ALTER TABLE ONLY public.variation_values ALTER COLUMN id SET DEFAULT nextval('public.variation_values_id_seq'::regclass);
 
 
+--
+-- Name: webhook_deliveries id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_deliveries ALTER COLUMN id SET DEFAULT nextval('public.webhook_deliveries_id_seq'::regclass);
+
+
+--
+-- Name: webhooks id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhooks ALTER COLUMN id SET DEFAULT nextval('public.webhooks_id_seq'::regclass);
+
+
+--
+-- Name: api_tokens api_tokens_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.api_tokens
+    ADD CONSTRAINT api_tokens_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: api_tokens api_tokens_token_hash_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.api_tokens
+    ADD CONSTRAINT api_tokens_token_hash_key UNIQUE (token_hash);
+
+
 --
 -- Name: changeset_actions changeset_actions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -1145,6 +1473,54 @@ ALTER TABLE ONLY public.changesets
     ADD CONSTRAINT changesets_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: client_credential_services client_credential_services_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.client_credential_services
+    ADD CONSTRAINT client_credential_services_pkey PRIMARY KEY (client_credential_id, service_id);
+
+
+--
+-- Name: client_credentials client_credentials_certificate_subject_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.client_credentials
+    ADD CONSTRAINT client_credentials_certificate_subject_key UNIQUE (certificate_subject);
+
+
+--
+-- Name: client_credentials client_credentials_key_hash_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.client_credentials
+    ADD CONSTRAINT client_credentials_key_hash_key UNIQUE (key_hash);
+
+
+--
+-- Name: client_credentials client_credentials_name_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.client_credentials
+    ADD CONSTRAINT client_credentials_name_key UNIQUE (name);
+
+
+--
+-- Name: client_credentials client_credentials_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.client_credentials
+    ADD CONSTRAINT client_credentials_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: feature_version_constraints feature_version_constraints_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.feature_version_constraints
+    ADD CONSTRAINT feature_version_constraints_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: feature_version_service_versions feature_version_service_versions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -1209,6 +1585,14 @@ ALTER TABLE ONLY public.schema_migrations
     ADD CONSTRAINT schema_migrations_pkey PRIMARY KEY (version);
 
 
+--
+-- Name: service_review_policies service_review_policies_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.service_review_policies
+    ADD CONSTRAINT service_review_policies_pkey PRIMARY KEY (service_id);
+
+
 --
 -- Name: service_type_variation_properties service_type_variation_proper_service_type_id_variation_pro_key; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -1385,6 +1769,29 @@ ALTER TABLE ONLY public.variation_values
     ADD CONSTRAINT variation_values_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: webhook_deliveries webhook_deliveries_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: webhooks webhooks_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhooks
+    ADD CONSTRAINT webhooks_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: idx_api_tokens_user_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_api_tokens_user_id ON public.api_tokens USING btree (user_id);
+
+
 --
 -- Name: idx_changeset_changes_feature_version_create; Type: INDEX; Schema: public; Owner: -
 --
@@ -1462,6 +1869,13 @@ CREATE INDEX idx_changeset_changes_variation_value_old ON public.changeset_chang
 CREATE INDEX idx_changesets_applied_at ON public.changesets USING btree (applied_at);
 
 
+--
+-- Name: idx_changesets_apply_at; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_changesets_apply_at ON public.changesets USING btree (apply_at);
+
+
 --
 -- Name: idx_changesets_one_open_per_user; Type: INDEX; Schema: public; Owner: -
 --
@@ -1469,6 +1883,13 @@ CREATE INDEX idx_changesets_applied_at ON public.changesets USING btree (applied
 CREATE UNIQUE INDEX idx_changesets_one_open_per_user ON public.changesets USING btree (user_id) WHERE (state = 'open'::public.changeset_state);
 
 
+--
+-- Name: idx_feature_version_constraints_feature_version_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_feature_version_constraints_feature_version_id ON public.feature_version_constraints USING btree (feature_version_id);
+
+
 --
 -- Name: idx_feature_version_service_versions_unique; Type: INDEX; Schema: public; Owner: -
 --
@@ -1616,6 +2037,35 @@ CREATE INDEX idx_variation_values_valid_from ON public.variation_values USING bt
 CREATE INDEX idx_variation_values_valid_to ON public.variation_values USING btree (valid_to);
 
 
+--
+-- Name: idx_webhook_deliveries_next_attempt_at; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_webhook_deliveries_next_attempt_at ON public.webhook_deliveries USING btree (next_attempt_at) WHERE (status = 'pending'::public.webhook_delivery_status);
+
+
+--
+-- Name: idx_webhook_deliveries_webhook_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_webhook_deliveries_webhook_id ON public.webhook_deliveries USING btree (webhook_id);
+
+
+--
+-- Name: idx_webhooks_service_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_webhooks_service_id ON public.webhooks USING btree (service_id);
+
+
+--
+-- Name: api_tokens api_tokens_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.api_tokens
+    ADD CONSTRAINT api_tokens_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
 --
 -- Name: changeset_actions changeset_actions_changeset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -1704,6 +2154,14 @@ ALTER TABLE ONLY public.changeset_changes
     ADD CONSTRAINT changeset_changes_service_version_id_fkey FOREIGN KEY (service_version_id) REFERENCES public.service_versions(id) ON DELETE CASCADE;
 
 
+--
+-- Name: changesets changesets_scheduled_by_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.changesets
+    ADD CONSTRAINT changesets_scheduled_by_user_id_fkey FOREIGN KEY (scheduled_by_user_id) REFERENCES public.users(id);
+
+
 --
 -- Name: changesets changesets_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -1712,6 +2170,30 @@ ALTER TABLE ONLY public.changesets
     ADD CONSTRAINT changesets_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id);
 
 
+--
+-- Name: client_credential_services client_credential_services_client_credential_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.client_credential_services
+    ADD CONSTRAINT client_credential_services_client_credential_id_fkey FOREIGN KEY (client_credential_id) REFERENCES public.client_credentials(id) ON DELETE CASCADE;
+
+
+--
+-- Name: client_credential_services client_credential_services_service_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.client_credential_services
+    ADD CONSTRAINT client_credential_services_service_id_fkey FOREIGN KEY (service_id) REFERENCES public.services(id) ON DELETE CASCADE;
+
+
+--
+-- Name: feature_version_constraints feature_version_constraints_feature_version_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.feature_version_constraints
+    ADD CONSTRAINT feature_version_constraints_feature_version_id_fkey FOREIGN KEY (feature_version_id) REFERENCES public.feature_versions(id) ON DELETE CASCADE;
+
+
 --
 -- Name: feature_version_service_versions feature_version_service_versions_feature_version_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -1808,6 +2290,22 @@ ALTER TABLE ONLY public.permissions
     ADD CONSTRAINT permissions_variation_context_id_fkey FOREIGN KEY (variation_context_id) REFERENCES public.variation_contexts(id) ON DELETE CASCADE;
 
 
+--
+-- Name: service_review_policies service_review_policies_required_user_group_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.service_review_policies
+    ADD CONSTRAINT service_review_policies_required_user_group_id_fkey FOREIGN KEY (required_user_group_id) REFERENCES public.user_groups(id) ON DELETE SET NULL;
+
+
+--
+-- Name: service_review_policies service_review_policies_service_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.service_review_policies
+    ADD CONSTRAINT service_review_policies_service_id_fkey FOREIGN KEY (service_id) REFERENCES public.services(id) ON DELETE CASCADE;
+
+
 --
 -- Name: service_type_variation_properties service_type_variation_properties_service_type_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -1920,6 +2418,30 @@ ALTER TABLE ONLY public.variation_values
     ADD CONSTRAINT variation_values_variation_context_id_fkey FOREIGN KEY (variation_context_id) REFERENCES public.variation_contexts(id);
 
 
+--
+-- Name: webhook_deliveries webhook_deliveries_changeset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_changeset_id_fkey FOREIGN KEY (changeset_id) REFERENCES public.changesets(id) ON DELETE CASCADE;
+
+
+--
+-- Name: webhook_deliveries webhook_deliveries_webhook_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_webhook_id_fkey FOREIGN KEY (webhook_id) REFERENCES public.webhooks(id) ON DELETE CASCADE;
+
+
+--
+-- Name: webhooks webhooks_service_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.webhooks
+    ADD CONSTRAINT webhooks_service_id_fkey FOREIGN KEY (service_id) REFERENCES public.services(id) ON DELETE CASCADE;
+
+
 --
 -- PostgreSQL database dump complete
 --
@@ -1934,4 +2456,16 @@ INSERT INTO public.schema_migrations (version) VALUES
     ('0002'),
     ('0003'),
     ('0004'),
-    ('0005');
+    ('0005'),
+    ('0006'),
+    ('0007'),
+    ('0008'),
+    ('0009'),
+    ('0010'),
+    ('0011'),
+    ('0012'),
+    ('0013'),
+    ('0014'),
+    ('0015'),
+    ('0016'),
+    ('0017');
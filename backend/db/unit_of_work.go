@@ -3,11 +3,62 @@ package db
 import (
 	"context"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type UnitOfWorkRunner interface {
 	Run(ctx context.Context, fn func(tx *Queries) error) error
+	// RunInContext runs fn in a transaction carried by the context. Queries made with the context, including units of
+	// work run inside fn, join the transaction, so everything fn does is committed or rolled back together.
+	RunInContext(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txContextKey struct{}
+
+func txFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+// InTransaction returns whether the context carries a transaction, which may still be rolled back
+func InTransaction(ctx context.Context) bool {
+	_, ok := txFromContext(ctx)
+	return ok
+}
+
+// ContextDBTX runs queries in the transaction of the context if there is one, otherwise on the pool
+type ContextDBTX struct {
+	db *pgxpool.Pool
+}
+
+func NewContextDBTX(db *pgxpool.Pool) *ContextDBTX {
+	return &ContextDBTX{db: db}
+}
+
+func (c *ContextDBTX) dbtx(ctx context.Context) DBTX {
+	if tx, ok := txFromContext(ctx); ok {
+		return tx
+	}
+
+	return c.db
+}
+
+func (c *ContextDBTX) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return c.dbtx(ctx).Exec(ctx, sql, args...)
+}
+
+func (c *ContextDBTX) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return c.dbtx(ctx).Query(ctx, sql, args...)
+}
+
+func (c *ContextDBTX) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return c.dbtx(ctx).QueryRow(ctx, sql, args...)
+}
+
+func (c *ContextDBTX) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return c.dbtx(ctx).CopyFrom(ctx, tableName, columnNames, rowSrc)
 }
 
 type PgxUnitOfWorkRunner struct {
@@ -20,6 +71,11 @@ func NewPgxUnitOfWorkRunner(db *pgxpool.Pool, queries *Queries) UnitOfWorkRunner
 }
 
 func (u *PgxUnitOfWorkRunner) Run(ctx context.Context, fn func(tx *Queries) error) error {
+	if tx, ok := txFromContext(ctx); ok {
+		// the outer unit of work commits or rolls back
+		return fn(u.queries.WithTx(tx))
+	}
+
 	tx, err := u.db.Begin(ctx)
 	if err != nil {
 		return err
@@ -35,3 +91,23 @@ func (u *PgxUnitOfWorkRunner) Run(ctx context.Context, fn func(tx *Queries) erro
 
 	return tx.Commit(ctx)
 }
+
+func (u *PgxUnitOfWorkRunner) RunInContext(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := u.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback(ctx)
+
+	err = fn(context.WithValue(ctx, txContextKey{}, tx))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -11,8 +11,8 @@ import (
 )
 
 const createVariationValue = `-- name: CreateVariationValue :one
-INSERT INTO variation_values(key_id, variation_context_id, data)
-    VALUES ($1, $2, $3)
+INSERT INTO variation_values(key_id, variation_context_id, data, rollout_percentage, rollout_attribute)
+    VALUES ($1, $2, $3, $4, $5)
 RETURNING
     id
 `
@@ -21,10 +21,18 @@ type CreateVariationValueParams struct {
 	KeyID              uint
 	VariationContextID uint
 	Data               string
+	RolloutPercentage  *int
+	RolloutAttribute   *string
 }
 
 func (q *Queries) CreateVariationValue(ctx context.Context, arg CreateVariationValueParams) (uint, error) {
-	row := q.db.QueryRow(ctx, createVariationValue, arg.KeyID, arg.VariationContextID, arg.Data)
+	row := q.db.QueryRow(ctx, createVariationValue,
+		arg.KeyID,
+		arg.VariationContextID,
+		arg.Data,
+		arg.RolloutPercentage,
+		arg.RolloutAttribute,
+	)
 	var id uint
 	err := row.Scan(&id)
 	return id, err
@@ -34,6 +42,8 @@ type CreateVariationValuesParams struct {
 	KeyID              uint
 	VariationContextID uint
 	Data               string
+	RolloutPercentage  *int
+	RolloutAttribute   *string
 }
 
 const deleteVariationValue = `-- name: DeleteVariationValue :exec
@@ -67,7 +77,7 @@ func (q *Queries) EndValueValidity(ctx context.Context, arg EndValueValidityPara
 
 const getVariationValue = `-- name: GetVariationValue :one
 SELECT
-    vv.id, vv.valid_from, vv.valid_to, vv.key_id, vv.variation_context_id, vv.data
+    vv.id, vv.valid_from, vv.valid_to, vv.key_id, vv.variation_context_id, vv.data, vv.rollout_percentage, vv.rollout_attribute
 FROM
     variation_values vv
     JOIN valid_variation_values_in_changeset($1) vvv ON vvv.id = vv.id
@@ -91,6 +101,8 @@ func (q *Queries) GetVariationValue(ctx context.Context, arg GetVariationValuePa
 		&i.KeyID,
 		&i.VariationContextID,
 		&i.Data,
+		&i.RolloutPercentage,
+		&i.RolloutAttribute,
 	)
 	return i, err
 }
@@ -122,7 +134,7 @@ func (q *Queries) GetVariationValueIDByVariationContextID(ctx context.Context, a
 
 const getVariationValuesForKey = `-- name: GetVariationValuesForKey :many
 SELECT
-    vv.id, vv.valid_from, vv.valid_to, vv.key_id, vv.variation_context_id, vv.data
+    vv.id, vv.valid_from, vv.valid_to, vv.key_id, vv.variation_context_id, vv.data, vv.rollout_percentage, vv.rollout_attribute
 FROM
     variation_values vv
     JOIN valid_variation_values_in_changeset($1) vvv ON vvv.id = vv.id
@@ -151,6 +163,8 @@ func (q *Queries) GetVariationValuesForKey(ctx context.Context, arg GetVariation
 			&i.KeyID,
 			&i.VariationContextID,
 			&i.Data,
+			&i.RolloutPercentage,
+			&i.RolloutAttribute,
 		); err != nil {
 			return nil, err
 		}
@@ -164,7 +178,7 @@ func (q *Queries) GetVariationValuesForKey(ctx context.Context, arg GetVariation
 
 const getVariationValuesForWipFeatureVersion = `-- name: GetVariationValuesForWipFeatureVersion :many
 SELECT
-    vv.id, vv.valid_from, vv.valid_to, vv.key_id, vv.variation_context_id, vv.data
+    vv.id, vv.valid_from, vv.valid_to, vv.key_id, vv.variation_context_id, vv.data, vv.rollout_percentage, vv.rollout_attribute
 FROM
     variation_values vv
     JOIN keys k ON k.id = vv.key_id
@@ -188,6 +202,8 @@ func (q *Queries) GetVariationValuesForWipFeatureVersion(ctx context.Context, fe
 			&i.KeyID,
 			&i.VariationContextID,
 			&i.Data,
+			&i.RolloutPercentage,
+			&i.RolloutAttribute,
 		); err != nil {
 			return nil, err
 		}
@@ -223,18 +239,28 @@ UPDATE
     variation_values
 SET
     data = $1,
-    variation_context_id = $2
+    variation_context_id = $2,
+    rollout_percentage = $3,
+    rollout_attribute = $4
 WHERE
-    id = $3
+    id = $5
 `
 
 type UpdateVariationValueParams struct {
 	Data               string
 	VariationContextID uint
+	RolloutPercentage  *int
+	RolloutAttribute   *string
 	VariationValueID   uint
 }
 
 func (q *Queries) UpdateVariationValue(ctx context.Context, arg UpdateVariationValueParams) error {
-	_, err := q.db.Exec(ctx, updateVariationValue, arg.Data, arg.VariationContextID, arg.VariationValueID)
+	_, err := q.db.Exec(ctx, updateVariationValue,
+		arg.Data,
+		arg.VariationContextID,
+		arg.RolloutPercentage,
+		arg.RolloutAttribute,
+		arg.VariationValueID,
+	)
 	return err
 }
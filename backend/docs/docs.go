@@ -880,6 +880,67 @@ const docTemplate = `{
                 }
             }
         },
+        "/changesets/{changeset_id}/approve": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve a committed changeset by ID. Approvals are required by services with a review policy.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Approve a changeset",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Changeset ID",
+                        "name": "changeset_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
         "/changesets/{changeset_id}/changes/{change_id}": {
             "delete": {
                 "security": [
@@ -1429,18 +1490,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/changesets/{changeset_id}/stash": {
-            "put": {
+        "/changesets/{changeset_id}/revert": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Stash a changeset by ID",
+                "description": "Add the inverse of all changes of an applied changeset to the current changeset",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Stash a changeset",
+                "summary": "Revert a changeset",
                 "parameters": [
                     {
                         "type": "integer",
@@ -1451,8 +1512,11 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1487,59 +1551,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/configuration": {
-            "get": {
-                "description": "Get configuration",
+        "/changesets/{changeset_id}/schedule": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Schedule a changeset to be applied at the given time",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get configuration",
+                "summary": "Schedule a changeset",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "Changeset ID",
-                        "name": "changesetId",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "multi",
-                        "example": "TestService:1",
-                        "description": "Service versions in format service:version",
-                        "name": "services[]",
-                        "in": "query",
+                        "name": "changeset_id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "enum": [
-                            "production"
-                        ],
-                        "type": "string",
-                        "description": "Mode",
-                        "name": "mode",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "multi",
-                        "example": "env:prod",
-                        "description": "Variation",
-                        "name": "variation[]",
-                        "in": "query"
+                        "description": "Schedule",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ScheduleChangesetRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/configuration.ConfigurationDto"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1547,6 +1594,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1560,47 +1619,30 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/configuration/changesets": {
-            "get": {
-                "description": "Get next changesets",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel the scheduled application of a changeset and move it back to the committed state",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get next changesets",
+                "summary": "Unschedule a changeset",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "After changeset ID",
-                        "name": "after",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Service versions",
-                        "name": "services[]",
-                        "in": "query",
+                        "description": "Changeset ID",
+                        "name": "changeset_id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "type": "integer"
-                                }
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1608,6 +1650,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1623,33 +1677,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/configuration/variation-hierarchy": {
-            "get": {
-                "description": "Get variation hierarchy",
+        "/changesets/{changeset_id}/stash": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stash a changeset by ID",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get variation hierarchy",
+                "summary": "Stash a changeset",
                 "parameters": [
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "multi",
-                        "example": "TestService:1",
-                        "description": "Service versions",
-                        "name": "services[]",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "Changeset ID",
+                        "name": "changeset_id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/configuration.VariationHierarchyDto"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1657,6 +1708,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1672,61 +1735,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/membership": {
+        "/client-credentials": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get list of users and groups",
+                "description": "Get credentials of configuration clients and the services they are allowed to read",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get users and groups",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
+                "summary": "Get client credentials",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/clientcredential.ClientCredentialDto"
+                            }
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Page size",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Name",
-                        "name": "name",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Type",
-                        "name": "type",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/core.PaginatedResult-membership_MembershipObjectDto"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -1738,31 +1776,29 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/membership/groups": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new group",
+                "description": "Create a credential for a configuration client. API keys are returned only once, clients send them in the \"x-api-key\" gRPC metadata or \"X-Api-Key\" header.\nCertificate credentials match the common name of a verified client certificate.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create a group",
+                "summary": "Create client credential",
                 "parameters": [
                     {
-                        "description": "Group",
-                        "name": "group",
+                        "description": "Client credential",
+                        "name": "credential",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateGroupRequest"
+                            "$ref": "#/definitions/handler.CreateClientCredentialRequest"
                         }
                     }
                 ],
@@ -1770,7 +1806,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateResponse"
+                            "$ref": "#/definitions/clientcredential.CreatedClientCredentialDto"
                         }
                     },
                     "400": {
@@ -1791,6 +1827,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
                     "422": {
                         "description": "Unprocessable Entity",
                         "schema": {
@@ -1806,23 +1848,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/membership/groups/{group_id}": {
+        "/client-credentials/{client_credential_id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a group by ID",
+                "description": "Get client credential by ID",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get a group",
+                "summary": "Get client credential",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Group ID",
-                        "name": "group_id",
+                        "description": "Client credential ID",
+                        "name": "client_credential_id",
                         "in": "path",
                         "required": true
                     }
@@ -1831,58 +1873,8 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/membership.GroupDto"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/clientcredential.ClientCredentialDto"
                         }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Delete a group by ID",
-                "produces": [
-                    "application/json"
-                ],
-                "summary": "Delete a group",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Group ID",
-                        "name": "group_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -1915,97 +1907,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/membership/groups/{group_id}/users": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get group users by ID",
-                "produces": [
+                "description": "Update name and allowed services of a client credential",
+                "consumes": [
                     "application/json"
                 ],
-                "summary": "Get group users",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Group ID",
-                        "name": "group_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Page size",
-                        "name": "pageSize",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/core.PaginatedResult-membership_GroupUserDto"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    }
-                }
-            }
-        },
-        "/membership/groups/{group_id}/users/{user_id}": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Add a user to a group",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Add a user to a group",
+                "summary": "Update client credential",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
+                        "description": "Client credential ID",
+                        "name": "client_credential_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Group ID",
-                        "name": "group_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Client credential",
+                        "name": "credential",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateClientCredentialRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -2036,6 +1968,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2050,23 +1988,16 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove a user from a group",
+                "description": "Delete a client credential, the client immediately loses access",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Remove a user from a group",
+                "summary": "Delete client credential",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Group ID",
-                        "name": "group_id",
+                        "description": "Client credential ID",
+                        "name": "client_credential_id",
                         "in": "path",
                         "required": true
                     }
@@ -2108,36 +2039,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/membership/permissions": {
+        "/configuration": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get permissions for a service, feature, key, or variation",
+                "description": "Get configuration. Secret values are revealed to clients with client credentials and users allowed to reveal them, anonymous clients don't get secret keys.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get permissions",
+                "summary": "Get configuration",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service version ID",
-                        "name": "serviceVersionId",
+                        "description": "Changeset ID",
+                        "name": "changesetId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "TestService:1",
+                        "description": "Service versions in format service:version",
+                        "name": "services[]",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Feature version ID",
-                        "name": "featureVersionId",
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Point in time (RFC3339), resolves to the last changeset applied at or before it",
+                        "name": "asOf",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Key ID",
-                        "name": "keyId",
+                        "enum": [
+                            "production"
+                        ],
+                        "type": "string",
+                        "description": "Mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return exactly one resolved value per key, requires the variation to specify all properties",
+                        "name": "resolve",
                         "in": "query"
                     },
                     {
@@ -2146,7 +2093,7 @@ const docTemplate = `{
                             "type": "string"
                         },
                         "collectionFormat": "multi",
-                        "example": "1:prod",
+                        "example": "env:prod",
                         "description": "Variation",
                         "name": "variation[]",
                         "in": "query"
@@ -2156,10 +2103,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/membership.EntityPermissionDto"
-                            }
+                            "$ref": "#/definitions/configuration.ConfigurationDto"
                         }
                     },
                     "400": {
@@ -2168,12 +2112,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2187,50 +2125,50 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Add a permission to a user or group",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/configuration/changesets": {
+            "get": {
+                "description": "Get next changesets",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Add a permission",
+                "summary": "Get next changesets",
                 "parameters": [
                     {
-                        "description": "Permission",
-                        "name": "permission",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.AddPermissionRequest"
-                        }
+                        "type": "integer",
+                        "description": "After changeset ID",
+                        "name": "after",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Service versions",
+                        "name": "services[]",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "type": "integer"
+                                }
+                            }
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -2241,12 +2179,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2256,95 +2188,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/membership/permissions/{permission_id}": {
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Remove a permission from a group",
+        "/configuration/delta": {
+            "get": {
+                "description": "Get only the keys and values that were added, changed or removed between two changesets. Secret keys are handled like in Get configuration.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Remove a permission from a group",
+                "summary": "Get configuration delta",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Permission ID",
-                        "name": "permission_id",
-                        "in": "path",
+                        "description": "Changeset ID to compute the delta from",
+                        "name": "fromChangesetId",
+                        "in": "query",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
+                    {
+                        "type": "integer",
+                        "description": "Changeset ID to compute the delta to, defaults to the last applied changeset",
+                        "name": "changesetId",
+                        "in": "query"
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "TestService:1",
+                        "description": "Service versions in format service:version",
+                        "name": "services[]",
+                        "in": "query",
+                        "required": true
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    }
-                }
-            }
-        },
-        "/membership/users": {
-            "post": {
-                "security": [
                     {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "summary": "Create a user",
-                "parameters": [
+                        "enum": [
+                            "production"
+                        ],
+                        "type": "string",
+                        "description": "Mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
                     {
-                        "description": "User",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.CreateUserRequest"
-                        }
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "env:prod",
+                        "description": "Variation",
+                        "name": "variation[]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateResponse"
+                            "$ref": "#/definitions/configuration.ConfigurationDeltaDto"
                         }
                     },
                     "400": {
@@ -2353,20 +2255,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -2380,32 +2270,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/membership/users/{user_id}": {
+        "/configuration/diff": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get a user by ID",
+                "description": "Get the added, removed and changed values between two changesets or points in time",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get a user",
+                "summary": "Get configuration diff",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Changeset ID or time (RFC3339) to compute the diff from",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Changeset ID or time (RFC3339) to compute the diff to, defaults to the last applied changeset",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "TestService:1",
+                        "description": "Service versions in format service:version",
+                        "name": "services[]",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "production"
+                        ],
+                        "type": "string",
+                        "description": "Mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "env:prod",
+                        "description": "Variation",
+                        "name": "variation[]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/membership.UserDto"
+                            "$ref": "#/definitions/configuration.ConfigurationDiffDto"
                         }
                     },
                     "400": {
@@ -2414,12 +2337,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2433,57 +2350,85 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Update a user by ID",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/configuration/explain": {
+            "get": {
+                "description": "Explain how the value of a key is resolved for a variation, listing all candidate values with their rank and whether they matched",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Update a user",
+                "summary": "Explain configuration value",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "TestService:1",
+                        "description": "Service versions in format service:version",
+                        "name": "services[]",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "User",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UpdateUserRequest"
-                        }
+                        "type": "string",
+                        "description": "Feature name",
+                        "name": "feature",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key name",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Changeset ID",
+                        "name": "changesetId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Point in time (RFC3339), resolves to the last changeset applied at or before it",
+                        "name": "asOf",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "production"
+                        ],
+                        "type": "string",
+                        "description": "Mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "env:prod",
+                        "description": "Variation",
+                        "name": "variation[]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/configuration.ExplainValueDto"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -2501,39 +2446,86 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Delete a user by ID",
+            }
+        },
+        "/configuration/export": {
+            "get": {
+                "description": "Export the resolved configuration as a file with one entry per key named Feature.Key. Fails if the configuration contains secrets the caller is not allowed to reveal.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/plain",
+                    "application/yaml"
                 ],
-                "summary": "Delete a user",
+                "summary": "Export configuration",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
+                        "enum": [
+                            "yaml",
+                            "env",
+                            "properties",
+                            "json"
+                        ],
+                        "type": "string",
+                        "description": "Format",
+                        "name": "format",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "TestService:1",
+                        "description": "Service versions in format service:version",
+                        "name": "services[]",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Changeset ID",
+                        "name": "changesetId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Point in time (RFC3339), resolves to the last changeset applied at or before it",
+                        "name": "asOf",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "production"
+                        ],
+                        "type": "string",
+                        "description": "Mode",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "env:prod",
+                        "description": "Variation, all properties are required",
+                        "name": "variation[]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -2550,39 +2542,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    }
-                }
-            }
-        },
-        "/service-types": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all service types",
-                "produces": [
-                    "application/json"
-                ],
-                "summary": "Get service types",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/servicetype.ServiceTypeDto"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -2594,37 +2555,34 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new service type",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/configuration/variation-hierarchy": {
+            "get": {
+                "description": "Get variation hierarchy",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create service type",
+                "summary": "Get variation hierarchy",
                 "parameters": [
                     {
-                        "description": "Service type",
-                        "name": "service_type_dto",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.CreateServiceTypeRequest"
-                        }
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "TestService:1",
+                        "description": "Service versions",
+                        "name": "services[]",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateResponse"
+                            "$ref": "#/definitions/configuration.VariationHierarchyDto"
                         }
                     },
                     "400": {
@@ -2633,14 +2591,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -2654,32 +2606,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/service-types/{service_type_id}": {
+        "/membership": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a service type",
+                "description": "Get list of users and groups",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get service type",
+                "summary": "Get users and groups",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service type ID",
-                        "name": "service_type_id",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Type",
+                        "name": "type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/servicetype.ServiceTypeDto"
+                            "$ref": "#/definitions/core.PaginatedResult-membership_MembershipObjectDto"
                         }
                     },
                     "400": {
@@ -2694,12 +2665,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2707,30 +2672,40 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/membership/groups": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a service type",
+                "description": "Create a new group",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Delete service type",
+                "summary": "Create a group",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Service type ID",
-                        "name": "service_type_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Group",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateGroupRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -2750,8 +2725,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -2765,23 +2740,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/service-types/{service_type_id}/variation-properties": {
+        "/membership/groups/{group_id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get variation properties for a service type",
+                "description": "Get a group by ID",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get variation properties",
+                "summary": "Get a group",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service type ID",
-                        "name": "service_type_id",
+                        "description": "Group ID",
+                        "name": "group_id",
                         "in": "path",
                         "required": true
                     }
@@ -2790,10 +2765,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/variationproperty.ServiceTypeVariationPropertyDto"
-                            }
+                            "$ref": "#/definitions/membership.GroupDto"
                         }
                     },
                     "400": {
@@ -2822,36 +2794,24 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Link a variation property to a service type",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delete a group by ID",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Link variation property to service type",
+                "summary": "Delete a group",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service type ID",
-                        "name": "service_type_id",
+                        "description": "Group ID",
+                        "name": "group_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Link variation property to service type request",
-                        "name": "link_variation_property_to_service_type_request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.LinkVariationPropertyToServiceTypeRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -2891,42 +2851,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/service-types/{service_type_id}/variation-properties/{variation_property_id}": {
-            "delete": {
+        "/membership/groups/{group_id}/users": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Unlink a variation property from a service type",
+                "description": "Get group users by ID",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Unlink variation property from service type",
+                "summary": "Get group users",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service type ID",
-                        "name": "service_type_id",
+                        "description": "Group ID",
+                        "name": "group_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Variation property ID",
-                        "name": "variation_property_id",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
+                            "$ref": "#/definitions/core.PaginatedResult-membership_GroupUserDto"
                         }
                     },
                     "401": {
@@ -2935,12 +2899,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -2956,44 +2914,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/service-types/{service_type_id}/variation-properties/{variation_property_id}/priority": {
-            "put": {
+        "/membership/groups/{group_id}/users/{user_id}": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the priority of a variation property in a service type",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Add a user to a group",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Update service type variation property priority",
+                "summary": "Add a user to a group",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service type ID",
-                        "name": "service_type_id",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Variation property ID",
-                        "name": "variation_property_id",
+                        "description": "Group ID",
+                        "name": "group_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update service type variation property priority request",
-                        "name": "update_service_type_variation_property_priority_request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UpdateServiceTypeVariationPropertyPriorityRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -3031,75 +2977,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/services": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get list of services",
-                "produces": [
-                    "application/json"
-                ],
-                "summary": "Get services",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/service.ServiceDto"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    }
-                }
             },
-            "post": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create service",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Remove a user from a group",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create service",
+                "summary": "Remove a user from a group",
                 "parameters": [
                     {
-                        "description": "Create service request",
-                        "name": "createServiceRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.CreateServiceRequest"
-                        }
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "group_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.CreateResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -3119,8 +3027,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -3134,81 +3042,58 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/name-taken/{name}": {
+        "/membership/permissions": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Check if service name is taken",
+                "description": "Get permissions for a service, feature, key, or variation",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Check if service name is taken",
+                "summary": "Get permissions",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Service name",
-                        "name": "name",
-                        "in": "path",
+                        "type": "integer",
+                        "description": "Service version ID",
+                        "name": "serviceVersionId",
+                        "in": "query",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.BooleanResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    }
-                }
-            }
-        },
-        "/services/{service_version_id}": {
-            "get": {
-                "security": [
                     {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get service",
-                "produces": [
-                    "application/json"
-                ],
-                "summary": "Get service",
-                "parameters": [
+                        "type": "integer",
+                        "description": "Feature version ID",
+                        "name": "featureVersionId",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Service version ID",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Key ID",
+                        "name": "keyId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "1:prod",
+                        "description": "Variation",
+                        "name": "variation[]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.ServiceVersionDto"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/membership.EntityPermissionDto"
+                            }
                         }
                     },
                     "400": {
@@ -3237,35 +3122,28 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update service",
+                "description": "Add a permission to a user or group",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Update service",
+                "summary": "Add a permission",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Service version ID (for url consistency, the underling service will be updated)",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update service request",
-                        "name": "updateServiceRequest",
+                        "description": "Permission",
+                        "name": "permission",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateServiceRequest"
+                            "$ref": "#/definitions/handler.AddPermissionRequest"
                         }
                     }
                 ],
@@ -3312,36 +3190,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{service_version_id}/features": {
-            "get": {
+        "/membership/permissions/{permission_id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get features for a service version",
+                "description": "Remove a permission from a group",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get features for a service version",
+                "summary": "Remove a permission from a group",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service version ID",
-                        "name": "service_version_id",
+                        "description": "Permission ID",
+                        "name": "permission_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/feature.FeatureVersionItemDto"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -3355,6 +3227,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -3368,36 +3246,31 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/membership/service-accounts": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create feature",
+                "description": "Create a user for automation that can only authenticate with API tokens",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create feature",
+                "summary": "Create a service account",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Service version ID",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Create feature request",
-                        "name": "createFeatureRequest",
+                        "description": "Service account",
+                        "name": "serviceAccount",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateFeatureRequest"
+                            "$ref": "#/definitions/handler.CreateServiceAccountRequest"
                         }
                     }
                 ],
@@ -3441,35 +3314,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{service_version_id}/features/linkable": {
-            "get": {
+        "/membership/users": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get feature versions that can be linked to a service version",
+                "description": "Create a new user",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get linkable features for a service version",
+                "summary": "Create a user",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Service version ID",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
+                        "description": "User",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateUserRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/feature.FeatureVersionDto"
-                            }
+                            "$ref": "#/definitions/handler.CreateResponse"
                         }
                     },
                     "400": {
@@ -3484,8 +3359,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -3499,30 +3380,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{service_version_id}/features/name-taken/{name}": {
+        "/membership/users/{user_id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Check if feature name is taken",
+                "description": "Get a user by ID",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Check if feature name is taken",
+                "summary": "Get a user",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service version ID",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Feature name",
-                        "name": "name",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     }
@@ -3531,7 +3405,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.BooleanResponse"
+                            "$ref": "#/definitions/membership.UserDto"
                         }
                     },
                     "400": {
@@ -3546,6 +3420,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3553,42 +3433,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/services/{service_version_id}/features/{feature_version_id}": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get feature",
+                "description": "Update a user by ID",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get feature",
+                "summary": "Update a user",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service version ID",
-                        "name": "service_version_id",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Feature version ID",
-                        "name": "feature_version_id",
-                        "in": "path",
-                        "required": true
+                        "description": "User",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateUserRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/feature.FeatureVersionDto"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -3602,6 +3482,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -3616,43 +3502,24 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create feature",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delete a user by ID",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create feature",
+                "summary": "Delete a user",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service version ID",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Feature version ID",
-                        "name": "feature_version_id",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update feature request",
-                        "name": "updateFeatureRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UpdateFeatureRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -3677,8 +3544,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -3692,30 +3559,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{service_version_id}/features/{feature_version_id}/keys": {
+        "/membership/users/{user_id}/tokens": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get keys for a feature",
+                "description": "Get API tokens of a user. The tokens themselves are never returned, only their prefix.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get keys for a feature",
+                "summary": "Get API tokens of a user",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service version ID",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Feature version ID",
-                        "name": "feature_version_id",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     }
@@ -3726,7 +3586,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/key.KeyItemDto"
+                                "$ref": "#/definitions/membership.ApiTokenDto"
                             }
                         }
                     },
@@ -3742,6 +3602,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -3762,36 +3628,29 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a key",
+                "description": "Create an API token for the user. The token is returned only once, use it as \"Authorization: Bearer cs_...\" header.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create a key",
+                "summary": "Create an API token",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service version ID",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Feature version ID",
-                        "name": "feature_version_id",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Create key request",
-                        "name": "createKeyRequest",
+                        "description": "API token",
+                        "name": "token",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateKeyRequest"
+                            "$ref": "#/definitions/handler.CreateApiTokenRequest"
                         }
                     }
                 ],
@@ -3799,7 +3658,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateResponse"
+                            "$ref": "#/definitions/membership.CreatedApiTokenDto"
                         }
                     },
                     "400": {
@@ -3826,6 +3685,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -3835,47 +3700,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{service_version_id}/features/{feature_version_id}/keys/name-taken/{name}": {
-            "get": {
+        "/membership/users/{user_id}/tokens/{token_id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Check if key name is taken",
+                "description": "Revoke an API token of the user",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Check if key name is taken",
+                "summary": "Revoke an API token",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service version ID",
-                        "name": "service_version_id",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Feature version ID",
-                        "name": "feature_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Key name",
-                        "name": "name",
+                        "description": "Token ID",
+                        "name": "token_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/handler.BooleanResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -3889,6 +3744,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -3904,46 +3765,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{service_version_id}/features/{feature_version_id}/keys/{key_id}": {
+        "/service-types": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a key",
+                "description": "Get all service types",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get a key",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Service version ID",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
+                "summary": "Get service types",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/servicetype.ServiceTypeDto"
+                            }
+                        }
                     },
-                    {
-                        "type": "integer",
-                        "description": "Feature version ID",
-                        "name": "feature_version_id",
-                        "in": "path",
-                        "required": true
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "Key ID",
-                        "name": "key_id",
-                        "in": "path",
-                        "required": true
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new service type",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create service type",
+                "parameters": [
+                    {
+                        "description": "Service type",
+                        "name": "service_type_dto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateServiceTypeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/key.KeyDto"
+                            "$ref": "#/definitions/handler.CreateResponse"
                         }
                     },
                     "400": {
@@ -3958,8 +3845,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -3971,56 +3858,35 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/service-types/{service_type_id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a key",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get a service type",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create a key",
+                "summary": "Get service type",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service version ID",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Feature version ID",
-                        "name": "feature_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Key ID",
-                        "name": "key_id",
+                        "description": "Service type ID",
+                        "name": "service_type_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update key request",
-                        "name": "updateKeyRequest",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UpdateKeyRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/servicetype.ServiceTypeDto"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -4034,12 +3900,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -4060,30 +3920,16 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a key",
+                "description": "Delete a service type",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Delete a key",
+                "summary": "Delete service type",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service version ID",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Feature version ID",
-                        "name": "feature_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Key ID",
-                        "name": "key_id",
+                        "description": "Service type ID",
+                        "name": "service_type_id",
                         "in": "path",
                         "required": true
                     }
@@ -4125,37 +3971,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{service_version_id}/features/{feature_version_id}/keys/{key_id}/values": {
+        "/service-types/{service_type_id}/variation-properties": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get values for a key",
+                "description": "Get variation properties for a service type",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get values for a key",
+                "summary": "Get variation properties",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service Version ID",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Feature Version ID",
-                        "name": "feature_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Key ID",
-                        "name": "key_id",
+                        "description": "Service type ID",
+                        "name": "service_type_id",
                         "in": "path",
                         "required": true
                     }
@@ -4166,7 +3998,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/value.VariationValueDto"
+                                "$ref": "#/definitions/variationproperty.ServiceTypeVariationPropertyDto"
                             }
                         }
                     },
@@ -4202,52 +4034,35 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create value",
+                "description": "Link a variation property to a service type",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create value",
+                "summary": "Link variation property to service type",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service Version ID",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Feature Version ID",
-                        "name": "feature_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Key ID",
-                        "name": "key_id",
+                        "description": "Service type ID",
+                        "name": "service_type_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Value request",
-                        "name": "valueRequest",
+                        "description": "Link variation property to service type request",
+                        "name": "link_variation_property_to_service_type_request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.ValueRequest"
+                            "$ref": "#/definitions/handler.LinkVariationPropertyToServiceTypeRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/value.NewValueInfo"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -4273,12 +4088,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4288,50 +4097,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{service_version_id}/features/{feature_version_id}/keys/{key_id}/values/can-add": {
-            "get": {
+        "/service-types/{service_type_id}/variation-properties/{variation_property_id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Can operate value with variation",
+                "description": "Unlink a variation property from a service type",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Can operate value with variation",
+                "summary": "Unlink variation property from service type",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service Version ID",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Feature Version ID",
-                        "name": "feature_version_id",
+                        "description": "Service type ID",
+                        "name": "service_type_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Key ID",
-                        "name": "key_id",
+                        "description": "Variation property ID",
+                        "name": "variation_property_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "multi",
-                        "example": "1:prod",
-                        "description": "Variation",
-                        "name": "variation[]",
-                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -4362,12 +4153,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4377,66 +4162,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{service_version_id}/features/{feature_version_id}/keys/{key_id}/values/{value_id}": {
+        "/service-types/{service_type_id}/variation-properties/{variation_property_id}/priority": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update value",
+                "description": "Update the priority of a variation property in a service type",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Update value",
+                "summary": "Update service type variation property priority",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service Version ID",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Feature Version ID",
-                        "name": "feature_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Key ID",
-                        "name": "key_id",
+                        "description": "Service type ID",
+                        "name": "service_type_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Value ID",
-                        "name": "value_id",
+                        "description": "Variation property ID",
+                        "name": "variation_property_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Value request",
-                        "name": "valueRequest",
+                        "description": "Update service type variation property priority request",
+                        "name": "update_service_type_variation_property_priority_request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.ValueRequest"
+                            "$ref": "#/definitions/handler.UpdateServiceTypeVariationPropertyPriorityRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/value.NewValueInfo"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -4462,8 +4230,39 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/services": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get list of services",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get services",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.ServiceDto"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -4476,50 +4275,37 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete value",
+                "description": "Create service",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Delete value",
+                "summary": "Create service",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Service Version ID",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Feature Version ID",
-                        "name": "feature_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Key ID",
-                        "name": "key_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Value ID",
-                        "name": "value_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Create service request",
+                        "name": "createServiceRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateServiceRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -4539,8 +4325,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -4554,62 +4340,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{service_version_id}/features/{feature_version_id}/keys/{key_id}/values/{value_id}/can-edit": {
+        "/services/name-taken/{name}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Can edit value with variation",
+                "description": "Check if service name is taken",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Can edit value with variation",
+                "summary": "Check if service name is taken",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Service Version ID",
-                        "name": "service_version_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Feature Version ID",
-                        "name": "feature_version_id",
+                        "type": "string",
+                        "description": "Service name",
+                        "name": "name",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Key ID",
-                        "name": "key_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Value ID",
-                        "name": "value_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "multi",
-                        "example": "1:prod",
-                        "description": "Variation",
-                        "name": "variation[]",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BooleanResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -4623,24 +4380,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4650,18 +4389,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{service_version_id}/features/{feature_version_id}/link": {
-            "post": {
+        "/services/{service_version_id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Link feature version to a service version",
+                "description": "Get service",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Link feature version",
+                "summary": "Get service",
                 "parameters": [
                     {
                         "type": "integer",
@@ -4669,27 +4408,23 @@ const docTemplate = `{
                         "name": "service_version_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Feature version ID",
-                        "name": "feature_version_id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ServiceVersionDto"
+                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -4707,34 +4442,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/services/{service_version_id}/features/{feature_version_id}/unlink": {
-            "delete": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Unlink feature version from a service version",
+                "description": "Update service",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Unlink feature version",
+                "summary": "Update service",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Service version ID",
+                        "description": "Service version ID (for url consistency, the underling service will be updated)",
                         "name": "service_version_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Feature version ID",
-                        "name": "feature_version_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Update service request",
+                        "name": "updateServiceRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateServiceRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -4765,6 +4503,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4774,18 +4518,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{service_version_id}/features/{feature_version_id}/versions": {
+        "/services/{service_version_id}/features": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get feature versions",
+                "description": "Get features for a service version",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get feature versions",
+                "summary": "Get features for a service version",
                 "parameters": [
                     {
                         "type": "integer",
@@ -4793,13 +4537,6 @@ const docTemplate = `{
                         "name": "service_version_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Feature version ID",
-                        "name": "feature_version_id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -4808,7 +4545,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/feature.FeatureVersionLinkDto"
+                                "$ref": "#/definitions/feature.FeatureVersionItemDto"
                             }
                         }
                     },
@@ -4844,11 +4581,14 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create feature version",
+                "description": "Create feature",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create feature version",
+                "summary": "Create feature",
                 "parameters": [
                     {
                         "type": "integer",
@@ -4858,11 +4598,13 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Feature version ID",
-                        "name": "feature_version_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Create feature request",
+                        "name": "createFeatureRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateFeatureRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -4890,8 +4632,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -4905,18 +4647,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{service_version_id}/publish": {
-            "put": {
+        "/services/{service_version_id}/features/linkable": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Publish service version",
+                "description": "Get feature versions that can be linked to a service version",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Publish service version",
+                "summary": "Get linkable features for a service version",
                 "parameters": [
                     {
                         "type": "integer",
@@ -4927,8 +4669,14 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/feature.FeatureVersionDto"
+                            }
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -4942,12 +4690,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -4963,18 +4705,18 @@ const docTemplate = `{
                 }
             }
         },
-        "/services/{service_version_id}/versions": {
+        "/services/{service_version_id}/features/name-taken/{name}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get service versions",
+                "description": "Check if feature name is taken",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get service versions",
+                "summary": "Check if feature name is taken",
                 "parameters": [
                     {
                         "type": "integer",
@@ -4982,16 +4724,20 @@ const docTemplate = `{
                         "name": "service_version_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feature name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/service.ServiceVersionLinkDto"
-                            }
+                            "$ref": "#/definitions/handler.BooleanResponse"
                         }
                     },
                     "400": {
@@ -5006,12 +4752,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5019,18 +4759,20 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/services/{service_version_id}/features/{feature_version_id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create service version",
+                "description": "Get feature",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create service version",
+                "summary": "Get feature",
                 "parameters": [
                     {
                         "type": "integer",
@@ -5038,13 +4780,20 @@ const docTemplate = `{
                         "name": "service_version_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateResponse"
+                            "$ref": "#/definitions/feature.FeatureVersionDto"
                         }
                     },
                     "400": {
@@ -5059,12 +4808,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -5078,118 +4821,70 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/value-types": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all value types",
+                "description": "Create feature",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Get value types",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/valuetype.ValueTypeDto"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
+                "summary": "Create feature",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    }
-                }
-            }
-        },
-        "/value-types/{value_type_id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get a value type by ID",
-                "produces": [
-                    "application/json"
-                ],
-                "summary": "Get value type",
-                "parameters": [
                     {
                         "type": "integer",
-                        "description": "Value type ID",
-                        "name": "value_type_id",
+                        "description": "Feature version ID",
+                        "name": "feature_version_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Update feature request",
+                        "name": "updateFeatureRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateFeatureRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/valuetype.ValueTypeDto"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
-                    }
-                }
-            }
-        },
-        "/variation-properties": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get all variation properties",
-                "produces": [
-                    "application/json"
-                ],
-                "summary": "Get variation properties",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/variationproperty.VariationPropertyItemDto"
-                            }
-                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -5201,37 +4896,44 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/services/{service_version_id}/features/{feature_version_id}/constraints": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new variation property",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get constraints of the feature version",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create variation property",
+                "summary": "Get constraints",
                 "parameters": [
                     {
-                        "description": "Variation property",
-                        "name": "variation_property",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.CreateVariationPropertyRequest"
-                        }
+                        "type": "integer",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/constraint.ConstraintDto"
+                            }
                         }
                     },
                     "400": {
@@ -5252,6 +4954,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5259,34 +4967,51 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/variation-properties/name-taken/{name}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Check if variation property name is taken",
+                "description": "Create constraint of the feature version. The expression is a CEL expression over the keys of the feature version, it is evaluated for every variation when a changeset changing the feature version is committed, scheduled or applied.\nThe current values must satisfy the constraint. Constraints cannot be changed for feature versions linked to a published service version.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Check if variation property name is taken",
+                "summary": "Create constraint",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Variation property name",
-                        "name": "name",
+                        "type": "integer",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature version ID",
+                        "name": "feature_version_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Constraint request",
+                        "name": "constraintRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ConstraintRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.BooleanResponse"
+                            "$ref": "#/definitions/handler.CreateResponse"
                         }
                     },
                     "400": {
@@ -5301,51 +5026,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
-                    }
-                }
-            }
-        },
-        "/variation-properties/{property_id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get variation property by ID",
-                "produces": [
-                    "application/json"
-                ],
-                "summary": "Get variation property",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Property ID",
-                        "name": "property_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/variationproperty.VariationPropertyDto"
-                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "422": {
+                        "description": "Unprocessable Entity",
                         "schema": {
                             "$ref": "#/definitions/echo.HTTPError"
                         }
@@ -5357,36 +5051,52 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/services/{service_version_id}/features/{feature_version_id}/constraints/{constraint_id}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update variation property",
+                "description": "Update constraint of the feature version",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Update variation property",
+                "summary": "Update constraint",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Property ID",
-                        "name": "property_id",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Variation property",
-                        "name": "variation_property",
+                        "type": "integer",
+                        "description": "Feature version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Constraint ID",
+                        "name": "constraint_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Constraint request",
+                        "name": "constraintRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateVariationPropertyRequest"
+                            "$ref": "#/definitions/handler.ConstraintRequest"
                         }
                     }
                 ],
@@ -5412,6 +5122,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5426,16 +5148,30 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a variation property",
+                "description": "Delete constraint of the feature version",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Delete variation property",
+                "summary": "Delete constraint",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Property ID",
-                        "name": "property_id",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Constraint ID",
+                        "name": "constraint_id",
                         "in": "path",
                         "required": true
                     }
@@ -5477,30 +5213,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/variation-properties/{property_id}/value-taken/{value}": {
+        "/services/{service_version_id}/features/{feature_version_id}/keys": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Check if variation property name is taken",
+                "description": "Get keys for a feature",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Check if variation property name is taken",
+                "summary": "Get keys for a feature",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Property ID",
-                        "name": "property_id",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Variation property value",
-                        "name": "value",
+                        "type": "integer",
+                        "description": "Feature version ID",
+                        "name": "feature_version_id",
                         "in": "path",
                         "required": true
                     }
@@ -5509,7 +5245,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.BooleanResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/key.KeyItemDto"
+                            }
                         }
                     },
                     "400": {
@@ -5524,6 +5263,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5531,38 +5276,43 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/variation-properties/{property_id}/values": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new variation property value",
+                "description": "Create a key",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Create variation property value",
+                "summary": "Create a key",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Property ID",
-                        "name": "property_id",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Variation property value",
-                        "name": "variation_property_value",
+                        "type": "integer",
+                        "description": "Feature version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create key request",
+                        "name": "createKeyRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.CreateVariationPropertyValueRequest"
+                            "$ref": "#/definitions/handler.CreateKeyRequest"
                         }
                     }
                 ],
@@ -5606,37 +5356,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/variation-properties/{property_id}/values/{value_id}": {
-            "delete": {
+        "/services/{service_version_id}/features/{feature_version_id}/keys/name-taken/{name}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a variation property value",
+                "description": "Check if key name is taken",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Delete variation property value",
+                "summary": "Check if key name is taken",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Property ID",
-                        "name": "property_id",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Value ID",
-                        "name": "value_id",
+                        "description": "Feature version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Key name",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BooleanResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -5650,12 +5410,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -5671,37 +5425,47 @@ const docTemplate = `{
                 }
             }
         },
-        "/variation-properties/{property_id}/values/{value_id}/archive": {
-            "put": {
+        "/services/{service_version_id}/features/{feature_version_id}/keys/{key_id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Archive a variation property value",
+                "description": "Get a key",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Archive variation property value",
+                "summary": "Get a key",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Property ID",
-                        "name": "property_id",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Value ID",
-                        "name": "value_id",
+                        "description": "Feature version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Key ID",
+                        "name": "key_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/key.KeyDto"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -5715,12 +5479,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -5734,45 +5492,50 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/variation-properties/{property_id}/values/{value_id}/order": {
+            },
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update variation property value order",
+                "description": "Create a key",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Update variation property value order",
+                "summary": "Create a key",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Property ID",
-                        "name": "property_id",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Value ID",
-                        "name": "value_id",
+                        "description": "Feature version ID",
+                        "name": "feature_version_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Variation property value order",
-                        "name": "variation_property_value_order",
+                        "type": "integer",
+                        "description": "Key ID",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update key request",
+                        "name": "updateKeyRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UpdateVariationPropertyValueOrderRequest"
+                            "$ref": "#/definitions/handler.UpdateKeyRequest"
                         }
                     }
                 ],
@@ -5811,32 +5574,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/variation-properties/{property_id}/values/{value_id}/unarchive": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Unarchive a variation property value",
+                "description": "Delete a key",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Unarchive variation property value",
+                "summary": "Delete a key",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Property ID",
-                        "name": "property_id",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Value ID",
-                        "name": "value_id",
+                        "description": "Feature version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Key ID",
+                        "name": "key_id",
                         "in": "path",
                         "required": true
                     }
@@ -5877,101 +5645,2719 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "auth.User": {
-            "type": "object",
-            "required": [
-                "id",
-                "isAuthenticated",
-                "isGlobalAdmin",
-                "username"
-            ],
-            "properties": {
-                "id": {
-                    "type": "integer"
-                },
-                "isAuthenticated": {
-                    "type": "boolean"
-                },
-                "isGlobalAdmin": {
-                    "type": "boolean"
-                },
-                "username": {
-                    "type": "string"
-                }
-            }
         },
-        "changeset.ChangeHistoryItemDto": {
-            "type": "object",
-            "required": [
-                "appliedAt",
-                "changesetId",
-                "featureId",
-                "featureName",
-                "featureVersion",
-                "featureVersionId",
-                "id",
-                "keyId",
-                "keyName",
-                "kind",
-                "newVariationValueData",
-                "newVariationValueId",
-                "oldVariationValueData",
-                "oldVariationValueId",
-                "serviceId",
-                "serviceName",
-                "serviceVersion",
-                "serviceVersionId",
-                "type",
-                "userId",
-                "userName",
-                "variation"
-            ],
-            "properties": {
-                "appliedAt": {
-                    "type": "string"
-                },
-                "changesetId": {
-                    "type": "integer"
-                },
-                "featureId": {
-                    "type": "integer"
-                },
-                "featureName": {
-                    "type": "string"
-                },
-                "featureVersion": {
-                    "type": "integer"
-                },
-                "featureVersionId": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "keyId": {
-                    "type": "integer"
-                },
-                "keyName": {
-                    "type": "string"
-                },
-                "kind": {
-                    "$ref": "#/definitions/db.ChangesetChangeKind"
-                },
-                "newVariationValueData": {
-                    "type": "string"
+        "/services/{service_version_id}/features/{feature_version_id}/keys/{key_id}/values": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get values for a key",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get values for a key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service Version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature Version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Key ID",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/value.VariationValueDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create value",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create value",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service Version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature Version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Key ID",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Value request",
+                        "name": "valueRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ValueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/value.NewValueInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{service_version_id}/features/{feature_version_id}/keys/{key_id}/values/can-add": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Can operate value with variation",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Can operate value with variation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service Version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature Version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Key ID",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "1:prod",
+                        "description": "Variation",
+                        "name": "variation[]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{service_version_id}/features/{feature_version_id}/keys/{key_id}/values/{value_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update value",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update value",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service Version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature Version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Key ID",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Value ID",
+                        "name": "value_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Value request",
+                        "name": "valueRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ValueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/value.NewValueInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete value",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Delete value",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service Version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature Version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Key ID",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Value ID",
+                        "name": "value_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{service_version_id}/features/{feature_version_id}/keys/{key_id}/values/{value_id}/can-edit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Can edit value with variation",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Can edit value with variation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service Version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature Version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Key ID",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Value ID",
+                        "name": "value_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "example": "1:prod",
+                        "description": "Variation",
+                        "name": "variation[]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{service_version_id}/features/{feature_version_id}/link": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Link feature version to a service version",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Link feature version",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{service_version_id}/features/{feature_version_id}/unlink": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unlink feature version from a service version",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Unlink feature version",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{service_version_id}/features/{feature_version_id}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get feature versions",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get feature versions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/feature.FeatureVersionLinkDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create feature version",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create feature version",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Feature version ID",
+                        "name": "feature_version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{service_version_id}/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create the features, keys and values declared in a YAML or JSON manifest in the open changeset.\nExisting features, keys and values that differ from the manifest are updated.\nFeatures, keys and values missing from the manifest are reported as drift, or removed when pruning.",
+                "consumes": [
+                    "application/json",
+                    "application/yaml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Import manifest",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the manifest and return the changes it would make",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Delete the keys and values and unlink the features that are not in the manifest",
+                        "name": "prune",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Manifest",
+                        "name": "manifest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/manifest.Manifest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/manifest.ImportResultDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{service_version_id}/publish": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publish service version",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Publish service version",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{service_version_id}/review-policy": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the review policy of the service. Changesets touching the service need the configured approvals before they can be applied.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get service review policy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID (for url consistency, the policy of the underlying service is returned)",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ReviewPolicyDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create or update the review policy of the service",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update service review policy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID (for url consistency, the policy of the underlying service will be updated)",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update review policy request",
+                        "name": "updateReviewPolicyRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateReviewPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete the review policy of the service",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Delete service review policy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID (for url consistency, the policy of the underlying service will be deleted)",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{service_version_id}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get service versions",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get service versions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.ServiceVersionLinkDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create service version",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create service version",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{service_version_id}/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get webhooks of the service",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get webhooks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID (for url consistency, the webhooks of the underlying service are returned)",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/webhook.WebhookDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create webhook for the service. Deliveries are signed with the secret using HMAC-SHA256, the signature is sent in the X-Config-Service-Signature header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID (for url consistency, the webhook is created for the underlying service)",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create webhook request",
+                        "name": "createWebhookRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{service_version_id}/webhooks/{webhook_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get webhook by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/webhook.WebhookDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update webhook. The secret is kept unchanged when not provided.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update webhook request",
+                        "name": "updateWebhookRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete webhook and its delivery log",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Delete webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/services/{service_version_id}/webhooks/{webhook_id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the delivery log of the webhook, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service version ID",
+                        "name": "service_version_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page Size",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/core.PaginatedResult-webhook_WebhookDeliveryDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/value-types": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all value types",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get value types",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/valuetype.ValueTypeDto"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/value-types/{value_type_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a value type by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get value type",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Value type ID",
+                        "name": "value_type_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/valuetype.ValueTypeDto"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/variation-properties": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all variation properties",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get variation properties",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/variationproperty.VariationPropertyItemDto"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new variation property",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create variation property",
+                "parameters": [
+                    {
+                        "description": "Variation property",
+                        "name": "variation_property",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateVariationPropertyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/variation-properties/name-taken/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check if variation property name is taken",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Check if variation property name is taken",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Variation property name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BooleanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/variation-properties/{property_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get variation property by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Get variation property",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Property ID",
+                        "name": "property_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/variationproperty.VariationPropertyDto"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update variation property",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update variation property",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Property ID",
+                        "name": "property_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variation property",
+                        "name": "variation_property",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateVariationPropertyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a variation property",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Delete variation property",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Property ID",
+                        "name": "property_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/variation-properties/{property_id}/value-taken/{value}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Check if variation property name is taken",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Check if variation property name is taken",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Property ID",
+                        "name": "property_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Variation property value",
+                        "name": "value",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.BooleanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/variation-properties/{property_id}/values": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new variation property value",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Create variation property value",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Property ID",
+                        "name": "property_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variation property value",
+                        "name": "variation_property_value",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateVariationPropertyValueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/variation-properties/{property_id}/values/{value_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a variation property value",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Delete variation property value",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Property ID",
+                        "name": "property_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Value ID",
+                        "name": "value_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/variation-properties/{property_id}/values/{value_id}/archive": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Archive a variation property value",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Archive variation property value",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Property ID",
+                        "name": "property_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Value ID",
+                        "name": "value_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/variation-properties/{property_id}/values/{value_id}/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update variation property value order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Update variation property value order",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Property ID",
+                        "name": "property_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Value ID",
+                        "name": "value_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variation property value order",
+                        "name": "variation_property_value_order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UpdateVariationPropertyValueOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/variation-properties/{property_id}/values/{value_id}/unarchive": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unarchive a variation property value",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Unarchive variation property value",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Property ID",
+                        "name": "property_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Value ID",
+                        "name": "value_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "auth.User": {
+            "type": "object",
+            "required": [
+                "canRevealSecrets",
+                "id",
+                "isAuthenticated",
+                "isGlobalAdmin",
+                "username"
+            ],
+            "properties": {
+                "canRevealSecrets": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isAuthenticated": {
+                    "type": "boolean"
+                },
+                "isGlobalAdmin": {
+                    "type": "boolean"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "changeset.ChangeHistoryItemDto": {
+            "type": "object",
+            "required": [
+                "appliedAt",
+                "changesetId",
+                "featureId",
+                "featureName",
+                "featureVersion",
+                "featureVersionId",
+                "id",
+                "keyId",
+                "keyName",
+                "kind",
+                "newVariationValueData",
+                "newVariationValueId",
+                "oldVariationValueData",
+                "oldVariationValueId",
+                "serviceId",
+                "serviceName",
+                "serviceVersion",
+                "serviceVersionId",
+                "type",
+                "userId",
+                "userName",
+                "variation"
+            ],
+            "properties": {
+                "appliedAt": {
+                    "type": "string"
+                },
+                "changesetId": {
+                    "type": "integer"
+                },
+                "featureId": {
+                    "type": "integer"
+                },
+                "featureName": {
+                    "type": "string"
+                },
+                "featureVersion": {
+                    "type": "integer"
+                },
+                "featureVersionId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "keyId": {
+                    "type": "integer"
+                },
+                "keyName": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/db.ChangesetChangeKind"
+                },
+                "newVariationValueData": {
+                    "type": "string"
+                },
+                "newVariationValueId": {
+                    "type": "integer"
+                },
+                "oldVariationValueData": {
+                    "type": "string"
+                },
+                "oldVariationValueId": {
+                    "type": "integer"
+                },
+                "serviceId": {
+                    "type": "integer"
+                },
+                "serviceName": {
+                    "type": "string"
+                },
+                "serviceVersion": {
+                    "type": "integer"
+                },
+                "serviceVersionId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/db.ChangesetChangeType"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "userName": {
+                    "type": "string"
+                },
+                "variation": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "changeset.ChangesetAction": {
+            "type": "object",
+            "required": [
+                "createdAt",
+                "id",
+                "type",
+                "userId",
+                "userName"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/db.ChangesetActionType"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "userName": {
+                    "type": "string"
+                }
+            }
+        },
+        "changeset.ChangesetApproval": {
+            "type": "object",
+            "required": [
+                "createdAt",
+                "userId",
+                "userName"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "userName": {
+                    "type": "string"
+                }
+            }
+        },
+        "changeset.ChangesetChange": {
+            "type": "object",
+            "required": [
+                "id",
+                "kind",
+                "serviceName",
+                "serviceVersion",
+                "serviceVersionId",
+                "type"
+            ],
+            "properties": {
+                "conflict": {
+                    "$ref": "#/definitions/changeset.Conflict"
+                },
+                "featureId": {
+                    "type": "integer"
+                },
+                "featureName": {
+                    "type": "string"
+                },
+                "featureVersion": {
+                    "type": "integer"
+                },
+                "featureVersionId": {
+                    "type": "integer"
+                },
+                "featureVersionServiceVersionId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "keyId": {
+                    "type": "integer"
+                },
+                "keyName": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/db.ChangesetChangeKind"
+                },
+                "newVariationValueData": {
+                    "type": "string"
                 },
                 "newVariationValueId": {
                     "type": "integer"
                 },
+                "newVariationValueRollout": {
+                    "$ref": "#/definitions/core.Rollout"
+                },
                 "oldVariationValueData": {
                     "type": "string"
                 },
                 "oldVariationValueId": {
                     "type": "integer"
                 },
+                "oldVariationValueRollout": {
+                    "$ref": "#/definitions/core.Rollout"
+                },
+                "previousFeatureVersionId": {
+                    "type": "integer"
+                },
+                "previousServiceVersionId": {
+                    "type": "integer"
+                },
                 "serviceId": {
                     "type": "integer"
                 },
@@ -5984,34 +8370,178 @@ const docTemplate = `{
                 "serviceVersionId": {
                     "type": "integer"
                 },
-                "type": {
-                    "$ref": "#/definitions/db.ChangesetChangeType"
+                "type": {
+                    "$ref": "#/definitions/db.ChangesetChangeType"
+                },
+                "variation": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "changeset.ChangesetDto": {
+            "type": "object",
+            "required": [
+                "actions",
+                "approvals",
+                "canApply",
+                "changes",
+                "conflictCount",
+                "id",
+                "state",
+                "userId",
+                "userName",
+                "variationContext"
+            ],
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/changeset.ChangesetAction"
+                    }
+                },
+                "applyAt": {
+                    "type": "string"
+                },
+                "approvals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/changeset.ChangesetApproval"
+                    }
+                },
+                "canApply": {
+                    "type": "boolean"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/changeset.ChangesetChange"
+                    }
+                },
+                "conflictCount": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "state": {
+                    "$ref": "#/definitions/db.ChangesetState"
+                },
+                "userId": {
+                    "type": "integer"
+                },
+                "userName": {
+                    "type": "string"
+                },
+                "variationContext": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "changeset.ChangesetItemDto": {
+            "type": "object",
+            "required": [
+                "actionCount",
+                "createdAt",
+                "id",
+                "lastActionAt",
+                "state",
+                "userId",
+                "userName"
+            ],
+            "properties": {
+                "actionCount": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastActionAt": {
+                    "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/db.ChangesetState"
                 },
                 "userId": {
                     "type": "integer"
                 },
                 "userName": {
                     "type": "string"
+                }
+            }
+        },
+        "changeset.Conflict": {
+            "type": "object",
+            "required": [
+                "kind"
+            ],
+            "properties": {
+                "existingValueData": {
+                    "type": "string"
                 },
-                "variation": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
+                "kind": {
+                    "$ref": "#/definitions/changeset.ConflictKind"
+                },
+                "message": {
+                    "type": "string"
                 }
             }
         },
-        "changeset.ChangesetAction": {
+        "changeset.ConflictKind": {
+            "type": "string",
+            "enum": [
+                "new_value_duplicate_variation",
+                "old_value_deleted",
+                "old_value_updated",
+                "value_in_deleted_key",
+                "value_in_deleted_feature",
+                "key_validators_updated",
+                "key_in_deleted_feature",
+                "key_duplicate_name",
+                "duplicate_link",
+                "deleted_link",
+                "inconsistent_feature_version",
+                "inconsistent_service_version",
+                "change_in_published_service_version",
+                "constraint_violated"
+            ],
+            "x-enum-varnames": [
+                "ConflictKindNewValueDuplicateVariation",
+                "ConflictKindOldValueDeleted",
+                "ConflictKindOldValueUpdated",
+                "ConflictKindValueInDeletedKey",
+                "ConflictKindValueInDeletedFeature",
+                "ConflictKindKeyValidatorsUpdated",
+                "ConflictKindKeyInDeletedFeature",
+                "ConflictKindKeyDuplicateName",
+                "ConflictKindDuplicateLink",
+                "ConflictKindDeletedLink",
+                "ConflictKindInconsistentFeatureVersion",
+                "ConflictKindInconsistentServiceVersion",
+                "ConflictKindChangeInPublishedServiceVersion",
+                "ConflictKindConstraintViolated"
+            ]
+        },
+        "clientcredential.ClientCredentialDto": {
             "type": "object",
             "required": [
                 "createdAt",
                 "id",
-                "type",
-                "userId",
-                "userName"
+                "kind",
+                "name",
+                "services",
+                "updatedAt"
             ],
             "properties": {
-                "comment": {
+                "certificateSubject": {
                     "type": "string"
                 },
                 "createdAt": {
@@ -6020,90 +8550,192 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
-                "type": {
-                    "$ref": "#/definitions/db.ChangesetActionType"
+                "keyPrefix": {
+                    "type": "string"
                 },
-                "userId": {
-                    "type": "integer"
+                "kind": {
+                    "$ref": "#/definitions/db.ClientCredentialKind"
                 },
-                "userName": {
+                "name": {
+                    "type": "string"
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/clientcredential.ClientCredentialServiceDto"
+                    }
+                },
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "changeset.ChangesetChange": {
+        "clientcredential.ClientCredentialServiceDto": {
             "type": "object",
             "required": [
                 "id",
-                "kind",
-                "serviceName",
-                "serviceVersion",
-                "serviceVersionId",
-                "type"
+                "name"
             ],
             "properties": {
-                "conflict": {
-                    "$ref": "#/definitions/changeset.Conflict"
-                },
-                "featureId": {
+                "id": {
                     "type": "integer"
                 },
-                "featureName": {
+                "name": {
                     "type": "string"
-                },
-                "featureVersion": {
+                }
+            }
+        },
+        "clientcredential.CreatedClientCredentialDto": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
                     "type": "integer"
                 },
-                "featureVersionId": {
-                    "type": "integer"
+                "key": {
+                    "type": "string"
+                }
+            }
+        },
+        "configuration.ConfigurationDeltaDto": {
+            "type": "object",
+            "required": [
+                "changesetId",
+                "features",
+                "fromChangesetId",
+                "removedFeatures"
+            ],
+            "properties": {
+                "appliedAt": {
+                    "type": "string"
                 },
-                "featureVersionServiceVersionId": {
+                "changesetId": {
                     "type": "integer"
                 },
-                "id": {
-                    "type": "integer"
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/configuration.FeatureConfigurationDeltaDto"
+                    }
                 },
-                "keyId": {
+                "fromChangesetId": {
                     "type": "integer"
                 },
-                "keyName": {
-                    "type": "string"
-                },
-                "kind": {
-                    "$ref": "#/definitions/db.ChangesetChangeKind"
+                "removedFeatures": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "configuration.ConfigurationDiffDto": {
+            "type": "object",
+            "required": [
+                "features",
+                "fromChangesetId",
+                "toChangesetId"
+            ],
+            "properties": {
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/configuration.FeatureDiffDto"
+                    }
                 },
-                "newVariationValueData": {
+                "fromAppliedAt": {
                     "type": "string"
                 },
-                "newVariationValueId": {
+                "fromChangesetId": {
                     "type": "integer"
                 },
-                "oldVariationValueData": {
+                "toAppliedAt": {
                     "type": "string"
                 },
-                "oldVariationValueId": {
+                "toChangesetId": {
                     "type": "integer"
+                }
+            }
+        },
+        "configuration.ConfigurationDto": {
+            "type": "object",
+            "required": [
+                "changesetId",
+                "features"
+            ],
+            "properties": {
+                "appliedAt": {
+                    "type": "string"
                 },
-                "previousFeatureVersionId": {
+                "changesetId": {
                     "type": "integer"
                 },
-                "previousServiceVersionId": {
-                    "type": "integer"
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/configuration.FeatureConfigurationDto"
+                    }
+                }
+            }
+        },
+        "configuration.DiffKind": {
+            "type": "string",
+            "enum": [
+                "added",
+                "removed",
+                "changed"
+            ],
+            "x-enum-varnames": [
+                "DiffKindAdded",
+                "DiffKindRemoved",
+                "DiffKindChanged"
+            ]
+        },
+        "configuration.ExplainCandidateDto": {
+            "type": "object",
+            "required": [
+                "data",
+                "matched",
+                "parentMatches",
+                "rank",
+                "selected"
+            ],
+            "properties": {
+                "data": {
+                    "type": "string"
                 },
-                "serviceId": {
-                    "type": "integer"
+                "matched": {
+                    "type": "boolean"
                 },
-                "serviceName": {
-                    "type": "string"
+                "parentMatches": {
+                    "description": "ParentMatches are the properties that matched because the requested value is a parent of the candidate value",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "serviceVersion": {
+                "rank": {
                     "type": "integer"
                 },
-                "serviceVersionId": {
-                    "type": "integer"
+                "rollout": {
+                    "description": "Rollout is set when the candidate applies only to a percentage of the subjects. Rolled out candidates are never\nselected, because whether they apply is decided by the clients for each subject.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/core.Rollout"
+                        }
+                    ]
                 },
-                "type": {
-                    "$ref": "#/definitions/db.ChangesetChangeType"
+                "selected": {
+                    "type": "boolean"
+                },
+                "unresolved": {
+                    "description": "Unresolved are the properties of the candidate variation that are not in the requested variation",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
                 "variation": {
                     "type": "object",
@@ -6113,51 +8745,51 @@ const docTemplate = `{
                 }
             }
         },
-        "changeset.ChangesetDto": {
+        "configuration.ExplainValueDto": {
             "type": "object",
             "required": [
-                "actions",
-                "canApply",
-                "changes",
-                "conflictCount",
-                "id",
-                "state",
-                "userId",
-                "userName",
-                "variationContext"
+                "candidates",
+                "changesetId",
+                "dataType",
+                "feature",
+                "key",
+                "variation"
             ],
             "properties": {
-                "actions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/changeset.ChangesetAction"
-                    }
-                },
-                "canApply": {
-                    "type": "boolean"
+                "appliedAt": {
+                    "type": "string"
                 },
-                "changes": {
+                "candidates": {
+                    "description": "Candidates are all values of the key ordered by rank from the highest",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/changeset.ChangesetChange"
+                        "$ref": "#/definitions/configuration.ExplainCandidateDto"
                     }
                 },
-                "conflictCount": {
+                "changesetId": {
                     "type": "integer"
                 },
-                "id": {
-                    "type": "integer"
+                "dataType": {
+                    "type": "string"
                 },
-                "state": {
-                    "$ref": "#/definitions/db.ChangesetState"
+                "feature": {
+                    "type": "string"
                 },
-                "userId": {
-                    "type": "integer"
+                "key": {
+                    "type": "string"
                 },
-                "userName": {
+                "mergeOrder": {
+                    "description": "MergeOrder are the indexes of the candidates in the order they are merged into the resolved value of a json key",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "value": {
+                    "description": "Value is the resolved value, nil if no candidate matched",
                     "type": "string"
                 },
-                "variationContext": {
+                "variation": {
                     "type": "object",
                     "additionalProperties": {
                         "type": "string"
@@ -6165,128 +8797,140 @@ const docTemplate = `{
                 }
             }
         },
-        "changeset.ChangesetItemDto": {
+        "configuration.FeatureConfigurationDeltaDto": {
             "type": "object",
             "required": [
-                "actionCount",
-                "createdAt",
-                "id",
-                "lastActionAt",
-                "state",
-                "userId",
-                "userName"
+                "keys",
+                "name",
+                "removedKeys"
             ],
             "properties": {
-                "actionCount": {
-                    "type": "integer"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/configuration.KeyConfigurationDeltaDto"
+                    }
                 },
-                "lastActionAt": {
+                "name": {
                     "type": "string"
                 },
-                "state": {
-                    "$ref": "#/definitions/db.ChangesetState"
-                },
-                "userId": {
-                    "type": "integer"
+                "removedKeys": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "configuration.FeatureConfigurationDto": {
+            "type": "object",
+            "required": [
+                "keys",
+                "name"
+            ],
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/configuration.KeyConfigurationDto"
+                    }
                 },
-                "userName": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "changeset.Conflict": {
+        "configuration.FeatureDiffDto": {
             "type": "object",
             "required": [
-                "kind"
+                "keys",
+                "kind",
+                "name"
             ],
             "properties": {
-                "existingValueData": {
-                    "type": "string"
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/configuration.KeyDiffDto"
+                    }
                 },
                 "kind": {
-                    "$ref": "#/definitions/changeset.ConflictKind"
+                    "$ref": "#/definitions/configuration.DiffKind"
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "changeset.ConflictKind": {
-            "type": "string",
-            "enum": [
-                "new_value_duplicate_variation",
-                "old_value_deleted",
-                "old_value_updated",
-                "value_in_deleted_key",
-                "value_in_deleted_feature",
-                "key_validators_updated",
-                "key_in_deleted_feature",
-                "key_duplicate_name",
-                "duplicate_link",
-                "deleted_link",
-                "inconsistent_feature_version",
-                "inconsistent_service_version",
-                "change_in_published_service_version"
-            ],
-            "x-enum-varnames": [
-                "ConflictKindNewValueDuplicateVariation",
-                "ConflictKindOldValueDeleted",
-                "ConflictKindOldValueUpdated",
-                "ConflictKindValueInDeletedKey",
-                "ConflictKindValueInDeletedFeature",
-                "ConflictKindKeyValidatorsUpdated",
-                "ConflictKindKeyInDeletedFeature",
-                "ConflictKindKeyDuplicateName",
-                "ConflictKindDuplicateLink",
-                "ConflictKindDeletedLink",
-                "ConflictKindInconsistentFeatureVersion",
-                "ConflictKindInconsistentServiceVersion",
-                "ConflictKindChangeInPublishedServiceVersion"
-            ]
-        },
-        "configuration.ConfigurationDto": {
+        "configuration.KeyConfigurationDeltaDto": {
             "type": "object",
             "required": [
-                "changesetId",
-                "features"
+                "dataType",
+                "name",
+                "removedValues",
+                "values"
             ],
             "properties": {
-                "changesetId": {
-                    "type": "integer"
+                "allowedValues": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "features": {
+                "dataType": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "removedValues": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/configuration.FeatureConfigurationDto"
+                        "$ref": "#/definitions/configuration.RemovedValueDto"
+                    }
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/configuration.ValueConfigurationDto"
                     }
                 }
             }
         },
-        "configuration.FeatureConfigurationDto": {
+        "configuration.KeyConfigurationDto": {
             "type": "object",
             "required": [
-                "keys",
-                "name"
+                "dataType",
+                "name",
+                "values"
             ],
             "properties": {
-                "keys": {
+                "allowedValues": {
+                    "description": "AllowedValues are the values allowed by the enum validator of the key",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/configuration.KeyConfigurationDto"
+                        "type": "string"
                     }
                 },
+                "dataType": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/configuration.ValueConfigurationDto"
+                    }
                 }
             }
         },
-        "configuration.KeyConfigurationDto": {
+        "configuration.KeyDiffDto": {
             "type": "object",
             "required": [
                 "dataType",
+                "kind",
                 "name",
                 "values"
             ],
@@ -6294,13 +8938,37 @@ const docTemplate = `{
                 "dataType": {
                     "type": "string"
                 },
+                "kind": {
+                    "$ref": "#/definitions/configuration.DiffKind"
+                },
                 "name": {
                     "type": "string"
                 },
                 "values": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/configuration.ValueConfigurationDto"
+                        "$ref": "#/definitions/configuration.ValueDiffDto"
+                    }
+                }
+            }
+        },
+        "configuration.RemovedValueDto": {
+            "type": "object",
+            "required": [
+                "rank"
+            ],
+            "properties": {
+                "rank": {
+                    "type": "integer"
+                },
+                "rollout": {
+                    "description": "Rollout is set if the removed value is the rolled out value of the variation",
+                    "type": "boolean"
+                },
+                "variation": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
                     }
                 }
             }
@@ -6318,6 +8986,50 @@ const docTemplate = `{
                 "rank": {
                     "type": "integer"
                 },
+                "rollout": {
+                    "description": "Rollout is set when the value applies only to a percentage of the subjects, clients fall back to lower ranked values for the rest",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/core.Rollout"
+                        }
+                    ]
+                },
+                "variation": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "configuration.ValueDiffDto": {
+            "type": "object",
+            "required": [
+                "kind"
+            ],
+            "properties": {
+                "jsonChanges": {
+                    "description": "JsonChanges are the structural changes of a changed json value",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jsondiff.Change"
+                    }
+                },
+                "kind": {
+                    "$ref": "#/definitions/configuration.DiffKind"
+                },
+                "newData": {
+                    "type": "string"
+                },
+                "newRollout": {
+                    "$ref": "#/definitions/core.Rollout"
+                },
+                "oldData": {
+                    "type": "string"
+                },
+                "oldRollout": {
+                    "$ref": "#/definitions/core.Rollout"
+                },
                 "variation": {
                     "type": "object",
                     "additionalProperties": {
@@ -6375,6 +9087,25 @@ const docTemplate = `{
                 }
             }
         },
+        "constraint.ConstraintDto": {
+            "type": "object",
+            "required": [
+                "errorText",
+                "expression",
+                "id"
+            ],
+            "properties": {
+                "errorText": {
+                    "type": "string"
+                },
+                "expression": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
         "core.PaginatedResult-changeset_ChangeHistoryItemDto": {
             "type": "object",
             "required": [
@@ -6447,6 +9178,50 @@ const docTemplate = `{
                 }
             }
         },
+        "core.PaginatedResult-webhook_WebhookDeliveryDto": {
+            "type": "object",
+            "required": [
+                "items",
+                "totalCount"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webhook.WebhookDeliveryDto"
+                    }
+                },
+                "totalCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "core.Rollout": {
+            "type": "object",
+            "required": [
+                "attribute",
+                "percentage"
+            ],
+            "properties": {
+                "attribute": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.ApiTokenScope": {
+            "type": "string",
+            "enum": [
+                "read",
+                "write"
+            ],
+            "x-enum-varnames": [
+                "ApiTokenScopeRead",
+                "ApiTokenScopeWrite"
+            ]
+        },
         "db.ChangesetActionType": {
             "type": "string",
             "enum": [
@@ -6455,7 +9230,12 @@ const docTemplate = `{
                 "stash",
                 "commit",
                 "reopen",
-                "comment"
+                "comment",
+                "revert",
+                "schedule",
+                "unschedule",
+                "schedule_failed",
+                "approve"
             ],
             "x-enum-varnames": [
                 "ChangesetActionTypeApply",
@@ -6463,7 +9243,12 @@ const docTemplate = `{
                 "ChangesetActionTypeStash",
                 "ChangesetActionTypeCommit",
                 "ChangesetActionTypeReopen",
-                "ChangesetActionTypeComment"
+                "ChangesetActionTypeComment",
+                "ChangesetActionTypeRevert",
+                "ChangesetActionTypeSchedule",
+                "ChangesetActionTypeUnschedule",
+                "ChangesetActionTypeScheduleFailed",
+                "ChangesetActionTypeApprove"
             ]
         },
         "db.ChangesetChangeKind": {
@@ -6504,7 +9289,8 @@ const docTemplate = `{
                 "applied",
                 "rejected",
                 "discarded",
-                "stashed"
+                "stashed",
+                "scheduled"
             ],
             "x-enum-varnames": [
                 "ChangesetStateOpen",
@@ -6512,7 +9298,19 @@ const docTemplate = `{
                 "ChangesetStateApplied",
                 "ChangesetStateRejected",
                 "ChangesetStateDiscarded",
-                "ChangesetStateStashed"
+                "ChangesetStateStashed",
+                "ChangesetStateScheduled"
+            ]
+        },
+        "db.ClientCredentialKind": {
+            "type": "string",
+            "enum": [
+                "api_key",
+                "certificate"
+            ],
+            "x-enum-varnames": [
+                "ClientCredentialKindApiKey",
+                "ClientCredentialKindCertificate"
             ]
         },
         "db.PermissionKind": {
@@ -6548,14 +9346,26 @@ const docTemplate = `{
                 "integer",
                 "decimal",
                 "boolean",
-                "json"
+                "json",
+                "enum",
+                "secret",
+                "string_list",
+                "integer_list",
+                "duration",
+                "url"
             ],
             "x-enum-varnames": [
                 "ValueTypeKindString",
                 "ValueTypeKindInteger",
                 "ValueTypeKindDecimal",
                 "ValueTypeKindBoolean",
-                "ValueTypeKindJson"
+                "ValueTypeKindJson",
+                "ValueTypeKindEnum",
+                "ValueTypeKindSecret",
+                "ValueTypeKindStringList",
+                "ValueTypeKindIntegerList",
+                "ValueTypeKindDuration",
+                "ValueTypeKindUrl"
             ]
         },
         "db.ValueValidatorType": {
@@ -6573,7 +9383,17 @@ const docTemplate = `{
                 "valid_json",
                 "valid_integer",
                 "valid_decimal",
-                "valid_regex"
+                "valid_regex",
+                "enum",
+                "valid_string_list",
+                "valid_integer_list",
+                "valid_duration",
+                "valid_url",
+                "min_items",
+                "max_items",
+                "min_duration",
+                "max_duration",
+                "cel"
             ],
             "x-enum-varnames": [
                 "ValueValidatorTypeRequired",
@@ -6588,7 +9408,45 @@ const docTemplate = `{
                 "ValueValidatorTypeValidJson",
                 "ValueValidatorTypeValidInteger",
                 "ValueValidatorTypeValidDecimal",
-                "ValueValidatorTypeValidRegex"
+                "ValueValidatorTypeValidRegex",
+                "ValueValidatorTypeEnum",
+                "ValueValidatorTypeValidStringList",
+                "ValueValidatorTypeValidIntegerList",
+                "ValueValidatorTypeValidDuration",
+                "ValueValidatorTypeValidUrl",
+                "ValueValidatorTypeMinItems",
+                "ValueValidatorTypeMaxItems",
+                "ValueValidatorTypeMinDuration",
+                "ValueValidatorTypeMaxDuration",
+                "ValueValidatorTypeCel"
+            ]
+        },
+        "db.WebhookDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "WebhookDeliveryStatusPending",
+                "WebhookDeliveryStatusSucceeded",
+                "WebhookDeliveryStatusFailed"
+            ]
+        },
+        "db.WebhookEventType": {
+            "type": "string",
+            "enum": [
+                "changeset_committed",
+                "changeset_applied",
+                "changeset_stashed",
+                "changeset_commented"
+            ],
+            "x-enum-varnames": [
+                "WebhookEventTypeChangesetCommitted",
+                "WebhookEventTypeChangesetApplied",
+                "WebhookEventTypeChangesetStashed",
+                "WebhookEventTypeChangesetCommented"
             ]
         },
         "echo.HTTPError": {
@@ -6752,84 +9610,378 @@ const docTemplate = `{
                 }
             }
         },
-        "handler.ApprovableChangesetCountResponse": {
+        "handler.ApprovableChangesetCountResponse": {
+            "type": "object",
+            "required": [
+                "count"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.BooleanResponse": {
+            "type": "object",
+            "required": [
+                "value"
+            ],
+            "properties": {
+                "value": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.ChangesetInfoResponse": {
+            "type": "object",
+            "required": [
+                "id",
+                "numberOfChanges"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "numberOfChanges": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ConstraintRequest": {
+            "type": "object",
+            "required": [
+                "expression"
+            ],
+            "properties": {
+                "errorText": {
+                    "type": "string"
+                },
+                "expression": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateApiTokenRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scope"
+            ],
+            "properties": {
+                "expiresAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "scope": {
+                    "$ref": "#/definitions/db.ApiTokenScope"
+                }
+            }
+        },
+        "handler.CreateClientCredentialRequest": {
+            "type": "object",
+            "required": [
+                "kind",
+                "name",
+                "serviceIds"
+            ],
+            "properties": {
+                "certificateSubject": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/db.ClientCredentialKind"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "serviceIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "handler.CreateFeatureRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateGroupRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "validators",
+                "valueTypeId"
+            ],
+            "properties": {
+                "defaultValue": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "validators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.ValidatorRequest"
+                    }
+                },
+                "valueTypeId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.CreateResponse": {
+            "type": "object",
+            "required": [
+                "newId"
+            ],
+            "properties": {
+                "newId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.CreateServiceAccountRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateServiceRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "name",
+                "serviceTypeId"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "serviceTypeId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.CreateServiceTypeRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "globalAdministrator",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "globalAdministrator": {
+                    "type": "boolean"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "revealSecrets": {
+                    "type": "boolean"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateVariationPropertyRequest": {
+            "type": "object",
+            "properties": {
+                "display_name": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateVariationPropertyValueRequest": {
+            "type": "object",
+            "properties": {
+                "parentId": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "enabled",
+                "events",
+                "secret",
+                "url"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.WebhookEventType"
+                    }
+                },
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.LinkVariationPropertyToServiceTypeRequest": {
+            "type": "object",
+            "required": [
+                "variation_property_id"
+            ],
+            "properties": {
+                "variation_property_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.LoginRequest": {
             "type": "object",
             "required": [
-                "count"
+                "password",
+                "username"
             ],
             "properties": {
-                "count": {
-                    "type": "integer"
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "handler.BooleanResponse": {
+        "handler.RefreshTokenRequest": {
             "type": "object",
             "required": [
-                "value"
+                "refresh_token"
             ],
             "properties": {
-                "value": {
-                    "type": "boolean"
+                "refresh_token": {
+                    "type": "string"
                 }
             }
         },
-        "handler.ChangesetInfoResponse": {
+        "handler.ScheduleChangesetRequest": {
             "type": "object",
             "required": [
-                "id",
-                "numberOfChanges"
+                "applyAt"
             ],
             "properties": {
-                "id": {
-                    "type": "integer"
+                "applyAt": {
+                    "type": "string"
                 },
-                "numberOfChanges": {
-                    "type": "integer"
+                "comment": {
+                    "type": "string"
                 }
             }
         },
-        "handler.CreateFeatureRequest": {
+        "handler.TokensResponse": {
             "type": "object",
             "required": [
-                "description",
-                "name"
+                "access_token",
+                "refresh_token"
             ],
             "properties": {
-                "description": {
+                "access_token": {
                     "type": "string"
                 },
-                "name": {
+                "refresh_token": {
                     "type": "string"
                 }
             }
         },
-        "handler.CreateGroupRequest": {
+        "handler.UpdateClientCredentialRequest": {
             "type": "object",
             "required": [
-                "name"
+                "name",
+                "serviceIds"
             ],
             "properties": {
                 "name": {
                     "type": "string"
+                },
+                "serviceIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "handler.CreateKeyRequest": {
+        "handler.UpdateFeatureRequest": {
             "type": "object",
             "required": [
-                "name",
-                "validators",
-                "valueTypeId"
+                "description"
             ],
             "properties": {
-                "defaultValue": {
-                    "type": "string"
-                },
                 "description": {
                     "type": "string"
-                },
-                "name": {
+                }
+            }
+        },
+        "handler.UpdateKeyRequest": {
+            "type": "object",
+            "required": [
+                "validators"
+            ],
+            "properties": {
+                "description": {
                     "type": "string"
                 },
                 "validators": {
@@ -6837,234 +9989,474 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/handler.ValidatorRequest"
                     }
-                },
-                "valueTypeId": {
-                    "type": "integer"
                 }
             }
         },
-        "handler.CreateResponse": {
+        "handler.UpdateReviewPolicyRequest": {
             "type": "object",
             "required": [
-                "newId"
+                "allowSelfApproval",
+                "requiredApprovals"
             ],
             "properties": {
-                "newId": {
+                "allowSelfApproval": {
+                    "type": "boolean"
+                },
+                "requiredApprovals": {
+                    "type": "integer"
+                },
+                "requiredUserGroupId": {
                     "type": "integer"
                 }
             }
         },
-        "handler.CreateServiceRequest": {
+        "handler.UpdateServiceRequest": {
             "type": "object",
             "required": [
-                "description",
-                "name",
-                "serviceTypeId"
+                "description"
             ],
             "properties": {
                 "description": {
                     "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "serviceTypeId": {
+                }
+            }
+        },
+        "handler.UpdateServiceTypeVariationPropertyPriorityRequest": {
+            "type": "object",
+            "required": [
+                "priority"
+            ],
+            "properties": {
+                "priority": {
                     "type": "integer"
                 }
             }
         },
-        "handler.CreateServiceTypeRequest": {
+        "handler.UpdateUserRequest": {
             "type": "object",
             "required": [
-                "name"
+                "globalAdministrator"
             ],
             "properties": {
-                "name": {
+                "globalAdministrator": {
+                    "type": "boolean"
+                },
+                "revealSecrets": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.UpdateVariationPropertyRequest": {
+            "type": "object",
+            "required": [
+                "displayName"
+            ],
+            "properties": {
+                "displayName": {
                     "type": "string"
                 }
             }
         },
-        "handler.CreateUserRequest": {
+        "handler.UpdateVariationPropertyValueOrderRequest": {
             "type": "object",
             "required": [
-                "globalAdministrator",
-                "password",
-                "username"
+                "order"
             ],
             "properties": {
-                "globalAdministrator": {
+                "order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.UpdateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "enabled",
+                "events",
+                "url"
+            ],
+            "properties": {
+                "enabled": {
                     "type": "boolean"
                 },
-                "password": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.WebhookEventType"
+                    }
+                },
+                "secret": {
                     "type": "string"
                 },
-                "username": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "handler.CreateVariationPropertyRequest": {
+        "handler.ValidatorRequest": {
             "type": "object",
+            "required": [
+                "errorText",
+                "parameter",
+                "validatorType"
+            ],
             "properties": {
-                "display_name": {
+                "errorText": {
                     "type": "string"
                 },
-                "name": {
+                "parameter": {
                     "type": "string"
+                },
+                "validatorType": {
+                    "$ref": "#/definitions/db.ValueValidatorType"
                 }
             }
         },
-        "handler.CreateVariationPropertyValueRequest": {
+        "handler.ValueRequest": {
             "type": "object",
+            "required": [
+                "data",
+                "variation"
+            ],
             "properties": {
-                "parentId": {
-                    "type": "integer"
+                "clearRollout": {
+                    "description": "ClearRollout makes a rolled out value apply to all subjects, only used when updating a value",
+                    "type": "boolean"
                 },
-                "value": {
+                "data": {
                     "type": "string"
+                },
+                "rollout": {
+                    "description": "Rollout limits the value to a percentage of the subjects, bucketed by the value of the attribute. When updating\na value, the existing rollout is kept if not set.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/core.Rollout"
+                        }
+                    ]
+                },
+                "variation": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "handler.LinkVariationPropertyToServiceTypeRequest": {
+        "jsondiff.Change": {
             "type": "object",
             "required": [
-                "variation_property_id"
+                "operation",
+                "path"
             ],
             "properties": {
-                "variation_property_id": {
-                    "type": "integer"
+                "new": {},
+                "old": {},
+                "operation": {
+                    "$ref": "#/definitions/jsondiff.Operation"
+                },
+                "path": {
+                    "description": "Path is a JSON pointer (RFC 6901) to the changed value",
+                    "type": "string"
                 }
             }
         },
-        "handler.LoginRequest": {
+        "jsondiff.Operation": {
+            "type": "string",
+            "enum": [
+                "added",
+                "removed",
+                "changed"
+            ],
+            "x-enum-varnames": [
+                "OperationAdded",
+                "OperationRemoved",
+                "OperationChanged"
+            ]
+        },
+        "key.AppliedKeyDto": {
             "type": "object",
             "required": [
-                "password",
-                "username"
+                "name"
             ],
             "properties": {
-                "password": {
-                    "type": "string"
-                },
-                "username": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "handler.RefreshTokenRequest": {
+        "key.KeyDto": {
             "type": "object",
             "required": [
-                "refresh_token"
+                "canEdit",
+                "description",
+                "id",
+                "name",
+                "validators",
+                "valueType",
+                "valueTypeId",
+                "valueTypeName"
             ],
             "properties": {
-                "refresh_token": {
+                "canEdit": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "validators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validation.ValidatorDto"
+                    }
+                },
+                "valueType": {
+                    "$ref": "#/definitions/db.ValueTypeKind"
+                },
+                "valueTypeId": {
+                    "type": "integer"
+                },
+                "valueTypeName": {
                     "type": "string"
                 }
             }
         },
-        "handler.TokensResponse": {
+        "key.KeyItemDto": {
             "type": "object",
             "required": [
-                "access_token",
-                "refresh_token"
+                "description",
+                "id",
+                "name",
+                "valueType",
+                "valueTypeId",
+                "valueTypeName"
             ],
             "properties": {
-                "access_token": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "refresh_token": {
+                "valueType": {
+                    "$ref": "#/definitions/db.ValueTypeKind"
+                },
+                "valueTypeId": {
+                    "type": "integer"
+                },
+                "valueTypeName": {
                     "type": "string"
                 }
             }
         },
-        "handler.UpdateFeatureRequest": {
+        "manifest.DriftDto": {
             "type": "object",
             "required": [
-                "description"
+                "feature",
+                "kind"
             ],
             "properties": {
-                "description": {
+                "data": {
+                    "description": "Data is the data of an unmanaged value",
+                    "type": "string"
+                },
+                "feature": {
                     "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/manifest.DriftKind"
+                },
+                "variation": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "handler.UpdateKeyRequest": {
+        "manifest.DriftKind": {
+            "type": "string",
+            "enum": [
+                "unmanaged_feature",
+                "unmanaged_key",
+                "unmanaged_value"
+            ],
+            "x-enum-varnames": [
+                "DriftKindUnmanagedFeature",
+                "DriftKindUnmanagedKey",
+                "DriftKindUnmanagedValue"
+            ]
+        },
+        "manifest.FeatureManifest": {
             "type": "object",
             "required": [
-                "validators"
+                "description",
+                "keys",
+                "name"
             ],
             "properties": {
                 "description": {
                     "type": "string"
                 },
-                "validators": {
+                "keys": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handler.ValidatorRequest"
+                        "$ref": "#/definitions/manifest.KeyManifest"
                     }
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "handler.UpdateServiceRequest": {
+        "manifest.ImportChangeDto": {
             "type": "object",
             "required": [
-                "description"
+                "feature",
+                "kind"
             ],
             "properties": {
-                "description": {
+                "feature": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/manifest.ImportChangeKind"
+                },
+                "newData": {
+                    "type": "string"
+                },
+                "newRollout": {
+                    "$ref": "#/definitions/core.Rollout"
+                },
+                "oldData": {
                     "type": "string"
+                },
+                "oldRollout": {
+                    "$ref": "#/definitions/core.Rollout"
+                },
+                "variation": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "handler.UpdateServiceTypeVariationPropertyPriorityRequest": {
-            "type": "object",
-            "required": [
-                "priority"
+        "manifest.ImportChangeKind": {
+            "type": "string",
+            "enum": [
+                "create_feature",
+                "create_key",
+                "create_value",
+                "update_value",
+                "update_feature",
+                "update_key",
+                "delete_value",
+                "delete_key",
+                "unlink_feature"
             ],
-            "properties": {
-                "priority": {
-                    "type": "integer"
-                }
-            }
+            "x-enum-varnames": [
+                "ImportChangeKindCreateFeature",
+                "ImportChangeKindCreateKey",
+                "ImportChangeKindCreateValue",
+                "ImportChangeKindUpdateValue",
+                "ImportChangeKindUpdateFeature",
+                "ImportChangeKindUpdateKey",
+                "ImportChangeKindDeleteValue",
+                "ImportChangeKindDeleteKey",
+                "ImportChangeKindUnlinkFeature"
+            ]
         },
-        "handler.UpdateUserRequest": {
+        "manifest.ImportResultDto": {
             "type": "object",
             "required": [
-                "globalAdministrator"
+                "changes",
+                "drift",
+                "dryRun"
             ],
             "properties": {
-                "globalAdministrator": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/manifest.ImportChangeDto"
+                    }
+                },
+                "drift": {
+                    "description": "Drift are the features, keys and values that are not in the manifest and are not removed by the import",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/manifest.DriftDto"
+                    }
+                },
+                "dryRun": {
                     "type": "boolean"
                 }
             }
         },
-        "handler.UpdateVariationPropertyRequest": {
+        "manifest.KeyManifest": {
             "type": "object",
             "required": [
-                "displayName"
+                "name",
+                "valueType",
+                "values"
             ],
             "properties": {
-                "displayName": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
                     "type": "string"
+                },
+                "validators": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/manifest.ValidatorManifest"
+                    }
+                },
+                "valueType": {
+                    "description": "ValueType is the name of the value type",
+                    "type": "string"
+                },
+                "values": {
+                    "description": "Values must contain the default value, which has no variation",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/manifest.ValueManifest"
+                    }
                 }
             }
         },
-        "handler.UpdateVariationPropertyValueOrderRequest": {
+        "manifest.Manifest": {
             "type": "object",
             "required": [
-                "order"
+                "features"
             ],
             "properties": {
-                "order": {
-                    "type": "integer"
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/manifest.FeatureManifest"
+                    }
                 }
             }
         },
-        "handler.ValidatorRequest": {
+        "manifest.ValidatorManifest": {
             "type": "object",
             "required": [
-                "errorText",
-                "parameter",
                 "validatorType"
             ],
             "properties": {
@@ -7079,16 +10471,23 @@ const docTemplate = `{
                 }
             }
         },
-        "handler.ValueRequest": {
+        "manifest.ValueManifest": {
             "type": "object",
             "required": [
-                "data",
-                "variation"
+                "data"
             ],
             "properties": {
                 "data": {
                     "type": "string"
                 },
+                "rollout": {
+                    "description": "Rollout limits the value to a percentage of the subjects, the default value cannot be rolled out",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/core.Rollout"
+                        }
+                    ]
+                },
                 "variation": {
                     "type": "object",
                     "additionalProperties": {
@@ -7097,86 +10496,53 @@ const docTemplate = `{
                 }
             }
         },
-        "key.AppliedKeyDto": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "key.KeyDto": {
+        "membership.ApiTokenDto": {
             "type": "object",
             "required": [
-                "canEdit",
-                "description",
+                "createdAt",
                 "id",
                 "name",
-                "validators",
-                "valueType",
-                "valueTypeId",
-                "valueTypeName"
+                "scope",
+                "tokenPrefix"
             ],
             "properties": {
-                "canEdit": {
-                    "type": "boolean"
+                "createdAt": {
+                    "type": "string"
                 },
-                "description": {
+                "expiresAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "lastUsedAt": {
                     "type": "string"
                 },
-                "validators": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/validation.ValidatorDto"
-                    }
+                "name": {
+                    "type": "string"
                 },
-                "valueType": {
-                    "$ref": "#/definitions/db.ValueTypeKind"
+                "revokedAt": {
+                    "type": "string"
                 },
-                "valueTypeId": {
-                    "type": "integer"
+                "scope": {
+                    "$ref": "#/definitions/db.ApiTokenScope"
                 },
-                "valueTypeName": {
+                "tokenPrefix": {
                     "type": "string"
                 }
             }
         },
-        "key.KeyItemDto": {
+        "membership.CreatedApiTokenDto": {
             "type": "object",
             "required": [
-                "description",
                 "id",
-                "name",
-                "valueType",
-                "valueTypeId",
-                "valueTypeName"
+                "token"
             ],
             "properties": {
-                "description": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
-                },
-                "valueType": {
-                    "$ref": "#/definitions/db.ValueTypeKind"
-                },
-                "valueTypeId": {
-                    "type": "integer"
-                },
-                "valueTypeName": {
+                "token": {
                     "type": "string"
                 }
             }
@@ -7329,6 +10695,8 @@ const docTemplate = `{
                 "globalAdministrator",
                 "groups",
                 "permissions",
+                "revealSecrets",
+                "serviceAccount",
                 "username"
             ],
             "properties": {
@@ -7347,6 +10715,12 @@ const docTemplate = `{
                         "$ref": "#/definitions/membership.PermissionDto"
                     }
                 },
+                "revealSecrets": {
+                    "type": "boolean"
+                },
+                "serviceAccount": {
+                    "type": "boolean"
+                },
                 "username": {
                     "type": "string"
                 }
@@ -7386,6 +10760,27 @@ const docTemplate = `{
                 }
             }
         },
+        "service.ReviewPolicyDto": {
+            "type": "object",
+            "required": [
+                "allowSelfApproval",
+                "requiredApprovals"
+            ],
+            "properties": {
+                "allowSelfApproval": {
+                    "type": "boolean"
+                },
+                "requiredApprovals": {
+                    "type": "integer"
+                },
+                "requiredUserGroupId": {
+                    "type": "integer"
+                },
+                "requiredUserGroupName": {
+                    "type": "string"
+                }
+            }
+        },
         "service.ServiceAdminDto": {
             "type": "object",
             "required": [
@@ -7609,14 +11004,20 @@ const docTemplate = `{
                 "integer",
                 "float",
                 "regex",
-                "json_schema"
+                "json_schema",
+                "string_list",
+                "duration",
+                "expression"
             ],
             "x-enum-varnames": [
                 "ValueValidatorParameterTypeNone",
                 "ValueValidatorParameterTypeInteger",
                 "ValueValidatorParameterTypeFloat",
                 "ValueValidatorParameterTypeRegex",
-                "ValueValidatorParameterTypeJsonSchema"
+                "ValueValidatorParameterTypeJsonSchema",
+                "ValueValidatorParameterTypeStringList",
+                "ValueValidatorParameterTypeDuration",
+                "ValueValidatorParameterTypeExpression"
             ]
         },
         "value.NewValueInfo": {
@@ -7666,6 +11067,9 @@ const docTemplate = `{
                 "rank": {
                     "type": "integer"
                 },
+                "rollout": {
+                    "$ref": "#/definitions/core.Rollout"
+                },
                 "variation": {
                     "type": "object",
                     "additionalProperties": {
@@ -7872,6 +11276,83 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "webhook.WebhookDeliveryDto": {
+            "type": "object",
+            "required": [
+                "attempts",
+                "changesetId",
+                "createdAt",
+                "event",
+                "id",
+                "payload",
+                "status"
+            ],
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "changesetId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "event": {
+                    "$ref": "#/definitions/db.WebhookEventType"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastAttemptAt": {
+                    "type": "string"
+                },
+                "lastError": {
+                    "type": "string"
+                },
+                "nextAttemptAt": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "responseStatus": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/db.WebhookDeliveryStatus"
+                }
+            }
+        },
+        "webhook.WebhookDto": {
+            "type": "object",
+            "required": [
+                "createdAt",
+                "enabled",
+                "events",
+                "id",
+                "url"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.WebhookEventType"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -31,7 +31,12 @@ type GetConfigurationRequest struct {
 	// Optional mode (e.g., "production")
 	Mode *string `protobuf:"bytes,3,opt,name=mode,proto3,oneof" json:"mode,omitempty"`
 	// Variation context as key-value pairs
-	Variation     map[string]string `protobuf:"bytes,4,rep,name=variation,proto3" json:"variation,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Variation map[string]string `protobuf:"bytes,4,rep,name=variation,proto3" json:"variation,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Optional point in time, resolves to the last changeset applied at or before it. Cannot be combined with changeset_id
+	AsOf *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=as_of,json=asOf,proto3,oneof" json:"as_of,omitempty"`
+	// Requires the variation to specify all properties and returns exactly one resolved value per key,
+	// with JSON values already merged
+	Resolve       *bool `protobuf:"varint,6,opt,name=resolve,proto3,oneof" json:"resolve,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -94,6 +99,20 @@ func (x *GetConfigurationRequest) GetVariation() map[string]string {
 	return nil
 }
 
+func (x *GetConfigurationRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *GetConfigurationRequest) GetResolve() bool {
+	if x != nil && x.Resolve != nil {
+		return *x.Resolve
+	}
+	return false
+}
+
 type GetConfigurationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChangesetId   uint32                 `protobuf:"varint,1,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
@@ -207,10 +226,12 @@ func (x *Feature) GetKeys() []*ConfigKey {
 }
 
 type ConfigKey struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	DataType      string                 `protobuf:"bytes,2,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`
-	Values        []*ConfigValue         `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DataType string                 `protobuf:"bytes,2,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`
+	Values   []*ConfigValue         `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	// Values allowed by the enum validator of the key
+	AllowedValues []string `protobuf:"bytes,4,rep,name=allowed_values,json=allowedValues,proto3" json:"allowed_values,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -266,11 +287,20 @@ func (x *ConfigKey) GetValues() []*ConfigValue {
 	return nil
 }
 
+func (x *ConfigKey) GetAllowedValues() []string {
+	if x != nil {
+		return x.AllowedValues
+	}
+	return nil
+}
+
 type ConfigValue struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Data          string                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
-	Rank          int32                  `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`
-	Variation     map[string]string      `protobuf:"bytes,3,rep,name=variation,proto3" json:"variation,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Data      string                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Rank      int32                  `protobuf:"varint,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	Variation map[string]string      `protobuf:"bytes,3,rep,name=variation,proto3" json:"variation,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Set when the value applies only to a percentage of the subjects, lower ranked values apply to the rest
+	Rollout       *Rollout `protobuf:"bytes,4,opt,name=rollout,proto3,oneof" json:"rollout,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -326,6 +356,425 @@ func (x *ConfigValue) GetVariation() map[string]string {
 	return nil
 }
 
+func (x *ConfigValue) GetRollout() *Rollout {
+	if x != nil {
+		return x.Rollout
+	}
+	return nil
+}
+
+type Rollout struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Percentage of the subjects the value applies to, 0 to 100
+	Percentage int32 `protobuf:"varint,1,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	// Attribute whose value is hashed to bucket the subjects, e.g. user_id
+	Attribute     string `protobuf:"bytes,2,opt,name=attribute,proto3" json:"attribute,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rollout) Reset() {
+	*x = Rollout{}
+	mi := &file_configuration_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rollout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rollout) ProtoMessage() {}
+
+func (x *Rollout) ProtoReflect() protoreflect.Message {
+	mi := &file_configuration_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rollout.ProtoReflect.Descriptor instead.
+func (*Rollout) Descriptor() ([]byte, []int) {
+	return file_configuration_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Rollout) GetPercentage() int32 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *Rollout) GetAttribute() string {
+	if x != nil {
+		return x.Attribute
+	}
+	return ""
+}
+
+type GetConfigurationDeltaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Service versions in format "service:version"
+	Services []string `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	// Changeset ID of the configuration to compute the delta from
+	FromChangesetId uint32 `protobuf:"varint,2,opt,name=from_changeset_id,json=fromChangesetId,proto3" json:"from_changeset_id,omitempty"`
+	// Optional changeset ID of the configuration to compute the delta to, defaults to the last applied changeset
+	ToChangesetId *uint32 `protobuf:"varint,3,opt,name=to_changeset_id,json=toChangesetId,proto3,oneof" json:"to_changeset_id,omitempty"`
+	// Optional mode (e.g., "production")
+	Mode *string `protobuf:"bytes,4,opt,name=mode,proto3,oneof" json:"mode,omitempty"`
+	// Variation context as key-value pairs
+	Variation     map[string]string `protobuf:"bytes,5,rep,name=variation,proto3" json:"variation,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigurationDeltaRequest) Reset() {
+	*x = GetConfigurationDeltaRequest{}
+	mi := &file_configuration_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigurationDeltaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigurationDeltaRequest) ProtoMessage() {}
+
+func (x *GetConfigurationDeltaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_configuration_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigurationDeltaRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigurationDeltaRequest) Descriptor() ([]byte, []int) {
+	return file_configuration_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetConfigurationDeltaRequest) GetServices() []string {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *GetConfigurationDeltaRequest) GetFromChangesetId() uint32 {
+	if x != nil {
+		return x.FromChangesetId
+	}
+	return 0
+}
+
+func (x *GetConfigurationDeltaRequest) GetToChangesetId() uint32 {
+	if x != nil && x.ToChangesetId != nil {
+		return *x.ToChangesetId
+	}
+	return 0
+}
+
+func (x *GetConfigurationDeltaRequest) GetMode() string {
+	if x != nil && x.Mode != nil {
+		return *x.Mode
+	}
+	return ""
+}
+
+func (x *GetConfigurationDeltaRequest) GetVariation() map[string]string {
+	if x != nil {
+		return x.Variation
+	}
+	return nil
+}
+
+type GetConfigurationDeltaResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	FromChangesetId uint32                 `protobuf:"varint,1,opt,name=from_changeset_id,json=fromChangesetId,proto3" json:"from_changeset_id,omitempty"`
+	ChangesetId     uint32                 `protobuf:"varint,2,opt,name=changeset_id,json=changesetId,proto3" json:"changeset_id,omitempty"`
+	AppliedAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=applied_at,json=appliedAt,proto3,oneof" json:"applied_at,omitempty"`
+	// Features with added or changed keys
+	Features        []*FeatureDelta `protobuf:"bytes,4,rep,name=features,proto3" json:"features,omitempty"`
+	RemovedFeatures []string        `protobuf:"bytes,5,rep,name=removed_features,json=removedFeatures,proto3" json:"removed_features,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetConfigurationDeltaResponse) Reset() {
+	*x = GetConfigurationDeltaResponse{}
+	mi := &file_configuration_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigurationDeltaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigurationDeltaResponse) ProtoMessage() {}
+
+func (x *GetConfigurationDeltaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_configuration_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigurationDeltaResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigurationDeltaResponse) Descriptor() ([]byte, []int) {
+	return file_configuration_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetConfigurationDeltaResponse) GetFromChangesetId() uint32 {
+	if x != nil {
+		return x.FromChangesetId
+	}
+	return 0
+}
+
+func (x *GetConfigurationDeltaResponse) GetChangesetId() uint32 {
+	if x != nil {
+		return x.ChangesetId
+	}
+	return 0
+}
+
+func (x *GetConfigurationDeltaResponse) GetAppliedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AppliedAt
+	}
+	return nil
+}
+
+func (x *GetConfigurationDeltaResponse) GetFeatures() []*FeatureDelta {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *GetConfigurationDeltaResponse) GetRemovedFeatures() []string {
+	if x != nil {
+		return x.RemovedFeatures
+	}
+	return nil
+}
+
+type FeatureDelta struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Added or changed keys. Removed keys are applied first, so a key can be both removed and added
+	Keys          []*ConfigKeyDelta `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	RemovedKeys   []string          `protobuf:"bytes,3,rep,name=removed_keys,json=removedKeys,proto3" json:"removed_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeatureDelta) Reset() {
+	*x = FeatureDelta{}
+	mi := &file_configuration_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureDelta) ProtoMessage() {}
+
+func (x *FeatureDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_configuration_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureDelta.ProtoReflect.Descriptor instead.
+func (*FeatureDelta) Descriptor() ([]byte, []int) {
+	return file_configuration_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FeatureDelta) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FeatureDelta) GetKeys() []*ConfigKeyDelta {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *FeatureDelta) GetRemovedKeys() []string {
+	if x != nil {
+		return x.RemovedKeys
+	}
+	return nil
+}
+
+type ConfigKeyDelta struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DataType string                 `protobuf:"bytes,2,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`
+	// Added or changed values, identified by their variation
+	Values        []*ConfigValue        `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	RemovedValues []*RemovedConfigValue `protobuf:"bytes,4,rep,name=removed_values,json=removedValues,proto3" json:"removed_values,omitempty"`
+	AllowedValues []string              `protobuf:"bytes,5,rep,name=allowed_values,json=allowedValues,proto3" json:"allowed_values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigKeyDelta) Reset() {
+	*x = ConfigKeyDelta{}
+	mi := &file_configuration_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigKeyDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigKeyDelta) ProtoMessage() {}
+
+func (x *ConfigKeyDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_configuration_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigKeyDelta.ProtoReflect.Descriptor instead.
+func (*ConfigKeyDelta) Descriptor() ([]byte, []int) {
+	return file_configuration_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ConfigKeyDelta) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ConfigKeyDelta) GetDataType() string {
+	if x != nil {
+		return x.DataType
+	}
+	return ""
+}
+
+func (x *ConfigKeyDelta) GetValues() []*ConfigValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *ConfigKeyDelta) GetRemovedValues() []*RemovedConfigValue {
+	if x != nil {
+		return x.RemovedValues
+	}
+	return nil
+}
+
+func (x *ConfigKeyDelta) GetAllowedValues() []string {
+	if x != nil {
+		return x.AllowedValues
+	}
+	return nil
+}
+
+type RemovedConfigValue struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Variation map[string]string      `protobuf:"bytes,1,rep,name=variation,proto3" json:"variation,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Whether the removed value is the rolled out value of the variation
+	Rollout bool `protobuf:"varint,2,opt,name=rollout,proto3" json:"rollout,omitempty"`
+	// Values with the same unresolved variation are told apart by their rank
+	Rank          int32 `protobuf:"varint,3,opt,name=rank,proto3" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemovedConfigValue) Reset() {
+	*x = RemovedConfigValue{}
+	mi := &file_configuration_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemovedConfigValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovedConfigValue) ProtoMessage() {}
+
+func (x *RemovedConfigValue) ProtoReflect() protoreflect.Message {
+	mi := &file_configuration_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovedConfigValue.ProtoReflect.Descriptor instead.
+func (*RemovedConfigValue) Descriptor() ([]byte, []int) {
+	return file_configuration_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RemovedConfigValue) GetVariation() map[string]string {
+	if x != nil {
+		return x.Variation
+	}
+	return nil
+}
+
+func (x *RemovedConfigValue) GetRollout() bool {
+	if x != nil {
+		return x.Rollout
+	}
+	return false
+}
+
+func (x *RemovedConfigValue) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
 type GetNextChangesetsRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	AfterChangesetId uint32                 `protobuf:"varint,1,opt,name=after_changeset_id,json=afterChangesetId,proto3" json:"after_changeset_id,omitempty"`
@@ -336,7 +785,7 @@ type GetNextChangesetsRequest struct {
 
 func (x *GetNextChangesetsRequest) Reset() {
 	*x = GetNextChangesetsRequest{}
-	mi := &file_configuration_proto_msgTypes[5]
+	mi := &file_configuration_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -348,7 +797,7 @@ func (x *GetNextChangesetsRequest) String() string {
 func (*GetNextChangesetsRequest) ProtoMessage() {}
 
 func (x *GetNextChangesetsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_configuration_proto_msgTypes[5]
+	mi := &file_configuration_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -361,7 +810,7 @@ func (x *GetNextChangesetsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNextChangesetsRequest.ProtoReflect.Descriptor instead.
 func (*GetNextChangesetsRequest) Descriptor() ([]byte, []int) {
-	return file_configuration_proto_rawDescGZIP(), []int{5}
+	return file_configuration_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetNextChangesetsRequest) GetAfterChangesetId() uint32 {
@@ -387,7 +836,7 @@ type GetNextChangesetsResponse struct {
 
 func (x *GetNextChangesetsResponse) Reset() {
 	*x = GetNextChangesetsResponse{}
-	mi := &file_configuration_proto_msgTypes[6]
+	mi := &file_configuration_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -399,7 +848,7 @@ func (x *GetNextChangesetsResponse) String() string {
 func (*GetNextChangesetsResponse) ProtoMessage() {}
 
 func (x *GetNextChangesetsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_configuration_proto_msgTypes[6]
+	mi := &file_configuration_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -412,7 +861,7 @@ func (x *GetNextChangesetsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNextChangesetsResponse.ProtoReflect.Descriptor instead.
 func (*GetNextChangesetsResponse) Descriptor() ([]byte, []int) {
-	return file_configuration_proto_rawDescGZIP(), []int{6}
+	return file_configuration_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetNextChangesetsResponse) GetChangesetIds() []uint32 {
@@ -422,6 +871,102 @@ func (x *GetNextChangesetsResponse) GetChangesetIds() []uint32 {
 	return nil
 }
 
+type WatchConfigurationRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AfterChangesetId uint32                 `protobuf:"varint,1,opt,name=after_changeset_id,json=afterChangesetId,proto3" json:"after_changeset_id,omitempty"`
+	Services         []string               `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *WatchConfigurationRequest) Reset() {
+	*x = WatchConfigurationRequest{}
+	mi := &file_configuration_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchConfigurationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchConfigurationRequest) ProtoMessage() {}
+
+func (x *WatchConfigurationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_configuration_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchConfigurationRequest.ProtoReflect.Descriptor instead.
+func (*WatchConfigurationRequest) Descriptor() ([]byte, []int) {
+	return file_configuration_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchConfigurationRequest) GetAfterChangesetId() uint32 {
+	if x != nil {
+		return x.AfterChangesetId
+	}
+	return 0
+}
+
+func (x *WatchConfigurationRequest) GetServices() []string {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+type WatchConfigurationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChangesetIds  []uint32               `protobuf:"varint,1,rep,packed,name=changeset_ids,json=changesetIds,proto3" json:"changeset_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchConfigurationResponse) Reset() {
+	*x = WatchConfigurationResponse{}
+	mi := &file_configuration_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchConfigurationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchConfigurationResponse) ProtoMessage() {}
+
+func (x *WatchConfigurationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_configuration_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchConfigurationResponse.ProtoReflect.Descriptor instead.
+func (*WatchConfigurationResponse) Descriptor() ([]byte, []int) {
+	return file_configuration_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WatchConfigurationResponse) GetChangesetIds() []uint32 {
+	if x != nil {
+		return x.ChangesetIds
+	}
+	return nil
+}
+
 type VariationHierarchyProperty struct {
 	state         protoimpl.MessageState             `protogen:"open.v1"`
 	Name          string                             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -432,7 +977,7 @@ type VariationHierarchyProperty struct {
 
 func (x *VariationHierarchyProperty) Reset() {
 	*x = VariationHierarchyProperty{}
-	mi := &file_configuration_proto_msgTypes[7]
+	mi := &file_configuration_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -444,7 +989,7 @@ func (x *VariationHierarchyProperty) String() string {
 func (*VariationHierarchyProperty) ProtoMessage() {}
 
 func (x *VariationHierarchyProperty) ProtoReflect() protoreflect.Message {
-	mi := &file_configuration_proto_msgTypes[7]
+	mi := &file_configuration_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -457,7 +1002,7 @@ func (x *VariationHierarchyProperty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VariationHierarchyProperty.ProtoReflect.Descriptor instead.
 func (*VariationHierarchyProperty) Descriptor() ([]byte, []int) {
-	return file_configuration_proto_rawDescGZIP(), []int{7}
+	return file_configuration_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *VariationHierarchyProperty) GetName() string {
@@ -484,7 +1029,7 @@ type VariationHierarchyPropertyValue struct {
 
 func (x *VariationHierarchyPropertyValue) Reset() {
 	*x = VariationHierarchyPropertyValue{}
-	mi := &file_configuration_proto_msgTypes[8]
+	mi := &file_configuration_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -496,7 +1041,7 @@ func (x *VariationHierarchyPropertyValue) String() string {
 func (*VariationHierarchyPropertyValue) ProtoMessage() {}
 
 func (x *VariationHierarchyPropertyValue) ProtoReflect() protoreflect.Message {
-	mi := &file_configuration_proto_msgTypes[8]
+	mi := &file_configuration_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -509,7 +1054,7 @@ func (x *VariationHierarchyPropertyValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VariationHierarchyPropertyValue.ProtoReflect.Descriptor instead.
 func (*VariationHierarchyPropertyValue) Descriptor() ([]byte, []int) {
-	return file_configuration_proto_rawDescGZIP(), []int{8}
+	return file_configuration_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *VariationHierarchyPropertyValue) GetValue() string {
@@ -535,7 +1080,7 @@ type GetVariationHierarchyRequest struct {
 
 func (x *GetVariationHierarchyRequest) Reset() {
 	*x = GetVariationHierarchyRequest{}
-	mi := &file_configuration_proto_msgTypes[9]
+	mi := &file_configuration_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -547,7 +1092,7 @@ func (x *GetVariationHierarchyRequest) String() string {
 func (*GetVariationHierarchyRequest) ProtoMessage() {}
 
 func (x *GetVariationHierarchyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_configuration_proto_msgTypes[9]
+	mi := &file_configuration_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -560,7 +1105,7 @@ func (x *GetVariationHierarchyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVariationHierarchyRequest.ProtoReflect.Descriptor instead.
 func (*GetVariationHierarchyRequest) Descriptor() ([]byte, []int) {
-	return file_configuration_proto_rawDescGZIP(), []int{9}
+	return file_configuration_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetVariationHierarchyRequest) GetServices() []string {
@@ -579,7 +1124,7 @@ type GetVariationHierarchyResponse struct {
 
 func (x *GetVariationHierarchyResponse) Reset() {
 	*x = GetVariationHierarchyResponse{}
-	mi := &file_configuration_proto_msgTypes[10]
+	mi := &file_configuration_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -591,7 +1136,7 @@ func (x *GetVariationHierarchyResponse) String() string {
 func (*GetVariationHierarchyResponse) ProtoMessage() {}
 
 func (x *GetVariationHierarchyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_configuration_proto_msgTypes[10]
+	mi := &file_configuration_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -604,7 +1149,7 @@ func (x *GetVariationHierarchyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVariationHierarchyResponse.ProtoReflect.Descriptor instead.
 func (*GetVariationHierarchyResponse) Descriptor() ([]byte, []int) {
-	return file_configuration_proto_rawDescGZIP(), []int{10}
+	return file_configuration_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GetVariationHierarchyResponse) GetProperties() []*VariationHierarchyProperty {
@@ -618,17 +1163,22 @@ var File_configuration_proto protoreflect.FileDescriptor
 
 const file_configuration_proto_rawDesc = "" +
 	"\n" +
-	"\x13configuration.proto\x12\agrpcgen\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9d\x02\n" +
+	"\x13configuration.proto\x12\agrpcgen\x1a\x1fgoogle/protobuf/timestamp.proto\"\x88\x03\n" +
 	"\x17GetConfigurationRequest\x12\x1a\n" +
 	"\bservices\x18\x01 \x03(\tR\bservices\x12&\n" +
 	"\fchangeset_id\x18\x02 \x01(\rH\x00R\vchangesetId\x88\x01\x01\x12\x17\n" +
 	"\x04mode\x18\x03 \x01(\tH\x01R\x04mode\x88\x01\x01\x12M\n" +
-	"\tvariation\x18\x04 \x03(\v2/.grpcgen.GetConfigurationRequest.VariationEntryR\tvariation\x1a<\n" +
+	"\tvariation\x18\x04 \x03(\v2/.grpcgen.GetConfigurationRequest.VariationEntryR\tvariation\x124\n" +
+	"\x05as_of\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampH\x02R\x04asOf\x88\x01\x01\x12\x1d\n" +
+	"\aresolve\x18\x06 \x01(\bH\x03R\aresolve\x88\x01\x01\x1a<\n" +
 	"\x0eVariationEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x0f\n" +
 	"\r_changeset_idB\a\n" +
-	"\x05_mode\"\xba\x01\n" +
+	"\x05_modeB\b\n" +
+	"\x06_as_ofB\n" +
+	"\n" +
+	"\b_resolve\"\xba\x01\n" +
 	"\x18GetConfigurationResponse\x12!\n" +
 	"\fchangeset_id\x18\x01 \x01(\rR\vchangesetId\x12,\n" +
 	"\bfeatures\x18\x02 \x03(\v2\x10.grpcgen.FeatureR\bfeatures\x12>\n" +
@@ -637,15 +1187,60 @@ const file_configuration_proto_rawDesc = "" +
 	"\v_applied_at\"E\n" +
 	"\aFeature\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12&\n" +
-	"\x04keys\x18\x02 \x03(\v2\x12.grpcgen.ConfigKeyR\x04keys\"j\n" +
+	"\x04keys\x18\x02 \x03(\v2\x12.grpcgen.ConfigKeyR\x04keys\"\x91\x01\n" +
 	"\tConfigKey\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
 	"\tdata_type\x18\x02 \x01(\tR\bdataType\x12,\n" +
-	"\x06values\x18\x03 \x03(\v2\x14.grpcgen.ConfigValueR\x06values\"\xb6\x01\n" +
+	"\x06values\x18\x03 \x03(\v2\x14.grpcgen.ConfigValueR\x06values\x12%\n" +
+	"\x0eallowed_values\x18\x04 \x03(\tR\rallowedValues\"\xf3\x01\n" +
 	"\vConfigValue\x12\x12\n" +
 	"\x04data\x18\x01 \x01(\tR\x04data\x12\x12\n" +
 	"\x04rank\x18\x02 \x01(\x05R\x04rank\x12A\n" +
-	"\tvariation\x18\x03 \x03(\v2#.grpcgen.ConfigValue.VariationEntryR\tvariation\x1a<\n" +
+	"\tvariation\x18\x03 \x03(\v2#.grpcgen.ConfigValue.VariationEntryR\tvariation\x12/\n" +
+	"\arollout\x18\x04 \x01(\v2\x10.grpcgen.RolloutH\x00R\arollout\x88\x01\x01\x1a<\n" +
+	"\x0eVariationEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\n" +
+	"\n" +
+	"\b_rollout\"G\n" +
+	"\aRollout\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x01 \x01(\x05R\n" +
+	"percentage\x12\x1c\n" +
+	"\tattribute\x18\x02 \x01(\tR\tattribute\"\xdb\x02\n" +
+	"\x1cGetConfigurationDeltaRequest\x12\x1a\n" +
+	"\bservices\x18\x01 \x03(\tR\bservices\x12*\n" +
+	"\x11from_changeset_id\x18\x02 \x01(\rR\x0ffromChangesetId\x12+\n" +
+	"\x0fto_changeset_id\x18\x03 \x01(\rH\x00R\rtoChangesetId\x88\x01\x01\x12\x17\n" +
+	"\x04mode\x18\x04 \x01(\tH\x01R\x04mode\x88\x01\x01\x12R\n" +
+	"\tvariation\x18\x05 \x03(\v24.grpcgen.GetConfigurationDeltaRequest.VariationEntryR\tvariation\x1a<\n" +
+	"\x0eVariationEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x12\n" +
+	"\x10_to_changeset_idB\a\n" +
+	"\x05_mode\"\x9b\x02\n" +
+	"\x1dGetConfigurationDeltaResponse\x12*\n" +
+	"\x11from_changeset_id\x18\x01 \x01(\rR\x0ffromChangesetId\x12!\n" +
+	"\fchangeset_id\x18\x02 \x01(\rR\vchangesetId\x12>\n" +
+	"\n" +
+	"applied_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tappliedAt\x88\x01\x01\x121\n" +
+	"\bfeatures\x18\x04 \x03(\v2\x15.grpcgen.FeatureDeltaR\bfeatures\x12)\n" +
+	"\x10removed_features\x18\x05 \x03(\tR\x0fremovedFeaturesB\r\n" +
+	"\v_applied_at\"r\n" +
+	"\fFeatureDelta\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12+\n" +
+	"\x04keys\x18\x02 \x03(\v2\x17.grpcgen.ConfigKeyDeltaR\x04keys\x12!\n" +
+	"\fremoved_keys\x18\x03 \x03(\tR\vremovedKeys\"\xda\x01\n" +
+	"\x0eConfigKeyDelta\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tdata_type\x18\x02 \x01(\tR\bdataType\x12,\n" +
+	"\x06values\x18\x03 \x03(\v2\x14.grpcgen.ConfigValueR\x06values\x12B\n" +
+	"\x0eremoved_values\x18\x04 \x03(\v2\x1b.grpcgen.RemovedConfigValueR\rremovedValues\x12%\n" +
+	"\x0eallowed_values\x18\x05 \x03(\tR\rallowedValues\"\xca\x01\n" +
+	"\x12RemovedConfigValue\x12H\n" +
+	"\tvariation\x18\x01 \x03(\v2*.grpcgen.RemovedConfigValue.VariationEntryR\tvariation\x12\x18\n" +
+	"\arollout\x18\x02 \x01(\bR\arollout\x12\x12\n" +
+	"\x04rank\x18\x03 \x01(\x05R\x04rank\x1a<\n" +
 	"\x0eVariationEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"d\n" +
@@ -653,6 +1248,11 @@ const file_configuration_proto_rawDesc = "" +
 	"\x12after_changeset_id\x18\x01 \x01(\rR\x10afterChangesetId\x12\x1a\n" +
 	"\bservices\x18\x02 \x03(\tR\bservices\"@\n" +
 	"\x19GetNextChangesetsResponse\x12#\n" +
+	"\rchangeset_ids\x18\x01 \x03(\rR\fchangesetIds\"e\n" +
+	"\x19WatchConfigurationRequest\x12,\n" +
+	"\x12after_changeset_id\x18\x01 \x01(\rR\x10afterChangesetId\x12\x1a\n" +
+	"\bservices\x18\x02 \x03(\tR\bservices\"A\n" +
+	"\x1aWatchConfigurationResponse\x12#\n" +
 	"\rchangeset_ids\x18\x01 \x03(\rR\fchangesetIds\"r\n" +
 	"\x1aVariationHierarchyProperty\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12@\n" +
@@ -665,11 +1265,13 @@ const file_configuration_proto_rawDesc = "" +
 	"\x1dGetVariationHierarchyResponse\x12C\n" +
 	"\n" +
 	"properties\x18\x01 \x03(\v2#.grpcgen.VariationHierarchyPropertyR\n" +
-	"properties2\xac\x02\n" +
+	"properties2\xf5\x03\n" +
 	"\rConfigService\x12W\n" +
-	"\x10GetConfiguration\x12 .grpcgen.GetConfigurationRequest\x1a!.grpcgen.GetConfigurationResponse\x12Z\n" +
+	"\x10GetConfiguration\x12 .grpcgen.GetConfigurationRequest\x1a!.grpcgen.GetConfigurationResponse\x12f\n" +
+	"\x15GetConfigurationDelta\x12%.grpcgen.GetConfigurationDeltaRequest\x1a&.grpcgen.GetConfigurationDeltaResponse\x12Z\n" +
 	"\x11GetNextChangesets\x12!.grpcgen.GetNextChangesetsRequest\x1a\".grpcgen.GetNextChangesetsResponse\x12f\n" +
-	"\x15GetVariationHierarchy\x12%.grpcgen.GetVariationHierarchyRequest\x1a&.grpcgen.GetVariationHierarchyResponseB/Z-github.com/necroskillz/config-service/grpcgenb\x06proto3"
+	"\x15GetVariationHierarchy\x12%.grpcgen.GetVariationHierarchyRequest\x1a&.grpcgen.GetVariationHierarchyResponse\x12_\n" +
+	"\x12WatchConfiguration\x12\".grpcgen.WatchConfigurationRequest\x1a#.grpcgen.WatchConfigurationResponse0\x01B/Z-github.com/necroskillz/config-service/grpcgenb\x06proto3"
 
 var (
 	file_configuration_proto_rawDescOnce sync.Once
@@ -683,44 +1285,67 @@ func file_configuration_proto_rawDescGZIP() []byte {
 	return file_configuration_proto_rawDescData
 }
 
-var file_configuration_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_configuration_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_configuration_proto_goTypes = []any{
 	(*GetConfigurationRequest)(nil),         // 0: grpcgen.GetConfigurationRequest
 	(*GetConfigurationResponse)(nil),        // 1: grpcgen.GetConfigurationResponse
 	(*Feature)(nil),                         // 2: grpcgen.Feature
 	(*ConfigKey)(nil),                       // 3: grpcgen.ConfigKey
 	(*ConfigValue)(nil),                     // 4: grpcgen.ConfigValue
-	(*GetNextChangesetsRequest)(nil),        // 5: grpcgen.GetNextChangesetsRequest
-	(*GetNextChangesetsResponse)(nil),       // 6: grpcgen.GetNextChangesetsResponse
-	(*VariationHierarchyProperty)(nil),      // 7: grpcgen.VariationHierarchyProperty
-	(*VariationHierarchyPropertyValue)(nil), // 8: grpcgen.VariationHierarchyPropertyValue
-	(*GetVariationHierarchyRequest)(nil),    // 9: grpcgen.GetVariationHierarchyRequest
-	(*GetVariationHierarchyResponse)(nil),   // 10: grpcgen.GetVariationHierarchyResponse
-	nil,                                     // 11: grpcgen.GetConfigurationRequest.VariationEntry
-	nil,                                     // 12: grpcgen.ConfigValue.VariationEntry
-	(*timestamppb.Timestamp)(nil),           // 13: google.protobuf.Timestamp
+	(*Rollout)(nil),                         // 5: grpcgen.Rollout
+	(*GetConfigurationDeltaRequest)(nil),    // 6: grpcgen.GetConfigurationDeltaRequest
+	(*GetConfigurationDeltaResponse)(nil),   // 7: grpcgen.GetConfigurationDeltaResponse
+	(*FeatureDelta)(nil),                    // 8: grpcgen.FeatureDelta
+	(*ConfigKeyDelta)(nil),                  // 9: grpcgen.ConfigKeyDelta
+	(*RemovedConfigValue)(nil),              // 10: grpcgen.RemovedConfigValue
+	(*GetNextChangesetsRequest)(nil),        // 11: grpcgen.GetNextChangesetsRequest
+	(*GetNextChangesetsResponse)(nil),       // 12: grpcgen.GetNextChangesetsResponse
+	(*WatchConfigurationRequest)(nil),       // 13: grpcgen.WatchConfigurationRequest
+	(*WatchConfigurationResponse)(nil),      // 14: grpcgen.WatchConfigurationResponse
+	(*VariationHierarchyProperty)(nil),      // 15: grpcgen.VariationHierarchyProperty
+	(*VariationHierarchyPropertyValue)(nil), // 16: grpcgen.VariationHierarchyPropertyValue
+	(*GetVariationHierarchyRequest)(nil),    // 17: grpcgen.GetVariationHierarchyRequest
+	(*GetVariationHierarchyResponse)(nil),   // 18: grpcgen.GetVariationHierarchyResponse
+	nil,                                     // 19: grpcgen.GetConfigurationRequest.VariationEntry
+	nil,                                     // 20: grpcgen.ConfigValue.VariationEntry
+	nil,                                     // 21: grpcgen.GetConfigurationDeltaRequest.VariationEntry
+	nil,                                     // 22: grpcgen.RemovedConfigValue.VariationEntry
+	(*timestamppb.Timestamp)(nil),           // 23: google.protobuf.Timestamp
 }
 var file_configuration_proto_depIdxs = []int32{
-	11, // 0: grpcgen.GetConfigurationRequest.variation:type_name -> grpcgen.GetConfigurationRequest.VariationEntry
-	2,  // 1: grpcgen.GetConfigurationResponse.features:type_name -> grpcgen.Feature
-	13, // 2: grpcgen.GetConfigurationResponse.applied_at:type_name -> google.protobuf.Timestamp
-	3,  // 3: grpcgen.Feature.keys:type_name -> grpcgen.ConfigKey
-	4,  // 4: grpcgen.ConfigKey.values:type_name -> grpcgen.ConfigValue
-	12, // 5: grpcgen.ConfigValue.variation:type_name -> grpcgen.ConfigValue.VariationEntry
-	8,  // 6: grpcgen.VariationHierarchyProperty.values:type_name -> grpcgen.VariationHierarchyPropertyValue
-	8,  // 7: grpcgen.VariationHierarchyPropertyValue.children:type_name -> grpcgen.VariationHierarchyPropertyValue
-	7,  // 8: grpcgen.GetVariationHierarchyResponse.properties:type_name -> grpcgen.VariationHierarchyProperty
-	0,  // 9: grpcgen.ConfigService.GetConfiguration:input_type -> grpcgen.GetConfigurationRequest
-	5,  // 10: grpcgen.ConfigService.GetNextChangesets:input_type -> grpcgen.GetNextChangesetsRequest
-	9,  // 11: grpcgen.ConfigService.GetVariationHierarchy:input_type -> grpcgen.GetVariationHierarchyRequest
-	1,  // 12: grpcgen.ConfigService.GetConfiguration:output_type -> grpcgen.GetConfigurationResponse
-	6,  // 13: grpcgen.ConfigService.GetNextChangesets:output_type -> grpcgen.GetNextChangesetsResponse
-	10, // 14: grpcgen.ConfigService.GetVariationHierarchy:output_type -> grpcgen.GetVariationHierarchyResponse
-	12, // [12:15] is the sub-list for method output_type
-	9,  // [9:12] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	19, // 0: grpcgen.GetConfigurationRequest.variation:type_name -> grpcgen.GetConfigurationRequest.VariationEntry
+	23, // 1: grpcgen.GetConfigurationRequest.as_of:type_name -> google.protobuf.Timestamp
+	2,  // 2: grpcgen.GetConfigurationResponse.features:type_name -> grpcgen.Feature
+	23, // 3: grpcgen.GetConfigurationResponse.applied_at:type_name -> google.protobuf.Timestamp
+	3,  // 4: grpcgen.Feature.keys:type_name -> grpcgen.ConfigKey
+	4,  // 5: grpcgen.ConfigKey.values:type_name -> grpcgen.ConfigValue
+	20, // 6: grpcgen.ConfigValue.variation:type_name -> grpcgen.ConfigValue.VariationEntry
+	5,  // 7: grpcgen.ConfigValue.rollout:type_name -> grpcgen.Rollout
+	21, // 8: grpcgen.GetConfigurationDeltaRequest.variation:type_name -> grpcgen.GetConfigurationDeltaRequest.VariationEntry
+	23, // 9: grpcgen.GetConfigurationDeltaResponse.applied_at:type_name -> google.protobuf.Timestamp
+	8,  // 10: grpcgen.GetConfigurationDeltaResponse.features:type_name -> grpcgen.FeatureDelta
+	9,  // 11: grpcgen.FeatureDelta.keys:type_name -> grpcgen.ConfigKeyDelta
+	4,  // 12: grpcgen.ConfigKeyDelta.values:type_name -> grpcgen.ConfigValue
+	10, // 13: grpcgen.ConfigKeyDelta.removed_values:type_name -> grpcgen.RemovedConfigValue
+	22, // 14: grpcgen.RemovedConfigValue.variation:type_name -> grpcgen.RemovedConfigValue.VariationEntry
+	16, // 15: grpcgen.VariationHierarchyProperty.values:type_name -> grpcgen.VariationHierarchyPropertyValue
+	16, // 16: grpcgen.VariationHierarchyPropertyValue.children:type_name -> grpcgen.VariationHierarchyPropertyValue
+	15, // 17: grpcgen.GetVariationHierarchyResponse.properties:type_name -> grpcgen.VariationHierarchyProperty
+	0,  // 18: grpcgen.ConfigService.GetConfiguration:input_type -> grpcgen.GetConfigurationRequest
+	6,  // 19: grpcgen.ConfigService.GetConfigurationDelta:input_type -> grpcgen.GetConfigurationDeltaRequest
+	11, // 20: grpcgen.ConfigService.GetNextChangesets:input_type -> grpcgen.GetNextChangesetsRequest
+	17, // 21: grpcgen.ConfigService.GetVariationHierarchy:input_type -> grpcgen.GetVariationHierarchyRequest
+	13, // 22: grpcgen.ConfigService.WatchConfiguration:input_type -> grpcgen.WatchConfigurationRequest
+	1,  // 23: grpcgen.ConfigService.GetConfiguration:output_type -> grpcgen.GetConfigurationResponse
+	7,  // 24: grpcgen.ConfigService.GetConfigurationDelta:output_type -> grpcgen.GetConfigurationDeltaResponse
+	12, // 25: grpcgen.ConfigService.GetNextChangesets:output_type -> grpcgen.GetNextChangesetsResponse
+	18, // 26: grpcgen.ConfigService.GetVariationHierarchy:output_type -> grpcgen.GetVariationHierarchyResponse
+	14, // 27: grpcgen.ConfigService.WatchConfiguration:output_type -> grpcgen.WatchConfigurationResponse
+	23, // [23:28] is the sub-list for method output_type
+	18, // [18:23] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_configuration_proto_init() }
@@ -730,13 +1355,16 @@ func file_configuration_proto_init() {
 	}
 	file_configuration_proto_msgTypes[0].OneofWrappers = []any{}
 	file_configuration_proto_msgTypes[1].OneofWrappers = []any{}
+	file_configuration_proto_msgTypes[4].OneofWrappers = []any{}
+	file_configuration_proto_msgTypes[6].OneofWrappers = []any{}
+	file_configuration_proto_msgTypes[7].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_configuration_proto_rawDesc), len(file_configuration_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
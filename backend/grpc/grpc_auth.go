@@ -0,0 +1,148 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/necroskillz/config-service/services/clientcredential"
+	"github.com/necroskillz/config-service/services/core"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ApiKeyMetadataKey is the metadata key clients send their API key in
+const ApiKeyMetadataKey = "x-api-key"
+
+type grantContextKey struct{}
+
+// grantFromContext returns the grant of the calling client, nil for anonymous clients
+func grantFromContext(ctx context.Context) *clientcredential.Grant {
+	grant, _ := ctx.Value(grantContextKey{}).(*clientcredential.Grant)
+	return grant
+}
+
+// servicesRequest is implemented by all requests that read configuration of services
+type servicesRequest interface {
+	GetServices() []string
+}
+
+// ClientAuthenticator authenticates configuration clients by API key or client certificate and checks that the
+// services they request are granted to them.
+type ClientAuthenticator struct {
+	clientCredentialService *clientcredential.Service
+	requireCredentials      bool
+}
+
+func NewClientAuthenticator(clientCredentialService *clientcredential.Service, requireCredentials bool) *ClientAuthenticator {
+	return &ClientAuthenticator{
+		clientCredentialService: clientCredentialService,
+		requireCredentials:      requireCredentials,
+	}
+}
+
+// authenticate returns the grant of the calling client. Anonymous clients get a nil grant, unless credentials are required.
+func (a *ClientAuthenticator) authenticate(ctx context.Context) (*clientcredential.Grant, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if keys := md.Get(ApiKeyMetadataKey); len(keys) > 0 {
+			grant, err := a.clientCredentialService.AuthenticateApiKey(ctx, keys[0])
+			if err != nil {
+				if errors.Is(err, core.ErrRecordNotFound) {
+					return nil, status.Error(codes.Unauthenticated, "invalid API key")
+				}
+
+				return nil, ToGRPCError(err)
+			}
+
+			return &grant, nil
+		}
+	}
+
+	// Client certificates are only present in verified chains when the server is configured to verify them
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.VerifiedChains) > 0 {
+			subject := tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+
+			grant, err := a.clientCredentialService.AuthenticateCertificate(ctx, subject)
+			if err != nil {
+				if errors.Is(err, core.ErrRecordNotFound) {
+					return nil, status.Errorf(codes.Unauthenticated, "client certificate %s is not registered", subject)
+				}
+
+				return nil, ToGRPCError(err)
+			}
+
+			return &grant, nil
+		}
+	}
+
+	if a.requireCredentials {
+		return nil, status.Error(codes.Unauthenticated, "client credentials are required")
+	}
+
+	return nil, nil
+}
+
+func authorize(grant *clientcredential.Grant, req any) error {
+	if grant == nil {
+		return nil
+	}
+
+	r, ok := req.(servicesRequest)
+	if !ok {
+		return nil
+	}
+
+	if err := grant.Authorize(r.GetServices()); err != nil {
+		return ToGRPCError(err)
+	}
+
+	return nil
+}
+
+func (a *ClientAuthenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		grant, err := a.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := authorize(grant, req); err != nil {
+			return nil, err
+		}
+
+		return handler(context.WithValue(ctx, grantContextKey{}, grant), req)
+	}
+}
+
+func (a *ClientAuthenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		grant, err := a.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authorizedServerStream{ServerStream: ss, grant: grant})
+	}
+}
+
+// authorizedServerStream checks the services of every message received from the client against the grant
+type authorizedServerStream struct {
+	grpc.ServerStream
+	grant *clientcredential.Grant
+}
+
+func (s *authorizedServerStream) Context() context.Context {
+	return context.WithValue(s.ServerStream.Context(), grantContextKey{}, s.grant)
+}
+
+func (s *authorizedServerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return authorize(s.grant, m)
+}
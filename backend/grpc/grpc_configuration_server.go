@@ -2,9 +2,11 @@ package grpc
 
 import (
 	"context"
+	"time"
 
 	pb "github.com/necroskillz/config-service/grpc/gen"
 	"github.com/necroskillz/config-service/services"
+	"github.com/necroskillz/config-service/services/changeset"
 	"github.com/necroskillz/config-service/services/configuration"
 	"github.com/necroskillz/config-service/services/core"
 	"github.com/necroskillz/config-service/services/variation"
@@ -12,16 +14,22 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Changesets applied by other instances are published through cache invalidation notifications, which can be missed
+// while the listener is reconnecting, so watchers also periodically check for new changesets.
+const watchCheckInterval = 30 * time.Second
+
 type ConfigurationServer struct {
 	pb.UnimplementedConfigServiceServer
 	ConfigurationService      *configuration.Service
 	VariationHierarchyService *variation.HierarchyService
+	ChangesetEventBroker      *changeset.EventBroker
 }
 
 func NewConfigurationServer(svc *services.Services) *ConfigurationServer {
 	return &ConfigurationServer{
 		ConfigurationService:      svc.ConfigurationService,
 		VariationHierarchyService: svc.VariationHierarchyService,
+		ChangesetEventBroker:      svc.ChangesetEventBroker,
 	}
 }
 
@@ -48,6 +56,50 @@ func (s *ConfigurationServer) GetNextChangesets(ctx context.Context, req *pb.Get
 	return response, nil
 }
 
+func (s *ConfigurationServer) WatchConfiguration(req *pb.WatchConfigurationRequest, stream pb.ConfigService_WatchConfigurationServer) error {
+	ctx := stream.Context()
+
+	serviceVersionSpecifiers, err := core.ParseServiceVersionSpecifiers(req.Services)
+	if err != nil {
+		return ToGRPCError(err)
+	}
+
+	events, unsubscribe := s.ChangesetEventBroker.Subscribe()
+	defer unsubscribe()
+
+	ticker := time.NewTicker(watchCheckInterval)
+	defer ticker.Stop()
+
+	lastChangesetID := uint(req.AfterChangesetId)
+
+	for {
+		changesets, err := s.ConfigurationService.GetNextChangesets(ctx, serviceVersionSpecifiers, lastChangesetID)
+		if err != nil {
+			return ToGRPCError(err)
+		}
+
+		if len(changesets) > 0 {
+			changesetIds := make([]uint32, len(changesets))
+			for i, changeset := range changesets {
+				changesetIds[i] = uint32(changeset)
+			}
+
+			if err := stream.Send(&pb.WatchConfigurationResponse{ChangesetIds: changesetIds}); err != nil {
+				return err
+			}
+
+			lastChangesetID = changesets[len(changesets)-1]
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-events:
+		case <-ticker.C:
+		}
+	}
+}
+
 func makeVariationHierarchyPropertyValues(values []configuration.VariationHierarchyPropertyValueDto) []*pb.VariationHierarchyPropertyValue {
 	dtos := make([]*pb.VariationHierarchyPropertyValue, len(values))
 	for i, value := range values {
@@ -86,6 +138,26 @@ func (s *ConfigurationServer) GetVariationHierarchy(ctx context.Context, req *pb
 	return response, nil
 }
 
+func makeConfigValues(values []configuration.ValueConfigurationDto) []*pb.ConfigValue {
+	dtos := make([]*pb.ConfigValue, len(values))
+	for i, value := range values {
+		dtos[i] = &pb.ConfigValue{
+			Data:      value.Data,
+			Variation: value.Variation,
+			Rank:      int32(value.Rank),
+		}
+
+		if value.Rollout != nil {
+			dtos[i].Rollout = &pb.Rollout{
+				Percentage: int32(value.Rollout.Percentage),
+				Attribute:  value.Rollout.Attribute,
+			}
+		}
+	}
+
+	return dtos
+}
+
 func (s *ConfigurationServer) GetConfiguration(ctx context.Context, req *pb.GetConfigurationRequest) (*pb.GetConfigurationResponse, error) {
 	serviceVersionSpecifiers, err := core.ParseServiceVersionSpecifiers(req.Services)
 	if err != nil {
@@ -102,11 +174,20 @@ func (s *ConfigurationServer) GetConfiguration(ctx context.Context, req *pb.GetC
 		return nil, ToGRPCError(err)
 	}
 
+	var asOf *time.Time
+	if req.AsOf != nil {
+		asOf = ptr.To(req.AsOf.AsTime())
+	}
+
 	configuration, err := s.ConfigurationService.GetConfiguration(ctx, configuration.GetConfigurationParams{
 		ServiceVersionSpecifiers: serviceVersionSpecifiers,
 		ChangesetID:              ptr.To(uint(ptr.From(req.ChangesetId)), ptr.NilIfZero()),
+		AsOf:                     asOf,
 		Mode:                     ptr.From(req.Mode),
+		Resolve:                  ptr.From(req.Resolve),
 		Variation:                variation,
+		RevealSecrets:            grantFromContext(ctx) != nil,
+		OmitRedactedSecrets:      true,
 	})
 	if err != nil {
 		return nil, ToGRPCError(err)
@@ -114,23 +195,14 @@ func (s *ConfigurationServer) GetConfiguration(ctx context.Context, req *pb.GetC
 
 	features := make([]*pb.Feature, len(configuration.Features))
 	for i, feature := range configuration.Features {
-		keys := make([]*pb.ConfigKey, len(feature.Keys))
-		for j, key := range feature.Keys {
-
-			values := make([]*pb.ConfigValue, len(key.Values))
-			for k, value := range key.Values {
-				values[k] = &pb.ConfigValue{
-					Data:      value.Data,
-					Variation: value.Variation,
-					Rank:      int32(value.Rank),
-				}
-			}
-
-			keys[j] = &pb.ConfigKey{
-				Name:     key.Name,
-				DataType: key.DataType,
-				Values:   values,
-			}
+		keys := make([]*pb.ConfigKey, 0, len(feature.Keys))
+		for _, key := range feature.Keys {
+			keys = append(keys, &pb.ConfigKey{
+				Name:          key.Name,
+				DataType:      key.DataType,
+				Values:        makeConfigValues(key.Values),
+				AllowedValues: key.AllowedValues,
+			})
 		}
 
 		features[i] = &pb.Feature{
@@ -150,3 +222,75 @@ func (s *ConfigurationServer) GetConfiguration(ctx context.Context, req *pb.GetC
 
 	return response, nil
 }
+
+func (s *ConfigurationServer) GetConfigurationDelta(ctx context.Context, req *pb.GetConfigurationDeltaRequest) (*pb.GetConfigurationDeltaResponse, error) {
+	serviceVersionSpecifiers, err := core.ParseServiceVersionSpecifiers(req.Services)
+	if err != nil {
+		return nil, ToGRPCError(err)
+	}
+
+	variationHierarchy, err := s.VariationHierarchyService.GetVariationHierarchy(ctx)
+	if err != nil {
+		return nil, ToGRPCError(err)
+	}
+
+	variation, err := variationHierarchy.GetVariationIDMap(req.Variation)
+	if err != nil {
+		return nil, ToGRPCError(err)
+	}
+
+	delta, err := s.ConfigurationService.GetConfigurationDelta(ctx, configuration.GetConfigurationDeltaParams{
+		ServiceVersionSpecifiers: serviceVersionSpecifiers,
+		FromChangesetID:          uint(req.FromChangesetId),
+		ToChangesetID:            ptr.To(uint(ptr.From(req.ToChangesetId)), ptr.NilIfZero()),
+		Mode:                     ptr.From(req.Mode),
+		Variation:                variation,
+		RevealSecrets:            grantFromContext(ctx) != nil,
+		OmitRedactedSecrets:      true,
+	})
+	if err != nil {
+		return nil, ToGRPCError(err)
+	}
+
+	features := make([]*pb.FeatureDelta, len(delta.Features))
+	for i, feature := range delta.Features {
+		keys := make([]*pb.ConfigKeyDelta, 0, len(feature.Keys))
+		for _, key := range feature.Keys {
+			removedValues := make([]*pb.RemovedConfigValue, len(key.RemovedValues))
+			for k, value := range key.RemovedValues {
+				removedValues[k] = &pb.RemovedConfigValue{
+					Variation: value.Variation,
+					Rank:      int32(value.Rank),
+					Rollout:   value.Rollout,
+				}
+			}
+
+			keys = append(keys, &pb.ConfigKeyDelta{
+				Name:          key.Name,
+				DataType:      key.DataType,
+				Values:        makeConfigValues(key.Values),
+				RemovedValues: removedValues,
+				AllowedValues: key.AllowedValues,
+			})
+		}
+
+		features[i] = &pb.FeatureDelta{
+			Name:        feature.Name,
+			Keys:        keys,
+			RemovedKeys: feature.RemovedKeys,
+		}
+	}
+
+	response := &pb.GetConfigurationDeltaResponse{
+		FromChangesetId: uint32(delta.FromChangesetID),
+		ChangesetId:     uint32(delta.ChangesetID),
+		Features:        features,
+		RemovedFeatures: delta.RemovedFeatures,
+	}
+
+	if delta.AppliedAt != nil {
+		response.AppliedAt = timestamppb.New(*delta.AppliedAt)
+	}
+
+	return response, nil
+}
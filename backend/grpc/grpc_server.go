@@ -16,16 +16,19 @@ import (
 	"github.com/necroskillz/config-service/db"
 	pb "github.com/necroskillz/config-service/grpc/gen"
 	"github.com/necroskillz/config-service/services"
+	"github.com/necroskillz/config-service/services/cacheinvalidation"
 	"github.com/necroskillz/config-service/util/logging"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
 )
 
 type Server struct {
-	grpcServer *grpc.Server
-	dbpool     *pgxpool.Pool
-	cache      *ristretto.Cache[string, any]
+	grpcServer                *grpc.Server
+	dbpool                    *pgxpool.Pool
+	cache                     *ristretto.Cache[string, any]
+	cacheInvalidationListener *cacheinvalidation.Listener
 }
 
 func NewServer() *Server {
@@ -74,7 +77,13 @@ func (s *Server) Start() error {
 	s.dbpool = dbpool
 	s.cache = cache
 
-	svc := services.InitializeServices(dbpool, cache)
+	svc, err := services.InitializeServices(dbpool, cache)
+	if err != nil {
+		return fmt.Errorf("failed to initialize services: %w", err)
+	}
+
+	s.cacheInvalidationListener = svc.CacheInvalidationListener
+	s.cacheInvalidationListener.Start(ctx)
 
 	logger := logging.ConfigureSlog("Config Service gRPC/server")
 
@@ -84,16 +93,33 @@ func (s *Server) Start() error {
 		return status.Errorf(codes.Internal, "%s", p)
 	}
 
-	s.grpcServer = grpc.NewServer(
+	clientAuthenticator := NewClientAuthenticator(svc.ClientCredentialService, os.Getenv("REQUIRE_CLIENT_CREDENTIALS") == "true")
+
+	serverOptions := []grpc.ServerOption{
 		grpc.ChainUnaryInterceptor(
 			grpcLogging.UnaryServerInterceptor(interceptorLogger(logger)),
 			recovery.UnaryServerInterceptor(recovery.WithRecoveryHandler(panicRecoveryHandler)),
+			clientAuthenticator.UnaryServerInterceptor(),
 		),
 		grpc.ChainStreamInterceptor(
 			grpcLogging.StreamServerInterceptor(interceptorLogger(logger)),
 			recovery.StreamServerInterceptor(recovery.WithRecoveryHandler(panicRecoveryHandler)),
+			clientAuthenticator.StreamServerInterceptor(),
 		),
-	)
+	}
+
+	tlsConfig, err := loadTLSConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load TLS configuration: %w", err)
+	}
+
+	if tlsConfig != nil {
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	} else {
+		slog.Warn("GRPC_TLS_CERT_FILE is not set, gRPC server is listening in plaintext")
+	}
+
+	s.grpcServer = grpc.NewServer(serverOptions...)
 	pb.RegisterConfigServiceServer(s.grpcServer, NewConfigurationServer(svc))
 
 	slog.Info("Starting gRPC server on port", "port", os.Getenv("GRPC_PORT"))
@@ -104,6 +130,10 @@ func (s *Server) Start() error {
 func (s *Server) Stop(ctx context.Context) error {
 	s.grpcServer.GracefulStop()
 
+	if s.cacheInvalidationListener != nil {
+		s.cacheInvalidationListener.Stop()
+	}
+
 	if s.dbpool != nil {
 		s.dbpool.Close()
 	}
@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// loadTLSConfig builds the TLS configuration of the server from environment variables. Returns nil when TLS is not configured.
+//
+//   - GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE are the PEM encoded server certificate and private key
+//   - GRPC_TLS_CLIENT_CA_FILE is the PEM encoded CA used to verify client certificates (mTLS). Clients without a certificate
+//     can still connect and authenticate with an API key, use REQUIRE_CLIENT_CREDENTIALS to reject anonymous clients.
+func loadTLSConfig() (*tls.Config, error) {
+	certFile := os.Getenv("GRPC_TLS_CERT_FILE")
+	keyFile := os.Getenv("GRPC_TLS_KEY_FILE")
+	clientCAFile := os.Getenv("GRPC_TLS_CLIENT_CA_FILE")
+
+	if certFile == "" {
+		if keyFile != "" || clientCAFile != "" {
+			return nil, fmt.Errorf("GRPC_TLS_CERT_FILE is required when GRPC_TLS_KEY_FILE or GRPC_TLS_CLIENT_CA_FILE is set")
+		}
+
+		return nil, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile != "" {
+		clientCA, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA: %w", err)
+		}
+
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(clientCA) {
+			return nil, fmt.Errorf("no certificates found in client CA file %s", clientCAFile)
+		}
+
+		tlsConfig.ClientCAs = clientCAs
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return tlsConfig, nil
+}
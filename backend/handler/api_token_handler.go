@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/membership"
+)
+
+// @Summary Get API tokens of a user
+// @Description Get API tokens of a user. The tokens themselves are never returned, only their prefix.
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path uint true "User ID"
+// @Success 200 {array} membership.ApiTokenDto
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /membership/users/{user_id}/tokens [get]
+func (h *Handler) GetApiTokens(c echo.Context) error {
+	var userID uint
+
+	err := echo.PathParamsBinder(c).MustUint("user_id", &userID).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	tokens, err := h.MembershipService.GetApiTokens(c.Request().Context(), userID)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+type CreateApiTokenRequest struct {
+	Name      string           `json:"name" validate:"required"`
+	Scope     db.ApiTokenScope `json:"scope" validate:"required"`
+	ExpiresAt *time.Time       `json:"expiresAt"`
+}
+
+// @Summary Create an API token
+// @Description Create an API token for the user. The token is returned only once, use it as "Authorization: Bearer cs_..." header.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path uint true "User ID"
+// @Param token body CreateApiTokenRequest true "API token"
+// @Success 200 {object} membership.CreatedApiTokenDto
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 422 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /membership/users/{user_id}/tokens [post]
+func (h *Handler) CreateApiToken(c echo.Context) error {
+	var userID uint
+
+	err := echo.PathParamsBinder(c).MustUint("user_id", &userID).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	var request CreateApiTokenRequest
+	err = c.Bind(&request)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	token, err := h.MembershipService.CreateApiToken(c.Request().Context(), membership.CreateApiTokenParams{
+		UserID:    userID,
+		Name:      request.Name,
+		Scope:     request.Scope,
+		ExpiresAt: request.ExpiresAt,
+	})
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, token)
+}
+
+// @Summary Revoke an API token
+// @Description Revoke an API token of the user
+// @Produce json
+// @Security BearerAuth
+// @Param user_id path uint true "User ID"
+// @Param token_id path uint true "Token ID"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /membership/users/{user_id}/tokens/{token_id} [delete]
+func (h *Handler) RevokeApiToken(c echo.Context) error {
+	var userID, tokenID uint
+
+	err := echo.PathParamsBinder(c).
+		MustUint("user_id", &userID).
+		MustUint("token_id", &tokenID).
+		BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	err = h.MembershipService.RevokeApiToken(c.Request().Context(), userID, tokenID)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+type CreateServiceAccountRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// @Summary Create a service account
+// @Description Create a user for automation that can only authenticate with API tokens
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param serviceAccount body CreateServiceAccountRequest true "Service account"
+// @Success 200 {object} CreateResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 422 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /membership/service-accounts [post]
+func (h *Handler) CreateServiceAccount(c echo.Context) error {
+	var request CreateServiceAccountRequest
+
+	err := c.Bind(&request)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	userID, err := h.MembershipService.CreateServiceAccount(c.Request().Context(), membership.CreateServiceAccountParams{
+		Name: request.Name,
+	})
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, NewCreateResponse(userID))
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/necroskillz/config-service/services/changeset"
@@ -116,6 +117,107 @@ func (h *Handler) ApplyChangeset(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// @Summary Approve a changeset
+// @Description Approve a committed changeset by ID. Approvals are required by services with a review policy.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param changeset_id path uint true "Changeset ID"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /changesets/{changeset_id}/approve [put]
+func (h *Handler) ApproveChangeset(c echo.Context) error {
+	var changesetID uint
+	err := echo.PathParamsBinder(c).MustUint("changeset_id", &changesetID).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	var request OptionalCommentRequest
+	err = c.Bind(&request)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	err = h.ChangesetService.ApproveChangeset(c.Request().Context(), changesetID, request.Comment)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+type ScheduleChangesetRequest struct {
+	ApplyAt time.Time `json:"applyAt" validate:"required"`
+	Comment *string   `json:"comment"`
+}
+
+// @Summary Schedule a changeset
+// @Description Schedule a changeset to be applied at the given time
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param changeset_id path uint true "Changeset ID"
+// @Param schedule body ScheduleChangesetRequest true "Schedule"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /changesets/{changeset_id}/schedule [put]
+func (h *Handler) ScheduleChangeset(c echo.Context) error {
+	var changesetID uint
+	err := echo.PathParamsBinder(c).MustUint("changeset_id", &changesetID).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	var request ScheduleChangesetRequest
+	err = c.Bind(&request)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	err = h.ChangesetService.ScheduleChangeset(c.Request().Context(), changesetID, request.ApplyAt, request.Comment)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// @Summary Unschedule a changeset
+// @Description Cancel the scheduled application of a changeset and move it back to the committed state
+// @Produce json
+// @Security BearerAuth
+// @Param changeset_id path uint true "Changeset ID"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /changesets/{changeset_id}/schedule [delete]
+func (h *Handler) UnscheduleChangeset(c echo.Context) error {
+	var changesetID uint
+	err := echo.PathParamsBinder(c).MustUint("changeset_id", &changesetID).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	err = h.ChangesetService.UnscheduleChangeset(c.Request().Context(), changesetID)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
 // @Summary Commit a changeset
 // @Description Commit a changeset by ID
 // @Accept json
@@ -177,6 +279,33 @@ func (h *Handler) ReopenChangeset(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// @Summary Revert a changeset
+// @Description Add the inverse of all changes of an applied changeset to the current changeset
+// @Produce json
+// @Security BearerAuth
+// @Param changeset_id path uint true "Changeset ID"
+// @Success 200 {object} CreateResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /changesets/{changeset_id}/revert [post]
+func (h *Handler) RevertChangeset(c echo.Context) error {
+	var changesetID uint
+	err := echo.PathParamsBinder(c).MustUint("changeset_id", &changesetID).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	revertChangesetID, err := h.ChangesetService.RevertChangeset(c.Request().Context(), changesetID)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, NewCreateResponse(revertChangesetID))
+}
+
 // @Summary Discard a changeset
 // @Description Discard a changeset by ID
 // @Produce json
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/clientcredential"
+)
+
+// @Summary Get client credentials
+// @Description Get credentials of configuration clients and the services they are allowed to read
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} clientcredential.ClientCredentialDto
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /client-credentials [get]
+func (h *Handler) GetClientCredentials(c echo.Context) error {
+	credentials, err := h.ClientCredentialService.GetClientCredentials(c.Request().Context())
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, credentials)
+}
+
+// @Summary Get client credential
+// @Description Get client credential by ID
+// @Produce json
+// @Security BearerAuth
+// @Param client_credential_id path uint true "Client credential ID"
+// @Success 200 {object} clientcredential.ClientCredentialDto
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /client-credentials/{client_credential_id} [get]
+func (h *Handler) GetClientCredential(c echo.Context) error {
+	var clientCredentialID uint
+
+	err := echo.PathParamsBinder(c).MustUint("client_credential_id", &clientCredentialID).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	credential, err := h.ClientCredentialService.GetClientCredential(c.Request().Context(), clientCredentialID)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, credential)
+}
+
+type CreateClientCredentialRequest struct {
+	Name               string                  `json:"name" validate:"required"`
+	Kind               db.ClientCredentialKind `json:"kind" validate:"required"`
+	CertificateSubject string                  `json:"certificateSubject"`
+	ServiceIDs         []uint                  `json:"serviceIds" validate:"required"`
+}
+
+// @Summary Create client credential
+// @Description Create a credential for a configuration client. API keys are returned only once, clients send them in the "x-api-key" gRPC metadata or "X-Api-Key" header.
+// @Description Certificate credentials match the common name of a verified client certificate.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param credential body CreateClientCredentialRequest true "Client credential"
+// @Success 200 {object} clientcredential.CreatedClientCredentialDto
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 422 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /client-credentials [post]
+func (h *Handler) CreateClientCredential(c echo.Context) error {
+	var request CreateClientCredentialRequest
+
+	err := c.Bind(&request)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	credential, err := h.ClientCredentialService.CreateClientCredential(c.Request().Context(), clientcredential.CreateClientCredentialParams{
+		Name:               request.Name,
+		Kind:               request.Kind,
+		CertificateSubject: request.CertificateSubject,
+		ServiceIDs:         request.ServiceIDs,
+	})
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, credential)
+}
+
+type UpdateClientCredentialRequest struct {
+	Name       string `json:"name" validate:"required"`
+	ServiceIDs []uint `json:"serviceIds" validate:"required"`
+}
+
+// @Summary Update client credential
+// @Description Update name and allowed services of a client credential
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param client_credential_id path uint true "Client credential ID"
+// @Param credential body UpdateClientCredentialRequest true "Client credential"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 422 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /client-credentials/{client_credential_id} [put]
+func (h *Handler) UpdateClientCredential(c echo.Context) error {
+	var clientCredentialID uint
+
+	err := echo.PathParamsBinder(c).MustUint("client_credential_id", &clientCredentialID).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	var request UpdateClientCredentialRequest
+	err = c.Bind(&request)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	err = h.ClientCredentialService.UpdateClientCredential(c.Request().Context(), clientcredential.UpdateClientCredentialParams{
+		ClientCredentialID: clientCredentialID,
+		Name:               request.Name,
+		ServiceIDs:         request.ServiceIDs,
+	})
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// @Summary Delete client credential
+// @Description Delete a client credential, the client immediately loses access
+// @Produce json
+// @Security BearerAuth
+// @Param client_credential_id path uint true "Client credential ID"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /client-credentials/{client_credential_id} [delete]
+func (h *Handler) DeleteClientCredential(c echo.Context) error {
+	var clientCredentialID uint
+
+	err := echo.PathParamsBinder(c).MustUint("client_credential_id", &clientCredentialID).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	err = h.ClientCredentialService.DeleteClientCredential(c.Request().Context(), clientCredentialID)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
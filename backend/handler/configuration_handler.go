@@ -1,9 +1,15 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/necroskillz/config-service/auth"
+	"github.com/necroskillz/config-service/constants"
+	"github.com/necroskillz/config-service/services/clientcredential"
 	"github.com/necroskillz/config-service/services/configuration"
 	"github.com/necroskillz/config-service/services/core"
 	"github.com/necroskillz/config-service/util/ptr"
@@ -70,11 +76,13 @@ func (h *Handler) GetVariationHierarchy(c echo.Context) error {
 }
 
 // @Summary Get configuration
-// @Description Get configuration
+// @Description Get configuration. Secret values are revealed to clients with client credentials and users allowed to reveal them, anonymous clients don't get secret keys.
 // @Produce json
 // @Param changesetId query uint false "Changeset ID"
 // @Param services[] query []string true "Service versions in format service:version" example(TestService:1) collectionFormat(multi)
+// @Param asOf query string false "Point in time (RFC3339), resolves to the last changeset applied at or before it" format(date-time)
 // @Param mode query string false "Mode" Enums(production)
+// @Param resolve query bool false "Return exactly one resolved value per key, requires the variation to specify all properties"
 // @Param variation[] query []string false "Variation" example(env:prod) collectionFormat(multi)
 // @Success 200 {object} configuration.ConfigurationDto
 // @Failure 400 {object} echo.HTTPError
@@ -85,7 +93,15 @@ func (h *Handler) GetConfiguration(c echo.Context) error {
 	var changesetID uint
 	var serviceVersions []string
 	var mode string
-	err := echo.QueryParamsBinder(c).Uint("changesetId", &changesetID).MustStrings("services[]", &serviceVersions).String("mode", &mode).BindError()
+	var asOf time.Time
+	var resolve bool
+	err := echo.QueryParamsBinder(c).
+		Uint("changesetId", &changesetID).
+		MustStrings("services[]", &serviceVersions).
+		String("mode", &mode).
+		Time("asOf", &asOf, time.RFC3339).
+		Bool("resolve", &resolve).
+		BindError()
 	if err != nil {
 		return ToHTTPError(err)
 	}
@@ -103,8 +119,12 @@ func (h *Handler) GetConfiguration(c echo.Context) error {
 	configuration, err := h.ConfigurationService.GetConfiguration(c.Request().Context(), configuration.GetConfigurationParams{
 		ServiceVersionSpecifiers: serviceVersionSpecifiers,
 		ChangesetID:              ptr.To(changesetID, ptr.NilIfZero()),
+		AsOf:                     ptr.To(asOf, ptr.NilIfZero()),
 		Mode:                     mode,
+		Resolve:                  resolve,
 		Variation:                variation,
+		RevealSecrets:            revealSecrets(c),
+		OmitRedactedSecrets:      omitRedactedSecrets(c),
 	})
 	if err != nil {
 		return ToHTTPError(err)
@@ -112,3 +132,263 @@ func (h *Handler) GetConfiguration(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, configuration)
 }
+
+// @Summary Get configuration delta
+// @Description Get only the keys and values that were added, changed or removed between two changesets. Secret keys are handled like in Get configuration.
+// @Produce json
+// @Param fromChangesetId query uint true "Changeset ID to compute the delta from"
+// @Param changesetId query uint false "Changeset ID to compute the delta to, defaults to the last applied changeset"
+// @Param services[] query []string true "Service versions in format service:version" example(TestService:1) collectionFormat(multi)
+// @Param mode query string false "Mode" Enums(production)
+// @Param variation[] query []string false "Variation" example(env:prod) collectionFormat(multi)
+// @Success 200 {object} configuration.ConfigurationDeltaDto
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /configuration/delta [get]
+func (h *Handler) GetConfigurationDelta(c echo.Context) error {
+	var fromChangesetID uint
+	var changesetID uint
+	var serviceVersions []string
+	var mode string
+	err := echo.QueryParamsBinder(c).MustUint("fromChangesetId", &fromChangesetID).Uint("changesetId", &changesetID).MustStrings("services[]", &serviceVersions).String("mode", &mode).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	variation, err := h.GetVariationFromQuery(c)
+	if err != nil {
+		return err
+	}
+
+	serviceVersionSpecifiers, err := core.ParseServiceVersionSpecifiers(serviceVersions)
+	if err != nil {
+		return err
+	}
+
+	delta, err := h.ConfigurationService.GetConfigurationDelta(c.Request().Context(), configuration.GetConfigurationDeltaParams{
+		ServiceVersionSpecifiers: serviceVersionSpecifiers,
+		FromChangesetID:          fromChangesetID,
+		ToChangesetID:            ptr.To(changesetID, ptr.NilIfZero()),
+		Mode:                     mode,
+		Variation:                variation,
+		RevealSecrets:            revealSecrets(c),
+		OmitRedactedSecrets:      omitRedactedSecrets(c),
+	})
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, delta)
+}
+
+// revealSecrets returns true if the caller can see decrypted secret values. Configuration clients authenticated with
+// client credentials can, like on the gRPC server.
+func revealSecrets(c echo.Context) bool {
+	_, isClient := c.Get(constants.ClientGrantKey).(*clientcredential.Grant)
+	return isClient || auth.GetUserFromEchoContext(c).CanRevealSecrets
+}
+
+// omitRedactedSecrets returns true for anonymous configuration clients, which don't get secret keys at all, because
+// they would use the redacted data as the actual value
+func omitRedactedSecrets(c echo.Context) bool {
+	return !auth.GetUserFromEchoContext(c).IsAuthenticated
+}
+
+// parseConfigurationPoint parses a changeset ID or an RFC3339 time
+func parseConfigurationPoint(name string, value string) (configuration.ConfigurationPoint, error) {
+	if changesetID, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return configuration.ConfigurationPoint{ChangesetID: ptr.To(uint(changesetID))}, nil
+	}
+
+	asOf, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return configuration.ConfigurationPoint{}, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s must be a changeset ID or an RFC3339 time", name))
+	}
+
+	return configuration.ConfigurationPoint{AsOf: &asOf}, nil
+}
+
+// @Summary Get configuration diff
+// @Description Get the added, removed and changed values between two changesets or points in time
+// @Produce json
+// @Param from query string true "Changeset ID or time (RFC3339) to compute the diff from"
+// @Param to query string false "Changeset ID or time (RFC3339) to compute the diff to, defaults to the last applied changeset"
+// @Param services[] query []string true "Service versions in format service:version" example(TestService:1) collectionFormat(multi)
+// @Param mode query string false "Mode" Enums(production)
+// @Param variation[] query []string false "Variation" example(env:prod) collectionFormat(multi)
+// @Success 200 {object} configuration.ConfigurationDiffDto
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /configuration/diff [get]
+func (h *Handler) GetConfigurationDiff(c echo.Context) error {
+	var from string
+	var to string
+	var serviceVersions []string
+	var mode string
+	err := echo.QueryParamsBinder(c).MustString("from", &from).String("to", &to).MustStrings("services[]", &serviceVersions).String("mode", &mode).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	fromPoint, err := parseConfigurationPoint("from", from)
+	if err != nil {
+		return err
+	}
+
+	var toPoint configuration.ConfigurationPoint
+	if to != "" {
+		toPoint, err = parseConfigurationPoint("to", to)
+		if err != nil {
+			return err
+		}
+	}
+
+	variation, err := h.GetVariationFromQuery(c)
+	if err != nil {
+		return err
+	}
+
+	serviceVersionSpecifiers, err := core.ParseServiceVersionSpecifiers(serviceVersions)
+	if err != nil {
+		return err
+	}
+
+	diff, err := h.ConfigurationService.GetConfigurationDiff(c.Request().Context(), configuration.GetConfigurationDiffParams{
+		ServiceVersionSpecifiers: serviceVersionSpecifiers,
+		From:                     fromPoint,
+		To:                       toPoint,
+		Mode:                     mode,
+		Variation:                variation,
+		RevealSecrets:            revealSecrets(c),
+	})
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, diff)
+}
+
+// @Summary Explain configuration value
+// @Description Explain how the value of a key is resolved for a variation, listing all candidate values with their rank and whether they matched
+// @Produce json
+// @Param services[] query []string true "Service versions in format service:version" example(TestService:1) collectionFormat(multi)
+// @Param feature query string true "Feature name"
+// @Param key query string true "Key name"
+// @Param changesetId query uint false "Changeset ID"
+// @Param asOf query string false "Point in time (RFC3339), resolves to the last changeset applied at or before it" format(date-time)
+// @Param mode query string false "Mode" Enums(production)
+// @Param variation[] query []string false "Variation" example(env:prod) collectionFormat(multi)
+// @Success 200 {object} configuration.ExplainValueDto
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /configuration/explain [get]
+func (h *Handler) ExplainConfigurationValue(c echo.Context) error {
+	var serviceVersions []string
+	var feature string
+	var key string
+	var changesetID uint
+	var asOf time.Time
+	var mode string
+	err := echo.QueryParamsBinder(c).
+		MustStrings("services[]", &serviceVersions).
+		MustString("feature", &feature).
+		MustString("key", &key).
+		Uint("changesetId", &changesetID).
+		Time("asOf", &asOf, time.RFC3339).
+		String("mode", &mode).
+		BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	variation, err := h.GetVariationFromQuery(c)
+	if err != nil {
+		return err
+	}
+
+	serviceVersionSpecifiers, err := core.ParseServiceVersionSpecifiers(serviceVersions)
+	if err != nil {
+		return err
+	}
+
+	explanation, err := h.ConfigurationService.ExplainValue(c.Request().Context(), configuration.ExplainValueParams{
+		ServiceVersionSpecifiers: serviceVersionSpecifiers,
+		ChangesetID:              ptr.To(changesetID, ptr.NilIfZero()),
+		AsOf:                     ptr.To(asOf, ptr.NilIfZero()),
+		Mode:                     mode,
+		Feature:                  feature,
+		Key:                      key,
+		Variation:                variation,
+		RevealSecrets:            revealSecrets(c),
+	})
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, explanation)
+}
+
+// @Summary Export configuration
+// @Description Export the resolved configuration as a file with one entry per key named Feature.Key. Fails if the configuration contains secrets the caller is not allowed to reveal.
+// @Produce json
+// @Produce plain
+// @Produce application/yaml
+// @Param format query string true "Format" Enums(yaml, env, properties, json)
+// @Param services[] query []string true "Service versions in format service:version" example(TestService:1) collectionFormat(multi)
+// @Param changesetId query uint false "Changeset ID"
+// @Param asOf query string false "Point in time (RFC3339), resolves to the last changeset applied at or before it" format(date-time)
+// @Param mode query string false "Mode" Enums(production)
+// @Param variation[] query []string false "Variation, all properties are required" example(env:prod) collectionFormat(multi)
+// @Success 200 {string} string
+// @Failure 400 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 422 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /configuration/export [get]
+func (h *Handler) ExportConfiguration(c echo.Context) error {
+	var format string
+	var serviceVersions []string
+	var changesetID uint
+	var asOf time.Time
+	var mode string
+	err := echo.QueryParamsBinder(c).
+		MustString("format", &format).
+		MustStrings("services[]", &serviceVersions).
+		Uint("changesetId", &changesetID).
+		Time("asOf", &asOf, time.RFC3339).
+		String("mode", &mode).
+		BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	variation, err := h.GetVariationFromQuery(c)
+	if err != nil {
+		return err
+	}
+
+	serviceVersionSpecifiers, err := core.ParseServiceVersionSpecifiers(serviceVersions)
+	if err != nil {
+		return err
+	}
+
+	exportFormat := configuration.ExportFormat(format)
+	data, err := h.ConfigurationService.ExportConfiguration(c.Request().Context(), configuration.ExportConfigurationParams{
+		ServiceVersionSpecifiers: serviceVersionSpecifiers,
+		ChangesetID:              ptr.To(changesetID, ptr.NilIfZero()),
+		AsOf:                     ptr.To(asOf, ptr.NilIfZero()),
+		Mode:                     mode,
+		Variation:                variation,
+		RevealSecrets:            revealSecrets(c),
+		Format:                   exportFormat,
+	})
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.Blob(http.StatusOK, exportFormat.ContentType(), data)
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/necroskillz/config-service/services/constraint"
+	_ "github.com/necroskillz/config-service/services/core"
+)
+
+// @Summary Get constraints
+// @Description Get constraints of the feature version
+// @Produce json
+// @Security BearerAuth
+// @Param service_version_id path int true "Service version ID"
+// @Param feature_version_id path int true "Feature version ID"
+// @Success 200 {array} constraint.ConstraintDto
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /services/{service_version_id}/features/{feature_version_id}/constraints [get]
+func (h *Handler) GetConstraints(c echo.Context) error {
+	var serviceVersionID, featureVersionID uint
+	err := echo.PathParamsBinder(c).
+		MustUint("service_version_id", &serviceVersionID).
+		MustUint("feature_version_id", &featureVersionID).
+		BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	constraints, err := h.ConstraintService.GetConstraints(c.Request().Context(), serviceVersionID, featureVersionID)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, constraints)
+}
+
+type ConstraintRequest struct {
+	Expression string `json:"expression" validate:"required"`
+	ErrorText  string `json:"errorText"`
+}
+
+// @Summary Create constraint
+// @Description Create constraint of the feature version. The expression is a CEL expression over the keys of the feature version, it is evaluated for every variation when a changeset changing the feature version is committed, scheduled or applied.
+// @Description The current values must satisfy the constraint. Constraints cannot be changed for feature versions linked to a published service version.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param service_version_id path int true "Service version ID"
+// @Param feature_version_id path int true "Feature version ID"
+// @Param constraintRequest body ConstraintRequest true "Constraint request"
+// @Success 200 {object} CreateResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 422 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /services/{service_version_id}/features/{feature_version_id}/constraints [post]
+func (h *Handler) CreateConstraint(c echo.Context) error {
+	var serviceVersionID, featureVersionID uint
+	err := echo.PathParamsBinder(c).
+		MustUint("service_version_id", &serviceVersionID).
+		MustUint("feature_version_id", &featureVersionID).
+		BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	var data ConstraintRequest
+	err = c.Bind(&data)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	constraintID, err := h.ConstraintService.CreateConstraint(c.Request().Context(), constraint.CreateConstraintParams{
+		ServiceVersionID: serviceVersionID,
+		FeatureVersionID: featureVersionID,
+		Expression:       data.Expression,
+		ErrorText:        data.ErrorText,
+	})
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, NewCreateResponse(constraintID))
+}
+
+// @Summary Update constraint
+// @Description Update constraint of the feature version
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param service_version_id path int true "Service version ID"
+// @Param feature_version_id path int true "Feature version ID"
+// @Param constraint_id path int true "Constraint ID"
+// @Param constraintRequest body ConstraintRequest true "Constraint request"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 422 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /services/{service_version_id}/features/{feature_version_id}/constraints/{constraint_id} [put]
+func (h *Handler) UpdateConstraint(c echo.Context) error {
+	var serviceVersionID, featureVersionID, constraintID uint
+	err := echo.PathParamsBinder(c).
+		MustUint("service_version_id", &serviceVersionID).
+		MustUint("feature_version_id", &featureVersionID).
+		MustUint("constraint_id", &constraintID).
+		BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	var data ConstraintRequest
+	err = c.Bind(&data)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	err = h.ConstraintService.UpdateConstraint(c.Request().Context(), constraint.UpdateConstraintParams{
+		ServiceVersionID: serviceVersionID,
+		FeatureVersionID: featureVersionID,
+		ConstraintID:     constraintID,
+		Expression:       data.Expression,
+		ErrorText:        data.ErrorText,
+	})
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// @Summary Delete constraint
+// @Description Delete constraint of the feature version
+// @Produce json
+// @Security BearerAuth
+// @Param service_version_id path int true "Service version ID"
+// @Param feature_version_id path int true "Feature version ID"
+// @Param constraint_id path int true "Constraint ID"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /services/{service_version_id}/features/{feature_version_id}/constraints/{constraint_id} [delete]
+func (h *Handler) DeleteConstraint(c echo.Context) error {
+	var serviceVersionID, featureVersionID, constraintID uint
+	err := echo.PathParamsBinder(c).
+		MustUint("service_version_id", &serviceVersionID).
+		MustUint("feature_version_id", &featureVersionID).
+		MustUint("constraint_id", &constraintID).
+		BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	err = h.ConstraintService.DeleteConstraint(c.Request().Context(), serviceVersionID, featureVersionID, constraintID)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
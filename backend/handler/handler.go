@@ -3,9 +3,12 @@ package handler
 import (
 	"github.com/necroskillz/config-service/auth"
 	"github.com/necroskillz/config-service/services/changeset"
+	"github.com/necroskillz/config-service/services/clientcredential"
 	"github.com/necroskillz/config-service/services/configuration"
+	"github.com/necroskillz/config-service/services/constraint"
 	"github.com/necroskillz/config-service/services/feature"
 	"github.com/necroskillz/config-service/services/key"
+	"github.com/necroskillz/config-service/services/manifest"
 	"github.com/necroskillz/config-service/services/membership"
 	"github.com/necroskillz/config-service/services/service"
 	"github.com/necroskillz/config-service/services/servicetype"
@@ -14,6 +17,7 @@ import (
 	"github.com/necroskillz/config-service/services/valuetype"
 	"github.com/necroskillz/config-service/services/variation"
 	"github.com/necroskillz/config-service/services/variationproperty"
+	"github.com/necroskillz/config-service/services/webhook"
 )
 
 type Handler struct {
@@ -31,6 +35,10 @@ type Handler struct {
 	ServiceTypeService        *servicetype.Service
 	ConfigurationService      *configuration.Service
 	MembershipService         *membership.Service
+	WebhookService            *webhook.Service
+	ClientCredentialService   *clientcredential.Service
+	ManifestService           *manifest.Service
+	ConstraintService         *constraint.Service
 }
 
 func NewHandler(
@@ -48,6 +56,10 @@ func NewHandler(
 	serviceTypeService *servicetype.Service,
 	configurationService *configuration.Service,
 	membershipService *membership.Service,
+	webhookService *webhook.Service,
+	clientCredentialService *clientcredential.Service,
+	manifestService *manifest.Service,
+	constraintService *constraint.Service,
 ) *Handler {
 	return &Handler{
 		ServiceService:            serviceService,
@@ -64,5 +76,9 @@ func NewHandler(
 		ServiceTypeService:        serviceTypeService,
 		ConfigurationService:      configurationService,
 		MembershipService:         membershipService,
+		WebhookService:            webhookService,
+		ClientCredentialService:   clientCredentialService,
+		ManifestService:           manifestService,
+		ConstraintService:         constraintService,
 	}
 }
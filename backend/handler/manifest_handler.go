@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/necroskillz/config-service/services/manifest"
+)
+
+// maxManifestSize limits the size of an imported manifest
+const maxManifestSize = 10 << 20
+
+// @Summary Import manifest
+// @Description Create the features, keys and values declared in a YAML or JSON manifest in the open changeset.
+// @Description Existing features, keys and values that differ from the manifest are updated.
+// @Description Features, keys and values missing from the manifest are reported as drift, or removed when pruning.
+// @Accept json
+// @Accept application/yaml
+// @Produce json
+// @Security BearerAuth
+// @Param service_version_id path int true "Service version ID"
+// @Param dryRun query bool false "Only validate the manifest and return the changes it would make"
+// @Param prune query bool false "Delete the keys and values and unlink the features that are not in the manifest"
+// @Param manifest body manifest.Manifest true "Manifest"
+// @Success 200 {object} manifest.ImportResultDto
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 413 {object} echo.HTTPError
+// @Failure 422 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /services/{service_version_id}/import [post]
+func (h *Handler) ImportManifest(c echo.Context) error {
+	var serviceVersionID uint
+	err := echo.PathParamsBinder(c).MustUint("service_version_id", &serviceVersionID).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	var dryRun, prune bool
+	err = echo.QueryParamsBinder(c).Bool("dryRun", &dryRun).Bool("prune", &prune).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(c.Request().Body, maxManifestSize+1))
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	if len(data) > maxManifestSize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Manifest is too large")
+	}
+
+	m, err := manifest.Parse(data)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	result, err := h.ManifestService.Import(c.Request().Context(), manifest.ImportParams{
+		ServiceVersionID: serviceVersionID,
+		Manifest:         m,
+		DryRun:           dryRun,
+		Prune:            prune,
+	})
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -84,6 +84,7 @@ type CreateUserRequest struct {
 	Username            string `json:"username" validate:"required"`
 	Password            string `json:"password" validate:"required"`
 	GlobalAdministrator bool   `json:"globalAdministrator" validate:"required"`
+	RevealSecrets       bool   `json:"revealSecrets"`
 }
 
 // @Summary Create a user
@@ -111,6 +112,7 @@ func (h *Handler) CreateUser(c echo.Context) error {
 		Username:            request.Username,
 		Password:            request.Password,
 		GlobalAdministrator: request.GlobalAdministrator,
+		RevealSecrets:       request.RevealSecrets,
 	})
 	if err != nil {
 		return ToHTTPError(err)
@@ -121,6 +123,7 @@ func (h *Handler) CreateUser(c echo.Context) error {
 
 type UpdateUserRequest struct {
 	GlobalAdministrator bool `json:"globalAdministrator" validate:"required"`
+	RevealSecrets       bool `json:"revealSecrets"`
 }
 
 // @Summary Update a user
@@ -153,6 +156,7 @@ func (h *Handler) UpdateUser(c echo.Context) error {
 
 	err = h.MembershipService.UpdateUser(c.Request().Context(), userID, membership.UpdateUserParams{
 		GlobalAdministrator: request.GlobalAdministrator,
+		RevealSecrets:       request.RevealSecrets,
 	})
 	if err != nil {
 		return ToHTTPError(err)
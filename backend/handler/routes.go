@@ -31,6 +31,11 @@ func (h *Handler) RegisterRoutes(e *echo.Echo) {
 	usersGroup.GET("/:user_id", h.GetUser)
 	usersGroup.PUT("/:user_id", h.UpdateUser)
 	usersGroup.DELETE("/:user_id", h.DeleteUser)
+	usersGroup.GET("/:user_id/tokens", h.GetApiTokens)
+	usersGroup.POST("/:user_id/tokens", h.CreateApiToken)
+	usersGroup.DELETE("/:user_id/tokens/:token_id", h.RevokeApiToken)
+
+	membershipGroup.POST("/service-accounts", h.CreateServiceAccount)
 
 	groupsGroup := membershipGroup.Group("/groups")
 	groupsGroup.POST("", h.CreateGroup)
@@ -51,6 +56,18 @@ func (h *Handler) RegisterRoutes(e *echo.Echo) {
 	serviceGroup.GET("", h.Service)
 	serviceGroup.GET("/versions", h.ServiceVersions)
 	serviceGroup.POST("/versions", h.CreateServiceVersion)
+	serviceGroup.GET("/review-policy", h.GetReviewPolicy)
+	serviceGroup.PUT("/review-policy", h.UpdateReviewPolicy)
+	serviceGroup.DELETE("/review-policy", h.DeleteReviewPolicy)
+	serviceGroup.POST("/import", h.ImportManifest)
+
+	webhooksGroup := serviceGroup.Group("/webhooks")
+	webhooksGroup.GET("", h.GetWebhooks)
+	webhooksGroup.POST("", h.CreateWebhook)
+	webhooksGroup.GET("/:webhook_id", h.GetWebhook)
+	webhooksGroup.PUT("/:webhook_id", h.UpdateWebhook)
+	webhooksGroup.DELETE("/:webhook_id", h.DeleteWebhook)
+	webhooksGroup.GET("/:webhook_id/deliveries", h.GetWebhookDeliveries)
 
 	featuresGroup := serviceGroup.Group("/features")
 	featuresGroup.GET("", h.Features)
@@ -66,6 +83,12 @@ func (h *Handler) RegisterRoutes(e *echo.Echo) {
 	featureGroup.POST("/link", h.LinkFeatureVersion)
 	featureGroup.DELETE("/unlink", h.UnlinkFeatureVersion)
 
+	constraintsGroup := featureGroup.Group("/constraints")
+	constraintsGroup.GET("", h.GetConstraints)
+	constraintsGroup.POST("", h.CreateConstraint)
+	constraintsGroup.PUT("/:constraint_id", h.UpdateConstraint)
+	constraintsGroup.DELETE("/:constraint_id", h.DeleteConstraint)
+
 	keysGroup := featureGroup.Group("/keys")
 	keysGroup.GET("", h.Keys)
 	keysGroup.POST("", h.CreateKey)
@@ -110,9 +133,13 @@ func (h *Handler) RegisterRoutes(e *echo.Echo) {
 	changesetGroup := changesetsGroup.Group("/:changeset_id")
 	changesetGroup.GET("", h.Changeset)
 	changesetGroup.PUT("/apply", h.ApplyChangeset)
+	changesetGroup.PUT("/approve", h.ApproveChangeset)
+	changesetGroup.PUT("/schedule", h.ScheduleChangeset)
+	changesetGroup.DELETE("/schedule", h.UnscheduleChangeset)
 	changesetGroup.PUT("/commit", h.CommitChangeset)
 	changesetGroup.PUT("/reopen", h.ReopenChangeset)
 	changesetGroup.PUT("/stash", h.StashChangeset)
+	changesetGroup.POST("/revert", h.RevertChangeset)
 	changesetGroup.DELETE("", h.DiscardChangeset)
 	changesetGroup.POST("/comment", h.AddComment)
 
@@ -146,9 +173,20 @@ func (h *Handler) RegisterRoutes(e *echo.Echo) {
 
 	configurationGroup := apiGroup.Group("/configuration")
 	configurationGroup.GET("", h.GetConfiguration)
+	configurationGroup.GET("/delta", h.GetConfigurationDelta)
+	configurationGroup.GET("/diff", h.GetConfigurationDiff)
+	configurationGroup.GET("/explain", h.ExplainConfigurationValue)
+	configurationGroup.GET("/export", h.ExportConfiguration)
 	configurationGroup.GET("/changesets", h.GetNextChangesets)
 	configurationGroup.GET("/variation-hierarchy", h.GetVariationHierarchy)
 
+	clientCredentialsGroup := apiGroup.Group("/client-credentials")
+	clientCredentialsGroup.GET("", h.GetClientCredentials)
+	clientCredentialsGroup.POST("", h.CreateClientCredential)
+	clientCredentialsGroup.GET("/:client_credential_id", h.GetClientCredential)
+	clientCredentialsGroup.PUT("/:client_credential_id", h.UpdateClientCredential)
+	clientCredentialsGroup.DELETE("/:client_credential_id", h.DeleteClientCredential)
+
 	changeHistoryGroup := apiGroup.Group("/change-history")
 	changeHistoryGroup.GET("", h.GetChangeHistory)
 	changeHistoryGroup.GET("/services", h.GetAppliedServices)
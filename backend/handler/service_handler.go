@@ -236,3 +236,103 @@ func (h *Handler) IsServiceNameTaken(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, NewBooleanResponse(exists))
 }
+
+// @Summary Get service review policy
+// @Description Get the review policy of the service. Changesets touching the service need the configured approvals before they can be applied.
+// @Produce json
+// @Security BearerAuth
+// @Param service_version_id path int true "Service version ID (for url consistency, the policy of the underlying service is returned)"
+// @Success 200 {object} service.ReviewPolicyDto
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /services/{service_version_id}/review-policy [get]
+func (h *Handler) GetReviewPolicy(c echo.Context) error {
+	var serviceVersionID uint
+	err := echo.PathParamsBinder(c).MustUint("service_version_id", &serviceVersionID).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	policy, err := h.ServiceService.GetReviewPolicy(c.Request().Context(), serviceVersionID)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, policy)
+}
+
+type UpdateReviewPolicyRequest struct {
+	RequiredApprovals   int   `json:"requiredApprovals" validate:"required"`
+	AllowSelfApproval   bool  `json:"allowSelfApproval" validate:"required"`
+	RequiredUserGroupID *uint `json:"requiredUserGroupId"`
+}
+
+// @Summary Update service review policy
+// @Description Create or update the review policy of the service
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param service_version_id path int true "Service version ID (for url consistency, the policy of the underlying service will be updated)"
+// @Param updateReviewPolicyRequest body UpdateReviewPolicyRequest true "Update review policy request"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 422 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /services/{service_version_id}/review-policy [put]
+func (h *Handler) UpdateReviewPolicy(c echo.Context) error {
+	var serviceVersionID uint
+	err := echo.PathParamsBinder(c).MustUint("service_version_id", &serviceVersionID).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	var data UpdateReviewPolicyRequest
+	err = c.Bind(&data)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	err = h.ServiceService.UpdateReviewPolicy(c.Request().Context(), service.UpdateReviewPolicyParams{
+		ServiceVersionID:    serviceVersionID,
+		RequiredApprovals:   data.RequiredApprovals,
+		AllowSelfApproval:   data.AllowSelfApproval,
+		RequiredUserGroupID: data.RequiredUserGroupID,
+	})
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// @Summary Delete service review policy
+// @Description Delete the review policy of the service
+// @Produce json
+// @Security BearerAuth
+// @Param service_version_id path int true "Service version ID (for url consistency, the policy of the underlying service will be deleted)"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /services/{service_version_id}/review-policy [delete]
+func (h *Handler) DeleteReviewPolicy(c echo.Context) error {
+	var serviceVersionID uint
+	err := echo.PathParamsBinder(c).MustUint("service_version_id", &serviceVersionID).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	err = h.ServiceService.DeleteReviewPolicy(c.Request().Context(), serviceVersionID)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
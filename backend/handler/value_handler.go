@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/necroskillz/config-service/services/core"
 	"github.com/necroskillz/config-service/services/value"
 )
 
@@ -45,6 +46,11 @@ func (h *Handler) Values(c echo.Context) error {
 type ValueRequest struct {
 	Data      string          `json:"data" validate:"required"`
 	Variation map[uint]string `json:"variation" validate:"required"`
+	// Rollout limits the value to a percentage of the subjects, bucketed by the value of the attribute. When updating
+	// a value, the existing rollout is kept if not set.
+	Rollout *core.Rollout `json:"rollout,omitempty"`
+	// ClearRollout makes a rolled out value apply to all subjects, only used when updating a value
+	ClearRollout bool `json:"clearRollout,omitempty"`
 }
 
 // @Summary Create value
@@ -90,6 +96,7 @@ func (h *Handler) CreateValue(c echo.Context) error {
 		KeyID:            keyID,
 		Data:             data.Data,
 		Variation:        data.Variation,
+		Rollout:          data.Rollout,
 	})
 	if err != nil {
 		return ToHTTPError(err)
@@ -145,6 +152,8 @@ func (h *Handler) UpdateValue(c echo.Context) error {
 		ValueID:          valueID,
 		Data:             data.Data,
 		Variation:        data.Variation,
+		Rollout:          data.Rollout,
+		ClearRollout:     data.ClearRollout,
 	})
 	if err != nil {
 		return ToHTTPError(err)
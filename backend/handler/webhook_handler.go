@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/necroskillz/config-service/db"
+	_ "github.com/necroskillz/config-service/services/core"
+	"github.com/necroskillz/config-service/services/webhook"
+)
+
+// @Summary Get webhooks
+// @Description Get webhooks of the service
+// @Produce json
+// @Security BearerAuth
+// @Param service_version_id path int true "Service version ID (for url consistency, the webhooks of the underlying service are returned)"
+// @Success 200 {array} webhook.WebhookDto
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /services/{service_version_id}/webhooks [get]
+func (h *Handler) GetWebhooks(c echo.Context) error {
+	var serviceVersionID uint
+	err := echo.PathParamsBinder(c).MustUint("service_version_id", &serviceVersionID).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	webhooks, err := h.WebhookService.GetWebhooks(c.Request().Context(), serviceVersionID)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, webhooks)
+}
+
+// @Summary Get webhook
+// @Description Get webhook by ID
+// @Produce json
+// @Security BearerAuth
+// @Param service_version_id path int true "Service version ID"
+// @Param webhook_id path int true "Webhook ID"
+// @Success 200 {object} webhook.WebhookDto
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /services/{service_version_id}/webhooks/{webhook_id} [get]
+func (h *Handler) GetWebhook(c echo.Context) error {
+	var serviceVersionID, webhookID uint
+	err := echo.PathParamsBinder(c).
+		MustUint("service_version_id", &serviceVersionID).
+		MustUint("webhook_id", &webhookID).
+		BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	webhook, err := h.WebhookService.GetWebhook(c.Request().Context(), serviceVersionID, webhookID)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+type CreateWebhookRequest struct {
+	Url     string                `json:"url" validate:"required"`
+	Secret  string                `json:"secret" validate:"required"`
+	Events  []db.WebhookEventType `json:"events" validate:"required"`
+	Enabled bool                  `json:"enabled" validate:"required"`
+}
+
+// @Summary Create webhook
+// @Description Create webhook for the service. Deliveries are signed with the secret using HMAC-SHA256, the signature is sent in the X-Config-Service-Signature header.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param service_version_id path int true "Service version ID (for url consistency, the webhook is created for the underlying service)"
+// @Param createWebhookRequest body CreateWebhookRequest true "Create webhook request"
+// @Success 200 {object} CreateResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 422 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /services/{service_version_id}/webhooks [post]
+func (h *Handler) CreateWebhook(c echo.Context) error {
+	var serviceVersionID uint
+	err := echo.PathParamsBinder(c).MustUint("service_version_id", &serviceVersionID).BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	var data CreateWebhookRequest
+	err = c.Bind(&data)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	webhookID, err := h.WebhookService.CreateWebhook(c.Request().Context(), webhook.CreateWebhookParams{
+		ServiceVersionID: serviceVersionID,
+		Url:              data.Url,
+		Secret:           data.Secret,
+		Events:           data.Events,
+		Enabled:          data.Enabled,
+	})
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, NewCreateResponse(webhookID))
+}
+
+type UpdateWebhookRequest struct {
+	Url     string                `json:"url" validate:"required"`
+	Secret  *string               `json:"secret"`
+	Events  []db.WebhookEventType `json:"events" validate:"required"`
+	Enabled bool                  `json:"enabled" validate:"required"`
+}
+
+// @Summary Update webhook
+// @Description Update webhook. The secret is kept unchanged when not provided.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param service_version_id path int true "Service version ID"
+// @Param webhook_id path int true "Webhook ID"
+// @Param updateWebhookRequest body UpdateWebhookRequest true "Update webhook request"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 422 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /services/{service_version_id}/webhooks/{webhook_id} [put]
+func (h *Handler) UpdateWebhook(c echo.Context) error {
+	var serviceVersionID, webhookID uint
+	err := echo.PathParamsBinder(c).
+		MustUint("service_version_id", &serviceVersionID).
+		MustUint("webhook_id", &webhookID).
+		BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	var data UpdateWebhookRequest
+	err = c.Bind(&data)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	err = h.WebhookService.UpdateWebhook(c.Request().Context(), webhook.UpdateWebhookParams{
+		ServiceVersionID: serviceVersionID,
+		WebhookID:        webhookID,
+		Url:              data.Url,
+		Secret:           data.Secret,
+		Events:           data.Events,
+		Enabled:          data.Enabled,
+	})
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// @Summary Delete webhook
+// @Description Delete webhook and its delivery log
+// @Produce json
+// @Security BearerAuth
+// @Param service_version_id path int true "Service version ID"
+// @Param webhook_id path int true "Webhook ID"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /services/{service_version_id}/webhooks/{webhook_id} [delete]
+func (h *Handler) DeleteWebhook(c echo.Context) error {
+	var serviceVersionID, webhookID uint
+	err := echo.PathParamsBinder(c).
+		MustUint("service_version_id", &serviceVersionID).
+		MustUint("webhook_id", &webhookID).
+		BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	err = h.WebhookService.DeleteWebhook(c.Request().Context(), serviceVersionID, webhookID)
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// @Summary Get webhook deliveries
+// @Description Get the delivery log of the webhook, newest first
+// @Produce json
+// @Security BearerAuth
+// @Param service_version_id path int true "Service version ID"
+// @Param webhook_id path int true "Webhook ID"
+// @Param page query int false "Page" default(1)
+// @Param pageSize query int false "Page Size" default(20)
+// @Success 200 {object} core.PaginatedResult[webhook.WebhookDeliveryDto]
+// @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /services/{service_version_id}/webhooks/{webhook_id}/deliveries [get]
+func (h *Handler) GetWebhookDeliveries(c echo.Context) error {
+	var serviceVersionID, webhookID uint
+	err := echo.PathParamsBinder(c).
+		MustUint("service_version_id", &serviceVersionID).
+		MustUint("webhook_id", &webhookID).
+		BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	page := 1
+	pageSize := 20
+
+	err = echo.QueryParamsBinder(c).
+		Int("page", &page).
+		Int("pageSize", &pageSize).
+		BindError()
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	deliveries, err := h.WebhookService.GetWebhookDeliveries(c.Request().Context(), webhook.DeliveryFilter{
+		ServiceVersionID: serviceVersionID,
+		WebhookID:        webhookID,
+		Page:             page,
+		PageSize:         pageSize,
+	})
+	if err != nil {
+		return ToHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, deliveries)
+}
@@ -8,49 +8,62 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/necroskillz/config-service/auth"
 	"github.com/necroskillz/config-service/constants"
-	"github.com/necroskillz/config-service/services/changeset"
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/core"
 	"github.com/necroskillz/config-service/services/membership"
-	"github.com/necroskillz/config-service/services/variation"
 )
 
-func AuthMiddleware(authService *membership.AuthService, variationHierarchyService *variation.HierarchyService, changesetService *changeset.Service) echo.MiddlewareFunc {
-	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			claims, err := auth.GetClaims(c)
-			if err != nil {
-				if errors.Is(err, auth.ErrUserNotAuthenticated) {
-					auth.StoreUserInContext(c, auth.AnonymousUser())
-					return next(c)
-				}
+func isReadOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
 
-				return err
-			}
+func loadApiTokenUser(c echo.Context, userLoader *membership.UserLoader, token string) (*auth.User, error) {
+	user, scope, err := userLoader.LoadUserForApiToken(c.Request().Context(), token)
+	if err != nil {
+		if errors.Is(err, core.ErrRecordNotFound) {
+			return nil, echo.NewHTTPError(http.StatusUnauthorized, "Invalid or expired API token")
+		}
 
-			user, err := authService.GetUser(c.Request().Context(), claims.UserId)
-			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user").WithInternal(err)
-			}
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to load user").WithInternal(err)
+	}
 
-			variationHierarchy, err := variationHierarchyService.GetVariationHierarchy(c.Request().Context())
-			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get variation hierarchy").WithInternal(err)
-			}
+	if scope == db.ApiTokenScopeRead && !isReadOnlyMethod(c.Request().Method) {
+		return nil, echo.NewHTTPError(http.StatusForbidden, "API token only has read scope")
+	}
 
-			changesetId, err := changesetService.GetOpenChangesetIDForUser(c.Request().Context(), user.ID)
-			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get open changeset for user").WithInternal(err)
-			}
+	return user, nil
+}
 
-			userBuilder := auth.NewUserBuilder(variationHierarchy)
-			userBuilder.WithBasicInfo(user.ID, user.Username, user.GlobalAdministrator)
-			userBuilder.WithChangesetID(changesetId)
+func AuthMiddleware(userLoader *membership.UserLoader) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			var user *auth.User
 
-			for _, permission := range user.Permissions {
-				userBuilder.WithPermission(permission.ServiceID, permission.FeatureID, permission.KeyID, permission.Variation, permission.Permission)
+			if token, ok := auth.GetApiToken(c); ok {
+				var err error
+				user, err = loadApiTokenUser(c, userLoader, token)
+				if err != nil {
+					return err
+				}
+			} else {
+				claims, err := auth.GetClaims(c)
+				if err != nil {
+					if errors.Is(err, auth.ErrUserNotAuthenticated) {
+						auth.StoreUserInContext(c, auth.AnonymousUser())
+						return next(c)
+					}
+
+					return err
+				}
+
+				user, err = userLoader.LoadUser(c.Request().Context(), claims.UserId)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load user").WithInternal(err)
+				}
 			}
 
-			auth.StoreUserInContext(c, userBuilder.User())
-			c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), constants.UserContextKey, userBuilder.User())))
+			auth.StoreUserInContext(c, user)
+			c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), constants.UserContextKey, user)))
 
 			return next(c)
 		}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/necroskillz/config-service/auth"
+	"github.com/necroskillz/config-service/constants"
+	"github.com/necroskillz/config-service/services/clientcredential"
+	"github.com/necroskillz/config-service/services/core"
+)
+
+const HeaderApiKey = "X-Api-Key"
+
+// ClientCredentialMiddleware authenticates configuration clients of the /api/configuration endpoints, which do not require
+// a logged in user. Requests with an API key can only read configuration of the services granted to the client, the
+// grant is stored in the echo context.
+func ClientCredentialMiddleware(clientCredentialService *clientcredential.Service, requireCredentials bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !strings.HasPrefix(c.Request().URL.Path, "/api/configuration") {
+				return next(c)
+			}
+
+			key := c.Request().Header.Get(HeaderApiKey)
+			if key == "" {
+				if requireCredentials && !auth.GetUserFromEchoContext(c).IsAuthenticated {
+					return echo.NewHTTPError(http.StatusUnauthorized, "Client credentials are required")
+				}
+
+				return next(c)
+			}
+
+			grant, err := clientCredentialService.AuthenticateApiKey(c.Request().Context(), key)
+			if err != nil {
+				if errors.Is(err, core.ErrRecordNotFound) {
+					return echo.NewHTTPError(http.StatusUnauthorized, "Invalid API key")
+				}
+
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to authenticate client").WithInternal(err)
+			}
+
+			if err := grant.Authorize(c.QueryParams()["services[]"]); err != nil {
+				if errors.Is(err, core.ErrPermissionDenied) {
+					return echo.NewHTTPError(http.StatusForbidden, err.Error())
+				}
+
+				return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+			}
+
+			c.Set(constants.ClientGrantKey, &grant)
+
+			return next(c)
+		}
+	}
+}
@@ -22,15 +22,21 @@ import (
 	"github.com/necroskillz/config-service/handler"
 	"github.com/necroskillz/config-service/middleware"
 	"github.com/necroskillz/config-service/services"
+	"github.com/necroskillz/config-service/services/cacheinvalidation"
+	"github.com/necroskillz/config-service/services/changeset"
+	"github.com/necroskillz/config-service/services/webhook"
 	"github.com/necroskillz/config-service/util/logging"
 	slogecho "github.com/samber/slog-echo"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
 type Server struct {
-	echo   *echo.Echo
-	dbpool *pgxpool.Pool
-	cache  *ristretto.Cache[string, any]
+	echo                      *echo.Echo
+	dbpool                    *pgxpool.Pool
+	cache                     *ristretto.Cache[string, any]
+	cacheInvalidationListener *cacheinvalidation.Listener
+	changesetScheduler        *changeset.Scheduler
+	webhookDispatcher         *webhook.Dispatcher
 }
 
 type PgxTraceLogger struct {
@@ -91,7 +97,19 @@ func (s *Server) Start() error {
 	s.echo = e
 	s.cache = cache
 
-	svc := services.InitializeServices(dbpool, cache)
+	svc, err := services.InitializeServices(dbpool, cache)
+	if err != nil {
+		return fmt.Errorf("failed to initialize services: %w", err)
+	}
+
+	s.cacheInvalidationListener = svc.CacheInvalidationListener
+	s.cacheInvalidationListener.Start(ctx)
+
+	s.changesetScheduler = svc.ChangesetScheduler
+	s.changesetScheduler.Start(ctx)
+
+	s.webhookDispatcher = svc.WebhookDispatcher
+	s.webhookDispatcher.Start(ctx)
 
 	e.Use(slogecho.NewWithFilters(logger,
 		slogecho.IgnoreStatus(http.StatusUnauthorized, http.StatusConflict),
@@ -100,7 +118,7 @@ func (s *Server) Start() error {
 	e.Use(echoMiddleware.CORSWithConfig(echoMiddleware.CORSConfig{
 		AllowOrigins:     []string{os.Getenv("FRONTEND_URL")},
 		AllowMethods:     []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete},
-		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, middleware.HeaderApiKey},
 		AllowCredentials: true,
 		MaxAge:           86400, // 24 hours
 	}))
@@ -116,10 +134,20 @@ func (s *Server) Start() error {
 				return true
 			}
 
+			// API tokens are not JWTs, they are resolved by the auth middleware
+			if _, ok := auth.GetApiToken(c); ok {
+				return true
+			}
+
+			// Configuration clients read configuration without logging in, but logged in users still need their claims
+			// on these routes, so secrets can be revealed to them
+			if strings.HasPrefix(url, "/api/configuration") {
+				return c.Request().Header.Get(echo.HeaderAuthorization) == ""
+			}
+
 			skippedPaths := []string{
 				"/api/auth/login",
 				"/api/auth/refresh_token",
-				"/api/configuration",
 			}
 
 			for _, path := range skippedPaths {
@@ -135,7 +163,8 @@ func (s *Server) Start() error {
 		},
 		ContextKey: "claims",
 	}))
-	e.Use(middleware.AuthMiddleware(svc.AuthService, svc.VariationHierarchyService, svc.ChangesetService))
+	e.Use(middleware.AuthMiddleware(svc.UserLoader))
+	e.Use(middleware.ClientCredentialMiddleware(svc.ClientCredentialService, os.Getenv("REQUIRE_CLIENT_CREDENTIALS") == "true"))
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
@@ -154,6 +183,10 @@ func (s *Server) Start() error {
 		svc.ServiceTypeService,
 		svc.ConfigurationService,
 		svc.MembershipService,
+		svc.WebhookService,
+		svc.ClientCredentialService,
+		svc.ManifestService,
+		svc.ConstraintService,
 	)
 	handler.RegisterRoutes(e)
 
@@ -169,6 +202,18 @@ func (s *Server) Stop(ctx context.Context) error {
 		}
 	}
 
+	if s.changesetScheduler != nil {
+		s.changesetScheduler.Stop()
+	}
+
+	if s.webhookDispatcher != nil {
+		s.webhookDispatcher.Stop()
+	}
+
+	if s.cacheInvalidationListener != nil {
+		s.cacheInvalidationListener.Stop()
+	}
+
 	if s.dbpool != nil {
 		s.dbpool.Close()
 	}
@@ -0,0 +1,110 @@
+package cacheinvalidation
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const reconnectDelay = 5 * time.Second
+
+type ListenerHandlers struct {
+	OnVariationChanged func(ctx context.Context)
+	OnChangesetApplied func(ctx context.Context, changesetID uint)
+}
+
+// Listener subscribes to invalidation events sent by other server instances and evicts the affected local state
+type Listener struct {
+	dbpool     *pgxpool.Pool
+	instanceID string
+	handlers   ListenerHandlers
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+func NewListener(dbpool *pgxpool.Pool, service *Service, handlers ListenerHandlers) *Listener {
+	return &Listener{
+		dbpool:     dbpool,
+		instanceID: service.InstanceID(),
+		handlers:   handlers,
+	}
+}
+
+func (l *Listener) Start(ctx context.Context) {
+	listenCtx, cancel := context.WithCancel(ctx)
+	l.cancel = cancel
+	l.done = make(chan struct{})
+
+	go func() {
+		defer close(l.done)
+
+		for {
+			err := l.listen(listenCtx)
+			if listenCtx.Err() != nil {
+				return
+			}
+
+			slog.ErrorContext(listenCtx, "cache invalidation listener failed, reconnecting", "error", err)
+
+			select {
+			case <-listenCtx.Done():
+				return
+			case <-time.After(reconnectDelay):
+			}
+		}
+	}()
+}
+
+func (l *Listener) Stop() {
+	if l.cancel == nil {
+		return
+	}
+
+	l.cancel()
+	<-l.done
+}
+
+func (l *Listener) listen(ctx context.Context) error {
+	poolConn, err := l.dbpool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the connection is taken out of the pool, so that it is not reused by queries while listening
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+Channel); err != nil {
+		return err
+	}
+
+	// Events could have been missed while disconnected, so everything is evicted after (re)connecting
+	l.handlers.OnVariationChanged(ctx)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var event Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			slog.ErrorContext(ctx, "invalid cache invalidation event", "payload", notification.Payload, "error", err)
+			continue
+		}
+
+		if event.Source == l.instanceID {
+			continue
+		}
+
+		switch event.Kind {
+		case EventKindVariationChanged:
+			l.handlers.OnVariationChanged(ctx)
+		case EventKindChangesetApplied:
+			l.handlers.OnChangesetApplied(ctx, event.ChangesetID)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package cacheinvalidation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+
+	"github.com/necroskillz/config-service/db"
+)
+
+// Channel is the Postgres NOTIFY channel used to propagate cache invalidations between server instances
+const Channel = "cache_invalidation"
+
+type EventKind string
+
+const (
+	EventKindVariationChanged EventKind = "variation_changed"
+	EventKindChangesetApplied EventKind = "changeset_applied"
+)
+
+type Event struct {
+	Kind        EventKind `json:"kind"`
+	ChangesetID uint      `json:"changesetId,omitempty"`
+	Source      string    `json:"source"`
+}
+
+type Service struct {
+	queries    *db.Queries
+	instanceID string
+}
+
+func NewService(queries *db.Queries) *Service {
+	return &Service{
+		queries:    queries,
+		instanceID: rand.Text(),
+	}
+}
+
+// InstanceID identifies this process, so that it can ignore its own notifications
+func (s *Service) InstanceID() string {
+	return s.instanceID
+}
+
+// Notify sends the event to all listening instances. When called with a transaction, the event is delivered only after it is committed.
+func (s *Service) Notify(ctx context.Context, tx *db.Queries, event Event) error {
+	event.Source = s.instanceID
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	queries := s.queries
+	if tx != nil {
+		queries = tx
+	}
+
+	return queries.Notify(ctx, db.NotifyParams{
+		Channel: Channel,
+		Payload: string(payload),
+	})
+}
@@ -1,9 +1,14 @@
 package changeset
 
 import (
+	"fmt"
+	"slices"
+	"time"
+
 	"github.com/necroskillz/config-service/auth"
 	"github.com/necroskillz/config-service/constants"
 	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/core"
 )
 
 type ChangesetChange struct {
@@ -25,36 +30,61 @@ type ChangesetChange struct {
 	KeyName                        *string                `json:"keyName"`
 	NewVariationValueID            *uint                  `json:"newVariationValueId"`
 	NewVariationValueData          *string                `json:"newVariationValueData"`
+	NewVariationValueRollout       *core.Rollout          `json:"newVariationValueRollout,omitempty"`
 	OldVariationValueID            *uint                  `json:"oldVariationValueId"`
 	OldVariationValueData          *string                `json:"oldVariationValueData"`
+	OldVariationValueRollout       *core.Rollout          `json:"oldVariationValueRollout,omitempty"`
 	Variation                      map[uint]string        `json:"variation"`
 	Conflict                       *Conflict              `json:"conflict,omitempty"`
+	ValueTypeKind                  db.ValueTypeKind       `json:"-"`
 }
 
 type Changeset struct {
-	ID       uint
-	UserID   uint
-	UserName string
-	State    db.ChangesetState
+	ID                uint
+	UserID            uint
+	UserName          string
+	State             db.ChangesetState
+	ApplyAt           *time.Time
+	ScheduledByUserID *uint
+}
+
+type ChangesetApproval struct {
+	UserID       uint      `json:"userId" validate:"required"`
+	UserName     string    `json:"userName" validate:"required"`
+	CreatedAt    time.Time `json:"createdAt" validate:"required"`
+	UserGroupIDs []uint    `json:"-"`
+}
+
+type ReviewPolicy struct {
+	ServiceID             uint
+	ServiceName           string
+	RequiredApprovals     int
+	AllowSelfApproval     bool
+	RequiredUserGroupID   *uint
+	RequiredUserGroupName *string
 }
 
 type ChangesetWithChanges struct {
 	Changeset
 	ChangesetChanges []ChangesetChange
 	ConflictCount    int
+	Approvals        []ChangesetApproval
+	ReviewPolicies   []ReviewPolicy
 }
 
 func NewChangeset(data db.GetChangesetRow) Changeset {
 	return Changeset{
-		ID:       data.ID,
-		UserID:   data.UserID,
-		UserName: data.UserName,
-		State:    data.State,
+		ID:                data.ID,
+		UserID:            data.UserID,
+		UserName:          data.UserName,
+		State:             data.State,
+		ApplyAt:           data.ApplyAt,
+		ScheduledByUserID: data.ScheduledByUserID,
 	}
 }
 
 func (c ChangesetWithChanges) CanBeAppliedBy(user *auth.User) bool {
-	if !c.IsOpen() && !c.IsCommitted() {
+	if !c.IsOpen() && !c.IsCommitted() && !c.IsScheduled() {
 		return false
 	}
 
@@ -87,6 +117,14 @@ func (c Changeset) IsCommitted() bool {
 	return c.State == db.ChangesetStateCommitted
 }
 
+func (c Changeset) IsApplied() bool {
+	return c.State == db.ChangesetStateApplied
+}
+
+func (c Changeset) IsScheduled() bool {
+	return c.State == db.ChangesetStateScheduled
+}
+
 func (c Changeset) IsDiscarded() bool {
 	return c.State == db.ChangesetStateDiscarded
 }
@@ -99,6 +137,60 @@ func (c ChangesetWithChanges) IsEmpty() bool {
 	return len(c.ChangesetChanges) == 0
 }
 
+func (c ChangesetWithChanges) IsApprovedBy(userID uint) bool {
+	return slices.ContainsFunc(c.Approvals, func(a ChangesetApproval) bool {
+		return a.UserID == userID
+	})
+}
+
+func (c ChangesetWithChanges) AllowsSelfApproval() bool {
+	return !slices.ContainsFunc(c.ReviewPolicies, func(p ReviewPolicy) bool {
+		return !p.AllowSelfApproval
+	})
+}
+
+// UnmetReviewPolicy returns the reason why the approvals do not satisfy the review policies of the services touched by the changeset, or empty string if they do
+func (c ChangesetWithChanges) UnmetReviewPolicy() string {
+	for _, policy := range c.ReviewPolicies {
+		approvals := 0
+		approvedByGroup := policy.RequiredUserGroupID == nil
+
+		for _, approval := range c.Approvals {
+			if !policy.AllowSelfApproval && c.BelongsTo(approval.UserID) {
+				continue
+			}
+
+			approvals++
+
+			if !approvedByGroup && slices.Contains(approval.UserGroupIDs, *policy.RequiredUserGroupID) {
+				approvedByGroup = true
+			}
+		}
+
+		if approvals < policy.RequiredApprovals {
+			return fmt.Sprintf("Service %s requires %d approvals, but changeset has %d", policy.ServiceName, policy.RequiredApprovals, approvals)
+		}
+
+		if !approvedByGroup {
+			return fmt.Sprintf("Service %s requires an approval from a member of group %s", policy.ServiceName, *policy.RequiredUserGroupName)
+		}
+	}
+
+	return ""
+}
+
 func (c ChangesetWithChanges) HasConflicts() bool {
 	return c.ConflictCount > 0
 }
+
+// ConstraintViolation returns the message of the first violated constraint, or empty string if no constraint is violated.
+// Constraints are only checked when the changeset is committed, scheduled or applied, so the message is reported with the error.
+func (c ChangesetWithChanges) ConstraintViolation() string {
+	for _, change := range c.ChangesetChanges {
+		if change.Conflict != nil && change.Conflict.Kind == ConflictKindConstraintViolated {
+			return *change.Conflict.Message
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,55 @@
+package changeset
+
+import (
+	"sync"
+	"time"
+)
+
+type AppliedEvent struct {
+	ChangesetID uint
+	AppliedAt   time.Time
+}
+
+type EventBroker struct {
+	mu          sync.RWMutex
+	subscribers map[chan AppliedEvent]struct{}
+}
+
+func NewEventBroker() *EventBroker {
+	return &EventBroker{
+		subscribers: make(map[chan AppliedEvent]struct{}),
+	}
+}
+
+// Subscribe registers a new listener for applied changesets. The returned function must be called to unsubscribe.
+func (b *EventBroker) Subscribe() (<-chan AppliedEvent, func()) {
+	ch := make(chan AppliedEvent, 16)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	unsubscribe := sync.OnceFunc(func() {
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		b.mu.Unlock()
+
+		close(ch)
+	})
+
+	return ch, unsubscribe
+}
+
+// Publish notifies all subscribers. Slow subscribers that have a full buffer miss the event,
+// which is fine since every event only signals that there is something new to fetch.
+func (b *EventBroker) Publish(event AppliedEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
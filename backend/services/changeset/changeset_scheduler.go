@@ -0,0 +1,88 @@
+package changeset
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/necroskillz/config-service/auth"
+	"github.com/necroskillz/config-service/constants"
+)
+
+const schedulerInterval = 15 * time.Second
+
+type UserLoaderFunc func(ctx context.Context, userID uint) (*auth.User, error)
+
+// Scheduler periodically applies scheduled changesets that are due, on behalf of the users that scheduled them
+type Scheduler struct {
+	service  *Service
+	loadUser UserLoaderFunc
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+func NewScheduler(service *Service, loadUser UserLoaderFunc) *Scheduler {
+	return &Scheduler{
+		service:  service,
+		loadUser: loadUser,
+	}
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	schedulerCtx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(schedulerInterval)
+		defer ticker.Stop()
+
+		for {
+			s.applyDueChangesets(schedulerCtx)
+
+			select {
+			case <-schedulerCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+	<-s.done
+}
+
+func (s *Scheduler) applyDueChangesets(ctx context.Context) {
+	now := time.Now()
+
+	changesets, err := s.service.queries.GetDueScheduledChangesets(ctx, &now)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.ErrorContext(ctx, "failed to get scheduled changesets", "error", err)
+		}
+
+		return
+	}
+
+	for _, changeset := range changesets {
+		user, err := s.loadUser(ctx, changeset.ScheduledByUserID)
+		if err != nil {
+			slog.ErrorContext(ctx, "failed to load user for scheduled changeset", "changesetID", changeset.ID, "userID", changeset.ScheduledByUserID, "error", err)
+			continue
+		}
+
+		userCtx := context.WithValue(ctx, constants.UserContextKey, user)
+
+		if err := s.service.ApplyScheduledChangeset(userCtx, changeset.ID); err != nil {
+			slog.ErrorContext(ctx, "failed to apply scheduled changeset", "changesetID", changeset.ID, "error", err)
+		}
+	}
+}
@@ -9,9 +9,14 @@ import (
 	"time"
 
 	"github.com/necroskillz/config-service/auth"
+	"github.com/necroskillz/config-service/constants"
 	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/cacheinvalidation"
+	"github.com/necroskillz/config-service/services/constraint"
 	"github.com/necroskillz/config-service/services/core"
+	"github.com/necroskillz/config-service/services/secret"
 	"github.com/necroskillz/config-service/services/variation"
+	"github.com/necroskillz/config-service/util/ptr"
 	"github.com/necroskillz/config-service/util/validator"
 )
 
@@ -22,6 +27,10 @@ type Service struct {
 	queries                 *db.Queries
 	validator               *validator.Validator
 	detector                *ConflictDetector
+	eventBroker             *EventBroker
+	cacheInvalidation       *cacheinvalidation.Service
+	secretService           *secret.Service
+	constraintService       *constraint.Service
 }
 
 func NewService(
@@ -30,6 +39,10 @@ func NewService(
 	unitOfWorkRunner db.UnitOfWorkRunner,
 	currentUserAccessor *auth.CurrentUserAccessor,
 	validator *validator.Validator,
+	eventBroker *EventBroker,
+	cacheInvalidation *cacheinvalidation.Service,
+	secretService *secret.Service,
+	constraintService *constraint.Service,
 ) *Service {
 	return &Service{
 		queries:                 queries,
@@ -38,6 +51,10 @@ func NewService(
 		currentUserAccessor:     currentUserAccessor,
 		validator:               validator,
 		detector:                NewConflictDetector(),
+		eventBroker:             eventBroker,
+		cacheInvalidation:       cacheInvalidation,
+		secretService:           secretService,
+		constraintService:       constraintService,
 	}
 }
 
@@ -156,15 +173,12 @@ func (s *Service) getChangesetWithoutChanges(ctx context.Context, changesetID ui
 		return Changeset{}, core.NewDbError(err, "Changeset")
 	}
 
-	return Changeset{
-		ID:       changeset.ID,
-		UserID:   changeset.UserID,
-		UserName: changeset.UserName,
-		State:    changeset.State,
-	}, nil
+	return NewChangeset(changeset), nil
 }
 
-func (s *Service) getChangeset(ctx context.Context, changesetID uint) (ChangesetWithChanges, error) {
+// getChangeset returns the changeset with its changes and their conflicts. Evaluating the constraints of the changed feature
+// versions is expensive, so violated constraints are only detected when checkConstraints is set.
+func (s *Service) getChangeset(ctx context.Context, changesetID uint, checkConstraints bool) (ChangesetWithChanges, error) {
 	changesetWithChanges := ChangesetWithChanges{}
 
 	changeset, err := s.getChangesetWithoutChanges(ctx, changesetID)
@@ -199,9 +213,12 @@ func (s *Service) getChangeset(ctx context.Context, changesetID uint) (Changeset
 			KeyName:                        change.KeyName,
 			NewVariationValueID:            change.NewVariationValueID,
 			NewVariationValueData:          change.NewVariationValueData,
+			NewVariationValueRollout:       core.NewRollout(change.NewVariationValueRolloutPercentage, change.NewVariationValueRolloutAttribute),
 			OldVariationValueID:            change.OldVariationValueID,
 			OldVariationValueData:          change.OldVariationValueData,
+			OldVariationValueRollout:       core.NewRollout(change.OldVariationValueRolloutPercentage, change.OldVariationValueRolloutAttribute),
 			FeatureVersionServiceVersionID: change.FeatureVersionServiceVersionID,
+			ValueTypeKind:                  change.ValueTypeKind.ValueTypeKind,
 		}
 
 		if change.VariationContextID != nil {
@@ -214,15 +231,80 @@ func (s *Service) getChangeset(ctx context.Context, changesetID uint) (Changeset
 		}
 	}
 
-	if changeset.IsOpen() || changeset.IsCommitted() {
-		changesetWithChanges.ConflictCount = s.detector.DetectConflicts(changes, changesetChanges)
+	if changeset.IsOpen() || changeset.IsCommitted() || changeset.IsScheduled() {
+		var constraintViolations map[uint]string
+		if checkConstraints {
+			constraintViolations, err = s.constraintService.CheckChangeset(ctx, changesetID, changes)
+			if err != nil {
+				return changesetWithChanges, err
+			}
+		}
+
+		changesetWithChanges.ConflictCount = s.detector.DetectConflicts(changes, changesetChanges, constraintViolations)
+
+		changesetWithChanges.ReviewPolicies, err = s.getReviewPolicies(ctx, changesetChanges)
+		if err != nil {
+			return changesetWithChanges, err
+		}
 	}
 
 	changesetWithChanges.ChangesetChanges = changesetChanges
 
+	approvals, err := s.queries.GetChangesetApprovals(ctx, changesetID)
+	if err != nil {
+		return changesetWithChanges, err
+	}
+
+	changesetWithChanges.Approvals = make([]ChangesetApproval, len(approvals))
+	for i, approval := range approvals {
+		changesetWithChanges.Approvals[i] = ChangesetApproval{
+			UserID:       approval.UserID,
+			UserName:     approval.UserName,
+			CreatedAt:    approval.CreatedAt,
+			UserGroupIDs: approval.UserGroupIds,
+		}
+	}
+
 	return changesetWithChanges, nil
 }
 
+// conflictsError returns the error for a changeset with conflicts, including the violated constraint, which is not shown with the changes
+func conflictsError(changeset ChangesetWithChanges, message string) error {
+	if violation := changeset.ConstraintViolation(); violation != "" {
+		message = fmt.Sprintf("%s, %s", message, violation)
+	}
+
+	return core.NewServiceError(core.ErrorCodeInvalidOperation, message)
+}
+
+func (s *Service) getReviewPolicies(ctx context.Context, changes []ChangesetChange) ([]ReviewPolicy, error) {
+	serviceIDs := make([]uint, 0, len(changes))
+	for _, change := range changes {
+		if !slices.Contains(serviceIDs, change.ServiceID) {
+			serviceIDs = append(serviceIDs, change.ServiceID)
+		}
+	}
+
+	policies, err := s.queries.GetServiceReviewPolicies(ctx, serviceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewPolicies := make([]ReviewPolicy, len(policies))
+	for i, policy := range policies {
+		reviewPolicies[i] = ReviewPolicy{
+			ServiceID:             policy.ServiceID,
+			ServiceName:           policy.ServiceName,
+			RequiredApprovals:     policy.RequiredApprovals,
+			AllowSelfApproval:     policy.AllowSelfApproval,
+			RequiredUserGroupID:   policy.RequiredUserGroupID,
+			RequiredUserGroupName: policy.RequiredUserGroupName,
+		}
+	}
+
+	return reviewPolicies, nil
+}
+
 type ChangesetAction struct {
 	ID        uint                   `json:"id" validate:"required"`
 	Type      db.ChangesetActionType `json:"type" validate:"required"`
@@ -233,19 +315,21 @@ type ChangesetAction struct {
 }
 
 type ChangesetDto struct {
-	ID               uint              `json:"id" validate:"required"`
-	UserID           uint              `json:"userId" validate:"required"`
-	UserName         string            `json:"userName" validate:"required"`
-	State            db.ChangesetState `json:"state" validate:"required"`
-	CanApply         bool              `json:"canApply" validate:"required"`
-	ConflictCount    int               `json:"conflictCount" validate:"required"`
-	VariationContext map[uint]string   `json:"variationContext" validate:"required"`
-	Changes          []ChangesetChange `json:"changes" validate:"required"`
-	Actions          []ChangesetAction `json:"actions" validate:"required"`
+	ID               uint                `json:"id" validate:"required"`
+	UserID           uint                `json:"userId" validate:"required"`
+	UserName         string              `json:"userName" validate:"required"`
+	State            db.ChangesetState   `json:"state" validate:"required"`
+	ApplyAt          *time.Time          `json:"applyAt"`
+	CanApply         bool                `json:"canApply" validate:"required"`
+	ConflictCount    int                 `json:"conflictCount" validate:"required"`
+	VariationContext map[uint]string     `json:"variationContext" validate:"required"`
+	Changes          []ChangesetChange   `json:"changes" validate:"required"`
+	Actions          []ChangesetAction   `json:"actions" validate:"required"`
+	Approvals        []ChangesetApproval `json:"approvals" validate:"required"`
 }
 
 func (s *Service) GetChangeset(ctx context.Context, changesetID uint) (ChangesetDto, error) {
-	changeset, err := s.getChangeset(ctx, changesetID)
+	changeset, err := s.getChangeset(ctx, changesetID, false)
 	if err != nil {
 		return ChangesetDto{}, err
 	}
@@ -255,6 +339,13 @@ func (s *Service) GetChangeset(ctx context.Context, changesetID uint) (Changeset
 		return ChangesetDto{}, err
 	}
 
+	revealSecrets := s.secretService.CanReveal(ctx)
+	for i := range changeset.ChangesetChanges {
+		if err := s.presentChange(&changeset.ChangesetChanges[i], revealSecrets); err != nil {
+			return ChangesetDto{}, err
+		}
+	}
+
 	user := s.currentUserAccessor.GetUser(ctx)
 
 	dto := ChangesetDto{
@@ -262,9 +353,11 @@ func (s *Service) GetChangeset(ctx context.Context, changesetID uint) (Changeset
 		UserID:        changeset.UserID,
 		UserName:      changeset.UserName,
 		State:         changeset.State,
+		ApplyAt:       changeset.ApplyAt,
 		Changes:       changeset.ChangesetChanges,
-		CanApply:      changeset.CanBeAppliedBy(user),
+		CanApply:      changeset.CanBeAppliedBy(user) && changeset.UnmetReviewPolicy() == "",
 		ConflictCount: changeset.ConflictCount,
+		Approvals:     changeset.Approvals,
 	}
 
 	dto.Actions = make([]ChangesetAction, 0, len(actions))
@@ -282,14 +375,43 @@ func (s *Service) GetChangeset(ctx context.Context, changesetID uint) (Changeset
 	return dto, nil
 }
 
+// presentChange replaces the data of secret values in the change with what the current user is allowed to see
+func (s *Service) presentChange(change *ChangesetChange, revealSecrets bool) error {
+	var err error
+
+	change.NewVariationValueData, err = s.secretService.PresentOptional(change.ValueTypeKind, change.NewVariationValueData, revealSecrets)
+	if err != nil {
+		return err
+	}
+
+	change.OldVariationValueData, err = s.secretService.PresentOptional(change.ValueTypeKind, change.OldVariationValueData, revealSecrets)
+	if err != nil {
+		return err
+	}
+
+	if change.Conflict != nil && change.Conflict.ExistingValueData != nil {
+		conflict := *change.Conflict
+		conflict.ExistingValueData, err = s.secretService.PresentOptional(change.ValueTypeKind, conflict.ExistingValueData, revealSecrets)
+		if err != nil {
+			return err
+		}
+
+		change.Conflict = &conflict
+	}
+
+	return nil
+}
+
 func (s *Service) ApplyChangeset(ctx context.Context, changesetID uint, comment *string) error {
-	return s.unitOfWorkRunner.Run(ctx, func(tx *db.Queries) error {
+	var appliedAt time.Time
+
+	err := s.unitOfWorkRunner.Run(ctx, func(tx *db.Queries) error {
 		_, err := tx.LockChangesetForUpdate(ctx, changesetID)
 		if err != nil {
 			return err
 		}
 
-		changeset, err := s.getChangeset(ctx, changesetID)
+		changeset, err := s.getChangeset(ctx, changesetID, true)
 		if err != nil {
 			return err
 		}
@@ -297,107 +419,193 @@ func (s *Service) ApplyChangeset(ctx context.Context, changesetID uint, comment
 		user := s.currentUserAccessor.GetUser(ctx)
 
 		if !changeset.CanBeAppliedBy(user) {
-			return core.NewServiceError(core.ErrorCodePermissionDenied, "To apply changeset, it needs to be in an open or committed state and the user needs to have admin permissions for all changes")
+			return core.NewServiceError(core.ErrorCodePermissionDenied, "To apply changeset, it needs to be in an open, committed or scheduled state and the user needs to have admin permissions for all changes")
+		}
+
+		if reason := changeset.UnmetReviewPolicy(); reason != "" {
+			return core.NewServiceError(core.ErrorCodePermissionDenied, reason)
 		}
 
 		if changeset.HasConflicts() {
-			return core.NewServiceError(core.ErrorCodeInvalidOperation, "Changeset has conflicts that need to be resolved before it can be applied")
+			return conflictsError(changeset, "Changeset has conflicts that need to be resolved before it can be applied")
 		}
 
-		startTime := time.Now()
-		endTime := startTime.Add(time.Microsecond * -1)
+		appliedAt, err = s.applyChangeset(ctx, tx, changeset, user, comment)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	s.eventBroker.Publish(AppliedEvent{
+		ChangesetID: changesetID,
+		AppliedAt:   appliedAt,
+	})
+
+	return nil
+}
 
-		for _, change := range changeset.ChangesetChanges {
-			if change.NewVariationValueID != nil || change.OldVariationValueID != nil {
-				if change.OldVariationValueID != nil {
-					if err := tx.EndValueValidity(ctx, db.EndValueValidityParams{
-						VariationValueID: *change.OldVariationValueID,
-						ValidTo:          &endTime,
-					}); err != nil {
-						return err
-					}
+func (s *Service) applyChangeset(ctx context.Context, tx *db.Queries, changeset ChangesetWithChanges, user *auth.User, comment *string) (time.Time, error) {
+	startTime := time.Now()
+	endTime := startTime.Add(time.Microsecond * -1)
+
+	for _, change := range changeset.ChangesetChanges {
+		if change.NewVariationValueID != nil || change.OldVariationValueID != nil {
+			if change.OldVariationValueID != nil {
+				if err := tx.EndValueValidity(ctx, db.EndValueValidityParams{
+					VariationValueID: *change.OldVariationValueID,
+					ValidTo:          &endTime,
+				}); err != nil {
+					return time.Time{}, err
 				}
+			}
 
-				if change.NewVariationValueID != nil {
-					if err := tx.StartValueValidity(ctx, db.StartValueValidityParams{
-						VariationValueID: *change.NewVariationValueID,
-						ValidFrom:        &startTime,
-					}); err != nil {
-						return err
-					}
+			if change.NewVariationValueID != nil {
+				if err := tx.StartValueValidity(ctx, db.StartValueValidityParams{
+					VariationValueID: *change.NewVariationValueID,
+					ValidFrom:        &startTime,
+				}); err != nil {
+					return time.Time{}, err
 				}
+			}
 
-			} else if change.KeyID != nil {
-				if change.Type == db.ChangesetChangeTypeCreate {
-					if err := tx.StartKeyValidity(ctx, db.StartKeyValidityParams{
-						KeyID:     *change.KeyID,
-						ValidFrom: &startTime,
-					}); err != nil {
-						return err
-					}
-				} else if change.Type == db.ChangesetChangeTypeDelete {
-					if err := tx.EndKeyValidity(ctx, db.EndKeyValidityParams{
-						KeyID:   *change.KeyID,
-						ValidTo: &endTime,
-					}); err != nil {
-						return err
-					}
+		} else if change.KeyID != nil {
+			if change.Type == db.ChangesetChangeTypeCreate {
+				if err := tx.StartKeyValidity(ctx, db.StartKeyValidityParams{
+					KeyID:     *change.KeyID,
+					ValidFrom: &startTime,
+				}); err != nil {
+					return time.Time{}, err
 				}
-			} else if change.FeatureVersionServiceVersionID != nil {
-				if change.Type == db.ChangesetChangeTypeCreate {
-					if err := tx.StartFeatureVersionServiceVersionValidity(ctx, db.StartFeatureVersionServiceVersionValidityParams{
-						FeatureVersionServiceVersionID: *change.FeatureVersionServiceVersionID,
-						ValidFrom:                      &startTime,
-					}); err != nil {
-						return err
-					}
-				} else if change.Type == db.ChangesetChangeTypeDelete {
-					if err := tx.EndFeatureVersionServiceVersionValidity(ctx, db.EndFeatureVersionServiceVersionValidityParams{
-						FeatureVersionServiceVersionID: *change.FeatureVersionServiceVersionID,
-						ValidTo:                        &endTime,
-					}); err != nil {
-						return err
-					}
+			} else if change.Type == db.ChangesetChangeTypeDelete {
+				if err := tx.EndKeyValidity(ctx, db.EndKeyValidityParams{
+					KeyID:   *change.KeyID,
+					ValidTo: &endTime,
+				}); err != nil {
+					return time.Time{}, err
 				}
-			} else if change.FeatureVersionID != nil {
-				if change.Type == db.ChangesetChangeTypeCreate {
-					if err := tx.StartFeatureVersionValidity(ctx, db.StartFeatureVersionValidityParams{
-						FeatureVersionID: *change.FeatureVersionID,
-						ValidFrom:        &startTime,
-					}); err != nil {
-						return err
-					}
-				} else if change.Type == db.ChangesetChangeTypeDelete {
-					if err := tx.EndFeatureVersionValidity(ctx, db.EndFeatureVersionValidityParams{
-						FeatureVersionID: *change.FeatureVersionID,
-						ValidTo:          &endTime,
-					}); err != nil {
-						return err
-					}
+			}
+		} else if change.FeatureVersionServiceVersionID != nil {
+			if change.Type == db.ChangesetChangeTypeCreate {
+				if err := tx.StartFeatureVersionServiceVersionValidity(ctx, db.StartFeatureVersionServiceVersionValidityParams{
+					FeatureVersionServiceVersionID: *change.FeatureVersionServiceVersionID,
+					ValidFrom:                      &startTime,
+				}); err != nil {
+					return time.Time{}, err
+				}
+			} else if change.Type == db.ChangesetChangeTypeDelete {
+				if err := tx.EndFeatureVersionServiceVersionValidity(ctx, db.EndFeatureVersionServiceVersionValidityParams{
+					FeatureVersionServiceVersionID: *change.FeatureVersionServiceVersionID,
+					ValidTo:                        &endTime,
+				}); err != nil {
+					return time.Time{}, err
+				}
+			}
+		} else if change.FeatureVersionID != nil {
+			if change.Type == db.ChangesetChangeTypeCreate {
+				if err := tx.StartFeatureVersionValidity(ctx, db.StartFeatureVersionValidityParams{
+					FeatureVersionID: *change.FeatureVersionID,
+					ValidFrom:        &startTime,
+				}); err != nil {
+					return time.Time{}, err
+				}
+			} else if change.Type == db.ChangesetChangeTypeDelete {
+				if err := tx.EndFeatureVersionValidity(ctx, db.EndFeatureVersionValidityParams{
+					FeatureVersionID: *change.FeatureVersionID,
+					ValidTo:          &endTime,
+				}); err != nil {
+					return time.Time{}, err
 				}
-			} else {
-				if change.Type == db.ChangesetChangeTypeCreate {
-					if err := tx.StartServiceVersionValidity(ctx, db.StartServiceVersionValidityParams{
-						ServiceVersionID: change.ServiceVersionID,
-						ValidFrom:        &startTime,
-					}); err != nil {
-						return err
-					}
-				} else if change.Type == db.ChangesetChangeTypeDelete {
-					if err := tx.EndServiceVersionValidity(ctx, db.EndServiceVersionValidityParams{
-						ServiceVersionID: change.ServiceVersionID,
-						ValidTo:          &endTime,
-					}); err != nil {
-						return err
-					}
+			}
+		} else {
+			if change.Type == db.ChangesetChangeTypeCreate {
+				if err := tx.StartServiceVersionValidity(ctx, db.StartServiceVersionValidityParams{
+					ServiceVersionID: change.ServiceVersionID,
+					ValidFrom:        &startTime,
+				}); err != nil {
+					return time.Time{}, err
+				}
+			} else if change.Type == db.ChangesetChangeTypeDelete {
+				if err := tx.EndServiceVersionValidity(ctx, db.EndServiceVersionValidityParams{
+					ServiceVersionID: change.ServiceVersionID,
+					ValidTo:          &endTime,
+				}); err != nil {
+					return time.Time{}, err
 				}
 			}
 		}
+	}
 
-		if err := tx.SetChangesetState(ctx, db.SetChangesetStateParams{
-			ChangesetID: changeset.ID,
-			State:       db.ChangesetStateApplied,
-			AppliedAt:   &startTime,
+	if err := tx.SetChangesetState(ctx, db.SetChangesetStateParams{
+		ChangesetID: changeset.ID,
+		State:       db.ChangesetStateApplied,
+		AppliedAt:   &startTime,
+	}); err != nil {
+		return time.Time{}, err
+	}
+
+	if err := tx.AddChangesetAction(ctx, db.AddChangesetActionParams{
+		ChangesetID: changeset.ID,
+		UserID:      user.ID,
+		Type:        db.ChangesetActionTypeApply,
+		Comment:     comment,
+	}); err != nil {
+		return time.Time{}, err
+	}
+
+	if err := enqueueWebhooks(ctx, tx, db.WebhookEventTypeChangesetApplied, changeset.ID, user, comment); err != nil {
+		return time.Time{}, err
+	}
+
+	if err := s.cacheInvalidation.Notify(ctx, tx, cacheinvalidation.Event{
+		Kind:        cacheinvalidation.EventKindChangesetApplied,
+		ChangesetID: changeset.ID,
+	}); err != nil {
+		return time.Time{}, err
+	}
+
+	return startTime, nil
+}
+
+func (s *Service) ScheduleChangeset(ctx context.Context, changesetID uint, applyAt time.Time, comment *string) error {
+	if !applyAt.After(time.Now()) {
+		return core.NewServiceError(core.ErrorCodeInvalidOperation, "Changeset can only be scheduled to be applied in the future")
+	}
+
+	return s.unitOfWorkRunner.Run(ctx, func(tx *db.Queries) error {
+		_, err := tx.LockChangesetForUpdate(ctx, changesetID)
+		if err != nil {
+			return err
+		}
+
+		changeset, err := s.getChangeset(ctx, changesetID, true)
+		if err != nil {
+			return err
+		}
+
+		user := s.currentUserAccessor.GetUser(ctx)
+
+		if changeset.IsScheduled() || !changeset.CanBeAppliedBy(user) {
+			return core.NewServiceError(core.ErrorCodePermissionDenied, "To schedule changeset, it needs to be in an open or committed state and the user needs to have admin permissions for all changes")
+		}
+
+		if changeset.IsEmpty() {
+			return core.NewServiceError(core.ErrorCodeInvalidOperation, "Changeset has no changes")
+		}
+
+		if reason := changeset.UnmetReviewPolicy(); reason != "" {
+			return core.NewServiceError(core.ErrorCodePermissionDenied, reason)
+		}
+
+		if changeset.HasConflicts() {
+			return conflictsError(changeset, "Changeset has conflicts that need to be resolved before it can be scheduled")
+		}
+
+		if err := tx.ScheduleChangeset(ctx, db.ScheduleChangesetParams{
+			ChangesetID:       changeset.ID,
+			ApplyAt:           &applyAt,
+			ScheduledByUserID: &user.ID,
 		}); err != nil {
 			return err
 		}
@@ -405,7 +613,7 @@ func (s *Service) ApplyChangeset(ctx context.Context, changesetID uint, comment
 		if err := tx.AddChangesetAction(ctx, db.AddChangesetActionParams{
 			ChangesetID: changeset.ID,
 			UserID:      user.ID,
-			Type:        db.ChangesetActionTypeApply,
+			Type:        db.ChangesetActionTypeSchedule,
 			Comment:     comment,
 		}); err != nil {
 			return err
@@ -415,8 +623,108 @@ func (s *Service) ApplyChangeset(ctx context.Context, changesetID uint, comment
 	})
 }
 
+func (s *Service) UnscheduleChangeset(ctx context.Context, changesetID uint) error {
+	changeset, err := s.getChangesetWithoutChanges(ctx, changesetID)
+	if err != nil {
+		return err
+	}
+
+	user := s.currentUserAccessor.GetUser(ctx)
+
+	if !changeset.IsScheduled() {
+		return core.NewServiceError(core.ErrorCodeInvalidOperation, fmt.Sprintf("Cannot unschedule changeset in state %s", changeset.State))
+	}
+
+	if !changeset.BelongsTo(user.ID) && *changeset.ScheduledByUserID != user.ID && !user.IsGlobalAdmin {
+		return core.NewServiceError(core.ErrorCodePermissionDenied, "You are not allowed to unschedule this changeset")
+	}
+
+	return s.unitOfWorkRunner.Run(ctx, func(tx *db.Queries) error {
+		if err := tx.UnscheduleChangeset(ctx, changeset.ID); err != nil {
+			return err
+		}
+
+		if err := tx.AddChangesetAction(ctx, db.AddChangesetActionParams{
+			ChangesetID: changeset.ID,
+			UserID:      user.ID,
+			Type:        db.ChangesetActionTypeUnschedule,
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// ApplyScheduledChangeset applies a scheduled changeset on behalf of the user in the context, who is expected to be the one that scheduled it.
+// If the changeset can no longer be applied, it is moved back to the committed state and the reason is recorded as a schedule_failed action.
+func (s *Service) ApplyScheduledChangeset(ctx context.Context, changesetID uint) error {
+	var appliedAt time.Time
+	var failure error
+
+	err := s.unitOfWorkRunner.Run(ctx, func(tx *db.Queries) error {
+		_, err := tx.LockChangesetForUpdate(ctx, changesetID)
+		if err != nil {
+			return err
+		}
+
+		changeset, err := s.getChangeset(ctx, changesetID, true)
+		if err != nil {
+			return err
+		}
+
+		// another instance could have applied or the user unscheduled the changeset in the meantime
+		if !changeset.IsScheduled() || changeset.ApplyAt.After(time.Now()) {
+			return nil
+		}
+
+		user := s.currentUserAccessor.GetUser(ctx)
+
+		if !changeset.CanBeAppliedBy(user) {
+			failure = core.NewServiceError(core.ErrorCodePermissionDenied, "The user that scheduled the changeset no longer has admin permissions for all changes")
+		} else if reason := changeset.UnmetReviewPolicy(); reason != "" {
+			failure = core.NewServiceError(core.ErrorCodePermissionDenied, reason)
+		} else if changeset.HasConflicts() {
+			failure = conflictsError(changeset, fmt.Sprintf("Changeset has %d conflicts that need to be resolved before it can be applied", changeset.ConflictCount))
+		}
+
+		if failure != nil {
+			if err := tx.UnscheduleChangeset(ctx, changeset.ID); err != nil {
+				return err
+			}
+
+			return tx.AddChangesetAction(ctx, db.AddChangesetActionParams{
+				ChangesetID: changeset.ID,
+				UserID:      user.ID,
+				Type:        db.ChangesetActionTypeScheduleFailed,
+				Comment:     ptr.To(failure.Error()),
+			})
+		}
+
+		appliedAt, err = s.applyChangeset(ctx, tx, changeset, user, nil)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if failure != nil {
+		return failure
+	}
+
+	if !appliedAt.IsZero() {
+		s.eventBroker.Publish(AppliedEvent{
+			ChangesetID: changesetID,
+			AppliedAt:   appliedAt,
+		})
+	}
+
+	return nil
+}
+
 func (s *Service) CommitChangeset(ctx context.Context, changesetID uint, comment *string) error {
-	changeset, err := s.getChangeset(ctx, changesetID)
+	changeset, err := s.getChangeset(ctx, changesetID, true)
 	if err != nil {
 		return err
 	}
@@ -432,7 +740,7 @@ func (s *Service) CommitChangeset(ctx context.Context, changesetID uint, comment
 	}
 
 	if changeset.HasConflicts() {
-		return core.NewServiceError(core.ErrorCodeInvalidOperation, "Changeset has conflicts that need to be resolved before it can be committed")
+		return conflictsError(changeset, "Changeset has conflicts that need to be resolved before it can be committed")
 	}
 
 	return s.unitOfWorkRunner.Run(ctx, func(tx *db.Queries) error {
@@ -452,7 +760,39 @@ func (s *Service) CommitChangeset(ctx context.Context, changesetID uint, comment
 			return err
 		}
 
-		return nil
+		return enqueueWebhooks(ctx, tx, db.WebhookEventTypeChangesetCommitted, changeset.ID, user, comment)
+	})
+}
+
+func (s *Service) ApproveChangeset(ctx context.Context, changesetID uint, comment *string) error {
+	changeset, err := s.getChangeset(ctx, changesetID, false)
+	if err != nil {
+		return err
+	}
+
+	user := s.currentUserAccessor.GetUser(ctx)
+
+	if !changeset.IsCommitted() {
+		return core.NewServiceError(core.ErrorCodeInvalidOperation, fmt.Sprintf("Cannot approve changeset in state %s", changeset.State))
+	}
+
+	if !changeset.CanBeAppliedBy(user) {
+		return core.NewServiceError(core.ErrorCodePermissionDenied, "To approve changeset, the user needs to have admin permissions for all changes")
+	}
+
+	if changeset.BelongsTo(user.ID) && !changeset.AllowsSelfApproval() {
+		return core.NewServiceError(core.ErrorCodePermissionDenied, "You are not allowed to approve your own changeset")
+	}
+
+	if changeset.IsApprovedBy(user.ID) {
+		return core.NewServiceError(core.ErrorCodeInvalidOperation, "You have already approved this changeset")
+	}
+
+	return s.queries.AddChangesetAction(ctx, db.AddChangesetActionParams{
+		ChangesetID: changeset.ID,
+		UserID:      user.ID,
+		Type:        db.ChangesetActionTypeApprove,
+		Comment:     comment,
 	})
 }
 
@@ -538,7 +878,7 @@ func (s *Service) discardChangeEntity(ctx context.Context, tx *db.Queries, chang
 
 func (s *Service) DiscardChangeset(ctx context.Context, changesetID uint) error {
 	user := s.currentUserAccessor.GetUser(ctx)
-	changeset, err := s.getChangeset(ctx, changesetID)
+	changeset, err := s.getChangeset(ctx, changesetID, false)
 	if err != nil {
 		return err
 	}
@@ -583,7 +923,7 @@ func (s *Service) DiscardChangeset(ctx context.Context, changesetID uint) error
 
 func (s *Service) DiscardChange(ctx context.Context, changesetID uint, changeID uint) error {
 	user := s.currentUserAccessor.GetUser(ctx)
-	changeset, err := s.getChangeset(ctx, changesetID)
+	changeset, err := s.getChangeset(ctx, changesetID, false)
 	if err != nil {
 		return err
 	}
@@ -623,6 +963,202 @@ func (s *Service) DiscardChange(ctx context.Context, changesetID uint, changeID
 	})
 }
 
+func (s *Service) validateRevertChangeset(ctx context.Context, changeset Changeset, changes []db.GetChangesetChangesRow) error {
+	user := s.currentUserAccessor.GetUser(ctx)
+
+	if !changeset.IsApplied() {
+		return core.NewServiceError(core.ErrorCodeInvalidOperation, fmt.Sprintf("Cannot revert changeset in state %s", changeset.State))
+	}
+
+	if user.ChangesetID != 0 {
+		changesCount, err := s.queries.GetChangesetChangesCount(ctx, user.ChangesetID)
+		if err != nil {
+			return core.NewDbError(err, "ChangesetChangesCount")
+		}
+
+		if changesCount > 0 {
+			return core.NewServiceError(core.ErrorCodeInvalidOperation, fmt.Sprintf("Your current changeset contains %d changes. Please apply, stash or discard them before reverting another changeset.", changesCount))
+		}
+	}
+
+	for _, change := range changes {
+		if user.GetPermissionForService(change.ServiceID) != constants.PermissionAdmin {
+			return core.NewServiceError(core.ErrorCodePermissionDenied, "To revert changeset, the user needs to have admin permissions for all changes")
+		}
+
+		if change.Kind == db.ChangesetChangeKindServiceVersion {
+			return core.NewServiceError(core.ErrorCodeInvalidOperation, "Changesets that create service versions cannot be reverted")
+		}
+
+		if change.Kind == db.ChangesetChangeKindKey && change.Type == db.ChangesetChangeTypeDelete {
+			return core.NewServiceError(core.ErrorCodeInvalidOperation, "Changesets that delete keys cannot be reverted")
+		}
+	}
+
+	return nil
+}
+
+// RevertChangeset adds the inverse of every change of an applied changeset to the open changeset of the current user and returns its ID.
+// Feature versions created by the changeset are unlinked, keys and values inside them are left untouched.
+func (s *Service) RevertChangeset(ctx context.Context, changesetID uint) (uint, error) {
+	changeset, err := s.getChangesetWithoutChanges(ctx, changesetID)
+	if err != nil {
+		return 0, err
+	}
+
+	changes, err := s.queries.GetChangesetChanges(ctx, changesetID)
+	if err != nil {
+		return 0, core.NewDbError(err, "ChangesetChanges")
+	}
+
+	if err := s.validateRevertChangeset(ctx, changeset, changes); err != nil {
+		return 0, err
+	}
+
+	createdFeatureVersions := map[uint]bool{}
+	createdKeys := map[uint]bool{}
+
+	for _, change := range changes {
+		if change.Kind == db.ChangesetChangeKindFeatureVersion && change.Type == db.ChangesetChangeTypeCreate {
+			createdFeatureVersions[*change.FeatureVersionID] = true
+		} else if change.Kind == db.ChangesetChangeKindKey && change.Type == db.ChangesetChangeTypeCreate {
+			createdKeys[*change.KeyID] = true
+		}
+	}
+
+	user := s.currentUserAccessor.GetUser(ctx)
+	var revertChangesetID uint
+
+	err = s.unitOfWorkRunner.Run(ctx, func(tx *db.Queries) error {
+		revertChangesetID, err = s.EnsureChangesetForUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		// changes are inverted in reverse order, so that e.g. a link is removed before the link it replaced is recreated
+		for _, change := range slices.Backward(changes) {
+			switch change.Kind {
+			case db.ChangesetChangeKindVariationValue:
+				if createdFeatureVersions[*change.FeatureVersionID] || createdKeys[*change.KeyID] {
+					continue
+				}
+
+				if err := s.revertValueChange(ctx, tx, revertChangesetID, change); err != nil {
+					return err
+				}
+			case db.ChangesetChangeKindKey:
+				if createdFeatureVersions[*change.FeatureVersionID] {
+					continue
+				}
+
+				if err := tx.AddDeleteKeyChange(ctx, db.AddDeleteKeyChangeParams{
+					ChangesetID:      revertChangesetID,
+					KeyID:            *change.KeyID,
+					FeatureVersionID: *change.FeatureVersionID,
+					ServiceVersionID: change.ServiceVersionID,
+				}); err != nil {
+					return err
+				}
+			case db.ChangesetChangeKindFeatureVersionServiceVersion:
+				if err := s.revertLinkChange(ctx, tx, revertChangesetID, change); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := tx.AddChangesetAction(ctx, db.AddChangesetActionParams{
+			ChangesetID: revertChangesetID,
+			UserID:      user.ID,
+			Type:        db.ChangesetActionTypeRevert,
+			Comment:     ptr.To(fmt.Sprintf("Reverts changeset #%d", changesetID)),
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return revertChangesetID, nil
+}
+
+func (s *Service) revertValueChange(ctx context.Context, tx *db.Queries, changesetID uint, change db.GetChangesetChangesRow) error {
+	var restoredValueID uint
+
+	if change.OldVariationValueID != nil {
+		id, err := tx.CreateVariationValue(ctx, db.CreateVariationValueParams{
+			KeyID:              *change.KeyID,
+			VariationContextID: *change.VariationContextID,
+			Data:               *change.OldVariationValueData,
+			RolloutPercentage:  change.OldVariationValueRolloutPercentage,
+			RolloutAttribute:   change.OldVariationValueRolloutAttribute,
+		})
+		if err != nil {
+			return err
+		}
+
+		restoredValueID = id
+	}
+
+	switch change.Type {
+	case db.ChangesetChangeTypeCreate:
+		return tx.AddDeleteVariationValueChange(ctx, db.AddDeleteVariationValueChangeParams{
+			ChangesetID:         changesetID,
+			OldVariationValueID: *change.NewVariationValueID,
+			FeatureVersionID:    *change.FeatureVersionID,
+			KeyID:               *change.KeyID,
+			ServiceVersionID:    change.ServiceVersionID,
+		})
+	case db.ChangesetChangeTypeUpdate:
+		return tx.AddUpdateVariationValueChange(ctx, db.AddUpdateVariationValueChangeParams{
+			ChangesetID:         changesetID,
+			NewVariationValueID: restoredValueID,
+			OldVariationValueID: *change.NewVariationValueID,
+			FeatureVersionID:    *change.FeatureVersionID,
+			KeyID:               *change.KeyID,
+			ServiceVersionID:    change.ServiceVersionID,
+		})
+	case db.ChangesetChangeTypeDelete:
+		return tx.AddCreateVariationValueChange(ctx, db.AddCreateVariationValueChangeParams{
+			ChangesetID:         changesetID,
+			NewVariationValueID: restoredValueID,
+			FeatureVersionID:    *change.FeatureVersionID,
+			KeyID:               *change.KeyID,
+			ServiceVersionID:    change.ServiceVersionID,
+		})
+	}
+
+	return nil
+}
+
+func (s *Service) revertLinkChange(ctx context.Context, tx *db.Queries, changesetID uint, change db.GetChangesetChangesRow) error {
+	if change.Type == db.ChangesetChangeTypeCreate {
+		return tx.AddDeleteFeatureVersionServiceVersionChange(ctx, db.AddDeleteFeatureVersionServiceVersionChangeParams{
+			ChangesetID:                    changesetID,
+			FeatureVersionServiceVersionID: *change.FeatureVersionServiceVersionID,
+			FeatureVersionID:               *change.FeatureVersionID,
+			ServiceVersionID:               change.ServiceVersionID,
+		})
+	}
+
+	linkID, err := tx.CreateFeatureVersionServiceVersion(ctx, db.CreateFeatureVersionServiceVersionParams{
+		ServiceVersionID: change.ServiceVersionID,
+		FeatureVersionID: *change.FeatureVersionID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.AddCreateFeatureVersionServiceVersionChange(ctx, db.AddCreateFeatureVersionServiceVersionChangeParams{
+		ChangesetID:                    changesetID,
+		FeatureVersionServiceVersionID: linkID,
+		FeatureVersionID:               *change.FeatureVersionID,
+		ServiceVersionID:               change.ServiceVersionID,
+	})
+}
+
 func (s *Service) StashChangeset(ctx context.Context, changesetID uint) error {
 	changeset, err := s.getChangesetWithoutChanges(ctx, changesetID)
 	if err != nil {
@@ -655,7 +1191,7 @@ func (s *Service) StashChangeset(ctx context.Context, changesetID uint) error {
 			return err
 		}
 
-		return nil
+		return enqueueWebhooks(ctx, tx, db.WebhookEventTypeChangesetStashed, changeset.ID, user, nil)
 	})
 }
 
@@ -670,16 +1206,18 @@ func (s *Service) AddComment(ctx context.Context, changesetID uint, comment stri
 
 	user := s.currentUserAccessor.GetUser(ctx)
 
-	if err := s.queries.AddChangesetAction(ctx, db.AddChangesetActionParams{
-		ChangesetID: changesetID,
-		UserID:      user.ID,
-		Type:        db.ChangesetActionTypeComment,
-		Comment:     &comment,
-	}); err != nil {
-		return err
-	}
+	return s.unitOfWorkRunner.Run(ctx, func(tx *db.Queries) error {
+		if err := tx.AddChangesetAction(ctx, db.AddChangesetActionParams{
+			ChangesetID: changesetID,
+			UserID:      user.ID,
+			Type:        db.ChangesetActionTypeComment,
+			Comment:     &comment,
+		}); err != nil {
+			return err
+		}
 
-	return nil
+		return enqueueWebhooks(ctx, tx, db.WebhookEventTypeChangesetCommented, changesetID, user, &comment)
+	})
 }
 
 func (s *Service) GetChangesetChangesCount(ctx context.Context) (int, error) {
@@ -784,8 +1322,20 @@ func (s *Service) GetChangeHistory(ctx context.Context, filter GetChangeHistoryF
 		return core.PaginatedResult[ChangeHistoryItemDto]{}, err
 	}
 
+	revealSecrets := s.secretService.CanReveal(ctx)
+
 	items := make([]ChangeHistoryItemDto, len(changes))
 	for i, change := range changes {
+		newData, err := s.secretService.PresentOptional(change.ValueTypeKind.ValueTypeKind, change.NewVariationValueData, revealSecrets)
+		if err != nil {
+			return core.PaginatedResult[ChangeHistoryItemDto]{}, err
+		}
+
+		oldData, err := s.secretService.PresentOptional(change.ValueTypeKind.ValueTypeKind, change.OldVariationValueData, revealSecrets)
+		if err != nil {
+			return core.PaginatedResult[ChangeHistoryItemDto]{}, err
+		}
+
 		items[i] = ChangeHistoryItemDto{
 			ID:                    change.ID,
 			Type:                  change.Type,
@@ -805,9 +1355,9 @@ func (s *Service) GetChangeHistory(ctx context.Context, filter GetChangeHistoryF
 			KeyID:                 change.KeyID,
 			KeyName:               change.KeyName,
 			NewVariationValueID:   change.NewVariationValueID,
-			NewVariationValueData: change.NewVariationValueData,
+			NewVariationValueData: newData,
 			OldVariationValueID:   change.OldVariationValueID,
-			OldVariationValueData: change.OldVariationValueData,
+			OldVariationValueData: oldData,
 		}
 
 		if change.VariationContextID != nil {
@@ -0,0 +1,42 @@
+package changeset
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/necroskillz/config-service/auth"
+	"github.com/necroskillz/config-service/db"
+)
+
+// WebhookPayload is the body sent to webhooks. The name of the service the webhook belongs to is added when the deliveries are enqueued.
+type WebhookPayload struct {
+	Event       db.WebhookEventType `json:"event"`
+	ChangesetID uint                `json:"changesetId"`
+	UserID      uint                `json:"userId"`
+	UserName    string              `json:"userName"`
+	Comment     *string             `json:"comment,omitempty"`
+	OccurredAt  time.Time           `json:"occurredAt"`
+}
+
+// enqueueWebhooks queues a delivery for every enabled webhook subscribed to the event on a service the changeset touches.
+// It should run in the same transaction as the state change, so that events are only sent for committed state.
+func enqueueWebhooks(ctx context.Context, tx *db.Queries, event db.WebhookEventType, changesetID uint, user *auth.User, comment *string) error {
+	payload, err := json.Marshal(WebhookPayload{
+		Event:       event,
+		ChangesetID: changesetID,
+		UserID:      user.ID,
+		UserName:    user.Username,
+		Comment:     comment,
+		OccurredAt:  time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.EnqueueWebhookDeliveries(ctx, db.EnqueueWebhookDeliveriesParams{
+		ChangesetID: changesetID,
+		Event:       event,
+		Payload:     payload,
+	})
+}
@@ -20,11 +20,13 @@ const (
 	ConflictKindInconsistentFeatureVersion      ConflictKind = "inconsistent_feature_version"
 	ConflictKindInconsistentServiceVersion      ConflictKind = "inconsistent_service_version"
 	ConflictKindChangeInPublishedServiceVersion ConflictKind = "change_in_published_service_version"
+	ConflictKindConstraintViolated              ConflictKind = "constraint_violated"
 )
 
 type Conflict struct {
 	Kind              ConflictKind `json:"kind" validate:"required"`
 	ExistingValueData *string      `json:"existingValueData,omitempty"`
+	Message           *string      `json:"message,omitempty"`
 }
 
 type LinkKey struct {
@@ -38,6 +40,8 @@ type ConflictCheckerContext struct {
 	DeletedKeys         map[string]bool
 	LastFeatureVersions map[uint]int
 	LastServiceVersions map[uint]int
+	// ConstraintViolations are the messages of violated feature version constraints by change ID
+	ConstraintViolations map[uint]string
 }
 
 type ConflictCheckerFunc func(ctx ConflictCheckerContext) ConflictKind
@@ -62,13 +66,14 @@ func NewConflictDetector() *ConflictDetector {
 			deletedLinkChecker,
 			inconsistentFeatureVersionChecker,
 			inconsistentServiceVersionChecker,
+			constraintViolatedChecker,
 		},
 	}
 
 	return detector
 }
 
-func (c *ConflictDetector) DetectConflicts(raw []db.GetChangesetChangesRow, changes []ChangesetChange) int {
+func (c *ConflictDetector) DetectConflicts(raw []db.GetChangesetChangesRow, changes []ChangesetChange, constraintViolations map[uint]string) int {
 	deletedLinks := map[LinkKey]bool{}
 	deletedKeys := map[string]bool{}
 	lastFeatureVersions := map[uint]int{}
@@ -77,11 +82,12 @@ func (c *ConflictDetector) DetectConflicts(raw []db.GetChangesetChangesRow, chan
 
 	for i, change := range raw {
 		ctx := ConflictCheckerContext{
-			Change:              change,
-			DeletedLinks:        deletedLinks,
-			DeletedKeys:         deletedKeys,
-			LastFeatureVersions: lastFeatureVersions,
-			LastServiceVersions: lastServiceVersions,
+			Change:               change,
+			DeletedLinks:         deletedLinks,
+			DeletedKeys:          deletedKeys,
+			LastFeatureVersions:  lastFeatureVersions,
+			LastServiceVersions:  lastServiceVersions,
+			ConstraintViolations: constraintViolations,
 		}
 
 		conflict := c.detect(ctx)
@@ -108,7 +114,13 @@ func (c *ConflictDetector) detect(ctx ConflictCheckerContext) *Conflict {
 	for _, checker := range c.checkers {
 		ck := checker(ctx)
 		if ck != "" {
-			return &Conflict{Kind: ck, ExistingValueData: ctx.Change.ExistingValueData}
+			conflict := &Conflict{Kind: ck, ExistingValueData: ctx.Change.ExistingValueData}
+			if ck == ConflictKindConstraintViolated {
+				message := ctx.ConstraintViolations[ctx.Change.ID]
+				conflict.Message = &message
+			}
+
+			return conflict
 		}
 	}
 
@@ -221,3 +233,11 @@ func changeInPublishedServiceVersionChecker(ctx ConflictCheckerContext) Conflict
 
 	return ""
 }
+
+func constraintViolatedChecker(ctx ConflictCheckerContext) ConflictKind {
+	if _, ok := ctx.ConstraintViolations[ctx.Change.ID]; ok {
+		return ConflictKindConstraintViolated
+	}
+
+	return ""
+}
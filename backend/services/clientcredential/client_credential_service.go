@@ -0,0 +1,367 @@
+package clientcredential
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/necroskillz/config-service/auth"
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/core"
+	"github.com/necroskillz/config-service/services/validation"
+	"github.com/necroskillz/config-service/util/validator"
+)
+
+// Grant is what an authenticated configuration client is allowed to read
+type Grant struct {
+	ClientCredentialID uint
+	Name               string
+	Services           []string
+}
+
+// Allows returns true if the client is allowed to read configuration of the service
+func (g Grant) Allows(serviceName string) bool {
+	return slices.Contains(g.Services, serviceName)
+}
+
+// Authorize checks that all services in the service version specifiers (service:version) are allowed by the grant
+func (g Grant) Authorize(serviceVersions []string) error {
+	serviceVersionSpecifiers, err := core.ParseServiceVersionSpecifiers(serviceVersions)
+	if err != nil {
+		return err
+	}
+
+	for _, specifier := range serviceVersionSpecifiers {
+		if !g.Allows(specifier.Name) {
+			return core.NewServiceError(core.ErrorCodePermissionDenied, fmt.Sprintf("Client %s is not allowed to read configuration of service %s", g.Name, specifier.Name))
+		}
+	}
+
+	return nil
+}
+
+type Service struct {
+	queries             *db.Queries
+	unitOfWorkRunner    db.UnitOfWorkRunner
+	currentUserAccessor *auth.CurrentUserAccessor
+	validator           *validator.Validator
+	validationService   *validation.Service
+	coreService         *core.Service
+}
+
+func NewService(
+	queries *db.Queries,
+	unitOfWorkRunner db.UnitOfWorkRunner,
+	currentUserAccessor *auth.CurrentUserAccessor,
+	validator *validator.Validator,
+	validationService *validation.Service,
+	coreService *core.Service,
+) *Service {
+	return &Service{
+		queries:             queries,
+		unitOfWorkRunner:    unitOfWorkRunner,
+		currentUserAccessor: currentUserAccessor,
+		validator:           validator,
+		validationService:   validationService,
+		coreService:         coreService,
+	}
+}
+
+type ClientCredentialServiceDto struct {
+	ID   uint   `json:"id" validate:"required"`
+	Name string `json:"name" validate:"required"`
+}
+
+type ClientCredentialDto struct {
+	ID                 uint                         `json:"id" validate:"required"`
+	CreatedAt          time.Time                    `json:"createdAt" validate:"required"`
+	UpdatedAt          time.Time                    `json:"updatedAt" validate:"required"`
+	Name               string                       `json:"name" validate:"required"`
+	Kind               db.ClientCredentialKind      `json:"kind" validate:"required"`
+	KeyPrefix          *string                      `json:"keyPrefix"`
+	CertificateSubject *string                      `json:"certificateSubject"`
+	Services           []ClientCredentialServiceDto `json:"services" validate:"required"`
+}
+
+// CreatedClientCredentialDto contains the plain text API key, which is only returned when the credential is created
+type CreatedClientCredentialDto struct {
+	ID  uint    `json:"id" validate:"required"`
+	Key *string `json:"key"`
+}
+
+func toServiceDtos(serviceIDs []uint, serviceNames []string) []ClientCredentialServiceDto {
+	services := make([]ClientCredentialServiceDto, len(serviceIDs))
+	for i, serviceID := range serviceIDs {
+		services[i] = ClientCredentialServiceDto{
+			ID:   serviceID,
+			Name: serviceNames[i],
+		}
+	}
+
+	return services
+}
+
+func (s *Service) validateGlobalAdmin(ctx context.Context) error {
+	user := s.currentUserAccessor.GetUser(ctx)
+
+	if !user.IsGlobalAdmin {
+		return core.NewServiceError(core.ErrorCodePermissionDenied, "You are not authorized to manage client credentials")
+	}
+
+	return nil
+}
+
+func (s *Service) GetClientCredentials(ctx context.Context) ([]ClientCredentialDto, error) {
+	if err := s.validateGlobalAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	credentials, err := s.queries.GetClientCredentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ClientCredentialDto, len(credentials))
+	for i, credential := range credentials {
+		result[i] = ClientCredentialDto{
+			ID:                 credential.ID,
+			CreatedAt:          credential.CreatedAt,
+			UpdatedAt:          credential.UpdatedAt,
+			Name:               credential.Name,
+			Kind:               credential.Kind,
+			KeyPrefix:          credential.KeyPrefix,
+			CertificateSubject: credential.CertificateSubject,
+			Services:           toServiceDtos(credential.ServiceIds, credential.ServiceNames),
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Service) GetClientCredential(ctx context.Context, clientCredentialID uint) (ClientCredentialDto, error) {
+	if err := s.validateGlobalAdmin(ctx); err != nil {
+		return ClientCredentialDto{}, err
+	}
+
+	credential, err := s.queries.GetClientCredential(ctx, clientCredentialID)
+	if err != nil {
+		return ClientCredentialDto{}, core.NewDbError(err, "ClientCredential")
+	}
+
+	return ClientCredentialDto{
+		ID:                 credential.ID,
+		CreatedAt:          credential.CreatedAt,
+		UpdatedAt:          credential.UpdatedAt,
+		Name:               credential.Name,
+		Kind:               credential.Kind,
+		KeyPrefix:          credential.KeyPrefix,
+		CertificateSubject: credential.CertificateSubject,
+		Services:           toServiceDtos(credential.ServiceIds, credential.ServiceNames),
+	}, nil
+}
+
+func (s *Service) validateServices(ctx context.Context, serviceIDs []uint) error {
+	if len(serviceIDs) == 0 {
+		return core.NewServiceError(core.ErrorCodeInvalidInput, "At least one service is required")
+	}
+
+	for _, serviceID := range serviceIDs {
+		if _, err := s.coreService.GetService(ctx, serviceID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type CreateClientCredentialParams struct {
+	Name string
+	Kind db.ClientCredentialKind
+	// CertificateSubject is the common name of the client certificate, required for certificate credentials
+	CertificateSubject string
+	ServiceIDs         []uint
+}
+
+func (s *Service) validateCreateClientCredential(ctx context.Context, params CreateClientCredentialParams) error {
+	if err := s.validateGlobalAdmin(ctx); err != nil {
+		return err
+	}
+
+	if err := s.validator.
+		Validate(params.Name, "Name").Required().MaxLength(100).
+		Error(ctx); err != nil {
+		return err
+	}
+
+	switch params.Kind {
+	case db.ClientCredentialKindApiKey:
+	case db.ClientCredentialKindCertificate:
+		if err := s.validator.
+			Validate(params.CertificateSubject, "Certificate Subject").Required().MaxLength(255).
+			Error(ctx); err != nil {
+			return err
+		}
+	default:
+		return core.NewServiceError(core.ErrorCodeInvalidInput, "Kind must be api_key or certificate")
+	}
+
+	if taken, err := s.validationService.IsClientCredentialNameTaken(ctx, params.Name); err != nil {
+		return err
+	} else if taken {
+		return core.NewServiceError(core.ErrorCodeInvalidInput, "Client credential name already exists")
+	}
+
+	return s.validateServices(ctx, params.ServiceIDs)
+}
+
+func (s *Service) CreateClientCredential(ctx context.Context, params CreateClientCredentialParams) (CreatedClientCredentialDto, error) {
+	if err := s.validateCreateClientCredential(ctx, params); err != nil {
+		return CreatedClientCredentialDto{}, err
+	}
+
+	createParams := db.CreateClientCredentialParams{
+		Name: params.Name,
+		Kind: params.Kind,
+	}
+
+	var key *string
+	if params.Kind == db.ClientCredentialKindApiKey {
+		generatedKey, displayPrefix, err := auth.GenerateClientKey()
+		if err != nil {
+			return CreatedClientCredentialDto{}, core.NewServiceError(core.ErrorCodeUnexpectedError, "Failed to generate API key")
+		}
+
+		keyHash := auth.HashApiToken(generatedKey)
+		createParams.KeyHash = &keyHash
+		createParams.KeyPrefix = &displayPrefix
+		key = &generatedKey
+	} else {
+		createParams.CertificateSubject = &params.CertificateSubject
+	}
+
+	var clientCredentialID uint
+	err := s.unitOfWorkRunner.Run(ctx, func(tx *db.Queries) error {
+		var err error
+		clientCredentialID, err = tx.CreateClientCredential(ctx, createParams)
+		if err != nil {
+			return err
+		}
+
+		return tx.AddClientCredentialServices(ctx, db.AddClientCredentialServicesParams{
+			ClientCredentialID: clientCredentialID,
+			ServiceIds:         params.ServiceIDs,
+		})
+	})
+	if err != nil {
+		return CreatedClientCredentialDto{}, err
+	}
+
+	return CreatedClientCredentialDto{
+		ID:  clientCredentialID,
+		Key: key,
+	}, nil
+}
+
+type UpdateClientCredentialParams struct {
+	ClientCredentialID uint
+	Name               string
+	ServiceIDs         []uint
+}
+
+func (s *Service) validateUpdateClientCredential(ctx context.Context, params UpdateClientCredentialParams) error {
+	if err := s.validateGlobalAdmin(ctx); err != nil {
+		return err
+	}
+
+	credential, err := s.queries.GetClientCredential(ctx, params.ClientCredentialID)
+	if err != nil {
+		return core.NewDbError(err, "ClientCredential")
+	}
+
+	if err := s.validator.
+		Validate(params.Name, "Name").Required().MaxLength(100).
+		Error(ctx); err != nil {
+		return err
+	}
+
+	if credential.Name != params.Name {
+		if taken, err := s.validationService.IsClientCredentialNameTaken(ctx, params.Name); err != nil {
+			return err
+		} else if taken {
+			return core.NewServiceError(core.ErrorCodeInvalidInput, "Client credential name already exists")
+		}
+	}
+
+	return s.validateServices(ctx, params.ServiceIDs)
+}
+
+func (s *Service) UpdateClientCredential(ctx context.Context, params UpdateClientCredentialParams) error {
+	if err := s.validateUpdateClientCredential(ctx, params); err != nil {
+		return err
+	}
+
+	return s.unitOfWorkRunner.Run(ctx, func(tx *db.Queries) error {
+		if err := tx.UpdateClientCredential(ctx, db.UpdateClientCredentialParams{
+			ClientCredentialID: params.ClientCredentialID,
+			Name:               params.Name,
+		}); err != nil {
+			return err
+		}
+
+		if err := tx.DeleteClientCredentialServices(ctx, params.ClientCredentialID); err != nil {
+			return err
+		}
+
+		return tx.AddClientCredentialServices(ctx, db.AddClientCredentialServicesParams{
+			ClientCredentialID: params.ClientCredentialID,
+			ServiceIds:         params.ServiceIDs,
+		})
+	})
+}
+
+func (s *Service) DeleteClientCredential(ctx context.Context, clientCredentialID uint) error {
+	if err := s.validateGlobalAdmin(ctx); err != nil {
+		return err
+	}
+
+	if _, err := s.queries.GetClientCredential(ctx, clientCredentialID); err != nil {
+		return core.NewDbError(err, "ClientCredential")
+	}
+
+	return s.queries.DeleteClientCredential(ctx, clientCredentialID)
+}
+
+// AuthenticateApiKey returns the grant of the client credential the API key belongs to
+func (s *Service) AuthenticateApiKey(ctx context.Context, key string) (Grant, error) {
+	keyHash := auth.HashApiToken(key)
+
+	credential, err := s.queries.GetClientCredentialGrantByKeyHash(ctx, &keyHash)
+	if err != nil {
+		return Grant{}, core.NewDbError(err, "ClientCredential")
+	}
+
+	return Grant{
+		ClientCredentialID: credential.ID,
+		Name:               credential.Name,
+		Services:           credential.ServiceNames,
+	}, nil
+}
+
+// AuthenticateCertificate returns the grant of the client credential registered for the certificate subject
+func (s *Service) AuthenticateCertificate(ctx context.Context, subject string) (Grant, error) {
+	if subject == "" {
+		return Grant{}, core.NewServiceError(core.ErrorCodeRecordNotFound, "ClientCredential not found")
+	}
+
+	credential, err := s.queries.GetClientCredentialGrantByCertificateSubject(ctx, &subject)
+	if err != nil {
+		return Grant{}, core.NewDbError(err, "ClientCredential")
+	}
+
+	return Grant{
+		ClientCredentialID: credential.ID,
+		Name:               credential.Name,
+		Services:           credential.ServiceNames,
+	}, nil
+}
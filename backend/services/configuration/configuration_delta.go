@@ -0,0 +1,235 @@
+package configuration
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/necroskillz/config-service/services/core"
+)
+
+type ConfigurationDeltaDto struct {
+	FromChangesetID uint                           `json:"fromChangesetId" validate:"required"`
+	ChangesetID     uint                           `json:"changesetId" validate:"required"`
+	AppliedAt       *time.Time                     `json:"appliedAt,omitempty"`
+	Features        []FeatureConfigurationDeltaDto `json:"features" validate:"required"`
+	RemovedFeatures []string                       `json:"removedFeatures" validate:"required"`
+}
+
+type FeatureConfigurationDeltaDto struct {
+	Name        string                     `json:"name" validate:"required"`
+	Keys        []KeyConfigurationDeltaDto `json:"keys" validate:"required"`
+	RemovedKeys []string                   `json:"removedKeys" validate:"required"`
+}
+
+type KeyConfigurationDeltaDto struct {
+	Name          string                  `json:"name" validate:"required"`
+	DataType      string                  `json:"dataType" validate:"required"`
+	AllowedValues []string                `json:"allowedValues,omitempty"`
+	Values        []ValueConfigurationDto `json:"values" validate:"required"`
+	RemovedValues []RemovedValueDto       `json:"removedValues" validate:"required"`
+}
+
+// RemovedValueDto identifies a removed value the same way values are identified in the delta
+type RemovedValueDto struct {
+	Variation map[string]string `json:"variation,omitempty"`
+	Rank      int               `json:"rank" validate:"required"`
+	// Rollout is set if the removed value is the rolled out value of the variation
+	Rollout bool `json:"rollout,omitempty"`
+}
+
+type GetConfigurationDeltaParams struct {
+	ServiceVersionSpecifiers []core.ServiceVersionSpecifier
+	FromChangesetID          uint
+	ToChangesetID            *uint
+	Mode                     string
+	Variation                map[uint]string
+	RevealSecrets            bool
+	// OmitRedactedSecrets leaves out secret keys instead of redacting them when secrets are not revealed
+	OmitRedactedSecrets bool
+}
+
+func variationKey(variation map[string]string) string {
+	parts := make([]string, 0, len(variation))
+	for _, property := range slices.Sorted(maps.Keys(variation)) {
+		parts = append(parts, property+"="+variation[property])
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// valueKey identifies the value by its unresolved variation, rank and whether it is rolled out. Values stored with
+// different variations can have the same unresolved variation, they have a different rank. A rolled out value can share
+// the variation and rank with the value it falls back to.
+func valueKey(variation map[string]string, rank int, rollout bool) string {
+	key := strconv.Itoa(rank) + "|" + variationKey(variation)
+	if rollout {
+		return key + "|rollout"
+	}
+
+	return key
+}
+
+func valueKeyOf(value ValueConfigurationDto) string {
+	return valueKey(value.Variation, value.Rank, value.Rollout != nil)
+}
+
+// diffKey returns the added or changed values and the removed values, identified by valueKey
+func diffKey(from KeyConfigurationDto, to KeyConfigurationDto) ([]ValueConfigurationDto, []RemovedValueDto) {
+	fromValues := make(map[string]ValueConfigurationDto, len(from.Values))
+	for _, value := range from.Values {
+		fromValues[valueKeyOf(value)] = value
+	}
+
+	changed := []ValueConfigurationDto{}
+	visited := make(map[string]bool, len(to.Values))
+
+	for _, value := range to.Values {
+		key := valueKeyOf(value)
+		visited[key] = true
+
+		if fromValue, ok := fromValues[key]; !ok || fromValue.Data != value.Data || !fromValue.Rollout.Equal(value.Rollout) {
+			changed = append(changed, value)
+		}
+	}
+
+	removed := []RemovedValueDto{}
+	for _, value := range from.Values {
+		if visited[valueKeyOf(value)] {
+			continue
+		}
+
+		removed = append(removed, RemovedValueDto{Variation: value.Variation, Rank: value.Rank, Rollout: value.Rollout != nil})
+	}
+
+	return changed, removed
+}
+
+func diffFeature(from FeatureConfigurationDto, to FeatureConfigurationDto) FeatureConfigurationDeltaDto {
+	fromKeys := make(map[string]KeyConfigurationDto, len(from.Keys))
+	for _, key := range from.Keys {
+		fromKeys[key.Name] = key
+	}
+
+	delta := FeatureConfigurationDeltaDto{
+		Name:        to.Name,
+		Keys:        []KeyConfigurationDeltaDto{},
+		RemovedKeys: []string{},
+	}
+	visited := make(map[string]bool, len(to.Keys))
+
+	for _, key := range to.Keys {
+		visited[key.Name] = true
+
+		fromKey, ok := fromKeys[key.Name]
+		if !ok || fromKey.DataType != key.DataType {
+			// a key with a different data type is a different key that happens to have the same name, so it is sent as removed and added again
+			if ok {
+				delta.RemovedKeys = append(delta.RemovedKeys, key.Name)
+			}
+
+			delta.Keys = append(delta.Keys, KeyConfigurationDeltaDto{
+				Name:          key.Name,
+				DataType:      key.DataType,
+				AllowedValues: key.AllowedValues,
+				Values:        key.Values,
+				RemovedValues: []RemovedValueDto{},
+			})
+
+			continue
+		}
+
+		values, removedValues := diffKey(fromKey, key)
+		if len(values) > 0 || len(removedValues) > 0 {
+			delta.Keys = append(delta.Keys, KeyConfigurationDeltaDto{
+				Name:          key.Name,
+				DataType:      key.DataType,
+				AllowedValues: key.AllowedValues,
+				Values:        values,
+				RemovedValues: removedValues,
+			})
+		}
+	}
+
+	for _, key := range from.Keys {
+		if !visited[key.Name] {
+			delta.RemovedKeys = append(delta.RemovedKeys, key.Name)
+		}
+	}
+
+	return delta
+}
+
+// GetConfigurationDelta returns only the keys and values that were added, changed or removed between the two changesets.
+// If ToChangesetID is not set, the delta is computed against the last applied changeset.
+func (s *Service) GetConfigurationDelta(ctx context.Context, params GetConfigurationDeltaParams) (ConfigurationDeltaDto, error) {
+	// values are compared as they are stored, secret values are presented only in the delta
+	from, err := s.getConfiguration(ctx, GetConfigurationParams{
+		ServiceVersionSpecifiers: params.ServiceVersionSpecifiers,
+		ChangesetID:              &params.FromChangesetID,
+		Mode:                     params.Mode,
+		Variation:                params.Variation,
+	})
+	if err != nil {
+		return ConfigurationDeltaDto{}, err
+	}
+
+	to, err := s.getConfiguration(ctx, GetConfigurationParams{
+		ServiceVersionSpecifiers: params.ServiceVersionSpecifiers,
+		ChangesetID:              params.ToChangesetID,
+		Mode:                     params.Mode,
+		Variation:                params.Variation,
+	})
+	if err != nil {
+		return ConfigurationDeltaDto{}, err
+	}
+
+	fromFeatures := make(map[string]FeatureConfigurationDto, len(from.Features))
+	for _, feature := range from.Features {
+		fromFeatures[feature.Name] = feature
+	}
+
+	delta := ConfigurationDeltaDto{
+		FromChangesetID: from.ChangesetID,
+		ChangesetID:     to.ChangesetID,
+		AppliedAt:       to.AppliedAt,
+		Features:        []FeatureConfigurationDeltaDto{},
+		RemovedFeatures: []string{},
+	}
+	visited := make(map[string]bool, len(to.Features))
+
+	for _, feature := range to.Features {
+		visited[feature.Name] = true
+
+		featureDelta := diffFeature(fromFeatures[feature.Name], feature)
+		keys := make([]KeyConfigurationDeltaDto, 0, len(featureDelta.Keys))
+		for _, key := range featureDelta.Keys {
+			// a key that changed its data type to secret is still sent as removed
+			if omitKey(key.DataType, params.RevealSecrets, params.OmitRedactedSecrets) {
+				continue
+			}
+
+			if err := s.presentValues(key.DataType, key.Values, params.RevealSecrets); err != nil {
+				return ConfigurationDeltaDto{}, err
+			}
+
+			keys = append(keys, key)
+		}
+		featureDelta.Keys = keys
+
+		if len(featureDelta.Keys) > 0 || len(featureDelta.RemovedKeys) > 0 {
+			delta.Features = append(delta.Features, featureDelta)
+		}
+	}
+
+	for _, feature := range from.Features {
+		if !visited[feature.Name] {
+			delta.RemovedFeatures = append(delta.RemovedFeatures, feature.Name)
+		}
+	}
+
+	return delta, nil
+}
@@ -0,0 +1,76 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/necroskillz/config-service/services/core"
+	"github.com/necroskillz/config-service/util/test"
+	"gotest.tools/v3/assert"
+)
+
+func TestConfigurationDelta(t *testing.T) {
+	t.Run("DiffKey", func(t *testing.T) {
+		rollout := &core.Rollout{Percentage: 10, Attribute: "user_id"}
+
+		type testCase struct {
+			from          []ValueConfigurationDto
+			to            []ValueConfigurationDto
+			expectValues  []ValueConfigurationDto
+			expectRemoved []RemovedValueDto
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			values, removed := diffKey(KeyConfigurationDto{Values: tc.from}, KeyConfigurationDto{Values: tc.to})
+
+			assert.DeepEqual(t, values, tc.expectValues)
+			assert.DeepEqual(t, removed, tc.expectRemoved)
+		}
+
+		testCases := map[string]testCase{
+			"unchanged": {
+				from:          []ValueConfigurationDto{{Data: "a"}, {Data: "b", Variation: map[string]string{"env": "prod"}, Rank: 1}},
+				to:            []ValueConfigurationDto{{Data: "a"}, {Data: "b", Variation: map[string]string{"env": "prod"}, Rank: 1}},
+				expectValues:  []ValueConfigurationDto{},
+				expectRemoved: []RemovedValueDto{},
+			},
+			"changed and removed": {
+				from:          []ValueConfigurationDto{{Data: "a"}, {Data: "b", Variation: map[string]string{"env": "prod"}, Rank: 1}},
+				to:            []ValueConfigurationDto{{Data: "c"}},
+				expectValues:  []ValueConfigurationDto{{Data: "c"}},
+				expectRemoved: []RemovedValueDto{{Variation: map[string]string{"env": "prod"}, Rank: 1}},
+			},
+			"rolled out value separate from its fallback": {
+				from:          []ValueConfigurationDto{{Data: "a"}, {Data: "b", Rollout: rollout}},
+				to:            []ValueConfigurationDto{{Data: "a"}, {Data: "c", Rollout: rollout}},
+				expectValues:  []ValueConfigurationDto{{Data: "c", Rollout: rollout}},
+				expectRemoved: []RemovedValueDto{},
+			},
+			"rolled out value removed": {
+				from:          []ValueConfigurationDto{{Data: "a"}, {Data: "b", Rollout: rollout}},
+				to:            []ValueConfigurationDto{{Data: "a"}},
+				expectValues:  []ValueConfigurationDto{},
+				expectRemoved: []RemovedValueDto{{Rollout: true}},
+			},
+			"rolled out values with the same resolved variation": {
+				from:          []ValueConfigurationDto{{Data: "a"}, {Data: "env", Rank: 1, Rollout: rollout}, {Data: "domain", Rank: 3, Rollout: rollout}},
+				to:            []ValueConfigurationDto{{Data: "a"}, {Data: "env", Rank: 1, Rollout: rollout}, {Data: "domain changed", Rank: 3, Rollout: rollout}},
+				expectValues:  []ValueConfigurationDto{{Data: "domain changed", Rank: 3, Rollout: rollout}},
+				expectRemoved: []RemovedValueDto{},
+			},
+			"one of rolled out values with the same resolved variation removed": {
+				from:          []ValueConfigurationDto{{Data: "a"}, {Data: "env", Rank: 1, Rollout: rollout}, {Data: "domain", Rank: 3, Rollout: rollout}},
+				to:            []ValueConfigurationDto{{Data: "a"}, {Data: "domain", Rank: 3, Rollout: rollout}},
+				expectValues:  []ValueConfigurationDto{},
+				expectRemoved: []RemovedValueDto{{Rank: 1, Rollout: true}},
+			},
+			"values with the same unresolved variation": {
+				from:          []ValueConfigurationDto{{Data: "a"}, {Data: "domain", Variation: map[string]string{"domain": "com"}, Rank: 2}, {Data: "env domain", Variation: map[string]string{"domain": "com"}, Rank: 3}},
+				to:            []ValueConfigurationDto{{Data: "a"}, {Data: "domain", Variation: map[string]string{"domain": "com"}, Rank: 2}},
+				expectValues:  []ValueConfigurationDto{},
+				expectRemoved: []RemovedValueDto{{Variation: map[string]string{"domain": "com"}, Rank: 3}},
+			},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+}
@@ -0,0 +1,278 @@
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/core"
+	"github.com/necroskillz/config-service/util/jsondiff"
+)
+
+type DiffKind string
+
+const (
+	DiffKindAdded   DiffKind = "added"
+	DiffKindRemoved DiffKind = "removed"
+	DiffKindChanged DiffKind = "changed"
+)
+
+type ConfigurationDiffDto struct {
+	FromChangesetID uint             `json:"fromChangesetId" validate:"required"`
+	FromAppliedAt   *time.Time       `json:"fromAppliedAt,omitempty"`
+	ToChangesetID   uint             `json:"toChangesetId" validate:"required"`
+	ToAppliedAt     *time.Time       `json:"toAppliedAt,omitempty"`
+	Features        []FeatureDiffDto `json:"features" validate:"required"`
+}
+
+type FeatureDiffDto struct {
+	Name string       `json:"name" validate:"required"`
+	Kind DiffKind     `json:"kind" validate:"required"`
+	Keys []KeyDiffDto `json:"keys" validate:"required"`
+}
+
+type KeyDiffDto struct {
+	Name     string         `json:"name" validate:"required"`
+	Kind     DiffKind       `json:"kind" validate:"required"`
+	DataType string         `json:"dataType" validate:"required"`
+	Values   []ValueDiffDto `json:"values" validate:"required"`
+}
+
+type ValueDiffDto struct {
+	Kind       DiffKind          `json:"kind" validate:"required"`
+	Variation  map[string]string `json:"variation,omitempty"`
+	OldData    *string           `json:"oldData,omitempty"`
+	NewData    *string           `json:"newData,omitempty"`
+	OldRollout *core.Rollout     `json:"oldRollout,omitempty"`
+	NewRollout *core.Rollout     `json:"newRollout,omitempty"`
+	// JsonChanges are the structural changes of a changed json value
+	JsonChanges []jsondiff.Change `json:"jsonChanges,omitempty"`
+}
+
+// ConfigurationPoint identifies a configuration by changeset or by the last changeset applied at or before a time
+type ConfigurationPoint struct {
+	ChangesetID *uint
+	AsOf        *time.Time
+}
+
+type GetConfigurationDiffParams struct {
+	ServiceVersionSpecifiers []core.ServiceVersionSpecifier
+	From                     ConfigurationPoint
+	// To defaults to the last applied changeset
+	To            ConfigurationPoint
+	Mode          string
+	Variation     map[uint]string
+	RevealSecrets bool
+}
+
+func diffValues(dataType string, from []ValueConfigurationDto, to []ValueConfigurationDto) ([]ValueDiffDto, error) {
+	fromValues := make(map[string]ValueConfigurationDto, len(from))
+	for _, value := range from {
+		fromValues[valueKeyOf(value)] = value
+	}
+
+	diffs := []ValueDiffDto{}
+	visited := make(map[string]bool, len(to))
+
+	for _, value := range to {
+		key := valueKeyOf(value)
+		visited[key] = true
+
+		fromValue, ok := fromValues[key]
+		if !ok {
+			diffs = append(diffs, ValueDiffDto{
+				Kind:       DiffKindAdded,
+				Variation:  value.Variation,
+				NewData:    &value.Data,
+				NewRollout: value.Rollout,
+			})
+
+			continue
+		}
+
+		rolloutChanged := !fromValue.Rollout.Equal(value.Rollout)
+		if fromValue.Data == value.Data && !rolloutChanged {
+			continue
+		}
+
+		valueDiff := ValueDiffDto{
+			Kind:       DiffKindChanged,
+			Variation:  value.Variation,
+			OldData:    &fromValue.Data,
+			NewData:    &value.Data,
+			OldRollout: fromValue.Rollout,
+			NewRollout: value.Rollout,
+		}
+
+		if dataType == "json" {
+			var oldJson, newJson any
+			if err := json.Unmarshal([]byte(fromValue.Data), &oldJson); err != nil {
+				return nil, err
+			}
+
+			if err := json.Unmarshal([]byte(value.Data), &newJson); err != nil {
+				return nil, err
+			}
+
+			valueDiff.JsonChanges = jsondiff.Diff(oldJson, newJson)
+			if len(valueDiff.JsonChanges) == 0 && !rolloutChanged {
+				// the same JSON formatted differently
+				continue
+			}
+		}
+
+		diffs = append(diffs, valueDiff)
+	}
+
+	for _, value := range from {
+		if !visited[valueKeyOf(value)] {
+			diffs = append(diffs, ValueDiffDto{
+				Kind:       DiffKindRemoved,
+				Variation:  value.Variation,
+				OldData:    &value.Data,
+				OldRollout: value.Rollout,
+			})
+		}
+	}
+
+	return diffs, nil
+}
+
+func diffFeatureKeys(from FeatureConfigurationDto, to FeatureConfigurationDto) ([]KeyDiffDto, error) {
+	fromKeys := make(map[string]KeyConfigurationDto, len(from.Keys))
+	for _, key := range from.Keys {
+		fromKeys[key.Name] = key
+	}
+
+	diffs := []KeyDiffDto{}
+	visited := make(map[string]bool, len(to.Keys))
+
+	for _, key := range to.Keys {
+		visited[key.Name] = true
+
+		fromKey, ok := fromKeys[key.Name]
+		if ok && fromKey.DataType != key.DataType {
+			// a key with a different data type is a different key that happens to have the same name
+			removedValues, err := diffValues(fromKey.DataType, fromKey.Values, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			diffs = append(diffs, KeyDiffDto{Name: fromKey.Name, Kind: DiffKindRemoved, DataType: fromKey.DataType, Values: removedValues})
+			fromKey, ok = KeyConfigurationDto{}, false
+		}
+
+		values, err := diffValues(key.DataType, fromKey.Values, key.Values)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			diffs = append(diffs, KeyDiffDto{Name: key.Name, Kind: DiffKindAdded, DataType: key.DataType, Values: values})
+		} else if len(values) > 0 {
+			diffs = append(diffs, KeyDiffDto{Name: key.Name, Kind: DiffKindChanged, DataType: key.DataType, Values: values})
+		}
+	}
+
+	for _, key := range from.Keys {
+		if !visited[key.Name] {
+			values, err := diffValues(key.DataType, key.Values, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			diffs = append(diffs, KeyDiffDto{Name: key.Name, Kind: DiffKindRemoved, DataType: key.DataType, Values: values})
+		}
+	}
+
+	return diffs, nil
+}
+
+// GetConfigurationDiff returns the added, removed and changed values of every feature and key between two configurations
+func (s *Service) GetConfigurationDiff(ctx context.Context, params GetConfigurationDiffParams) (ConfigurationDiffDto, error) {
+	if params.From.ChangesetID == nil && params.From.AsOf == nil {
+		return ConfigurationDiffDto{}, core.NewServiceError(core.ErrorCodeInvalidInput, "Changeset ID or time to compute the diff from is required")
+	}
+
+	// values are compared as they are stored, secret values are presented only in the diff
+	from, err := s.getConfiguration(ctx, GetConfigurationParams{
+		ServiceVersionSpecifiers: params.ServiceVersionSpecifiers,
+		ChangesetID:              params.From.ChangesetID,
+		AsOf:                     params.From.AsOf,
+		Mode:                     params.Mode,
+		Variation:                params.Variation,
+	})
+	if err != nil {
+		return ConfigurationDiffDto{}, err
+	}
+
+	to, err := s.getConfiguration(ctx, GetConfigurationParams{
+		ServiceVersionSpecifiers: params.ServiceVersionSpecifiers,
+		ChangesetID:              params.To.ChangesetID,
+		AsOf:                     params.To.AsOf,
+		Mode:                     params.Mode,
+		Variation:                params.Variation,
+	})
+	if err != nil {
+		return ConfigurationDiffDto{}, err
+	}
+
+	fromFeatures := make(map[string]FeatureConfigurationDto, len(from.Features))
+	for _, feature := range from.Features {
+		fromFeatures[feature.Name] = feature
+	}
+
+	diff := ConfigurationDiffDto{
+		FromChangesetID: from.ChangesetID,
+		FromAppliedAt:   from.AppliedAt,
+		ToChangesetID:   to.ChangesetID,
+		ToAppliedAt:     to.AppliedAt,
+		Features:        []FeatureDiffDto{},
+	}
+	visited := make(map[string]bool, len(to.Features))
+
+	for _, feature := range to.Features {
+		visited[feature.Name] = true
+
+		fromFeature, ok := fromFeatures[feature.Name]
+
+		keys, err := diffFeatureKeys(fromFeature, feature)
+		if err != nil {
+			return ConfigurationDiffDto{}, err
+		}
+
+		if !ok {
+			diff.Features = append(diff.Features, FeatureDiffDto{Name: feature.Name, Kind: DiffKindAdded, Keys: keys})
+		} else if len(keys) > 0 {
+			diff.Features = append(diff.Features, FeatureDiffDto{Name: feature.Name, Kind: DiffKindChanged, Keys: keys})
+		}
+	}
+
+	for _, feature := range from.Features {
+		if !visited[feature.Name] {
+			keys, err := diffFeatureKeys(feature, FeatureConfigurationDto{})
+			if err != nil {
+				return ConfigurationDiffDto{}, err
+			}
+
+			diff.Features = append(diff.Features, FeatureDiffDto{Name: feature.Name, Kind: DiffKindRemoved, Keys: keys})
+		}
+	}
+
+	for _, feature := range diff.Features {
+		for _, key := range feature.Keys {
+			for i, value := range key.Values {
+				if key.Values[i].OldData, err = s.secretService.PresentOptional(db.ValueTypeKind(key.DataType), value.OldData, params.RevealSecrets); err != nil {
+					return ConfigurationDiffDto{}, err
+				}
+
+				if key.Values[i].NewData, err = s.secretService.PresentOptional(db.ValueTypeKind(key.DataType), value.NewData, params.RevealSecrets); err != nil {
+					return ConfigurationDiffDto{}, err
+				}
+			}
+		}
+	}
+
+	return diff, nil
+}
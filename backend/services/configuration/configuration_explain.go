@@ -0,0 +1,211 @@
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/core"
+	"github.com/necroskillz/config-service/util/jsonmerge"
+	"github.com/necroskillz/config-service/util/ptr"
+)
+
+type ExplainCandidateDto struct {
+	Data      string            `json:"data" validate:"required"`
+	Variation map[string]string `json:"variation,omitempty"`
+	Rank      int               `json:"rank" validate:"required"`
+	// Rollout is set when the candidate applies only to a percentage of the subjects. Rolled out candidates are never
+	// selected, because whether they apply is decided by the clients for each subject.
+	Rollout *core.Rollout `json:"rollout,omitempty"`
+	Matched bool          `json:"matched" validate:"required"`
+	// ParentMatches are the properties that matched because the requested value is a parent of the candidate value
+	ParentMatches []string `json:"parentMatches" validate:"required"`
+	// Unresolved are the properties of the candidate variation that are not in the requested variation
+	Unresolved map[string]string `json:"unresolved,omitempty"`
+	Selected   bool              `json:"selected" validate:"required"`
+}
+
+type ExplainValueDto struct {
+	ChangesetID uint              `json:"changesetId" validate:"required"`
+	AppliedAt   *time.Time        `json:"appliedAt,omitempty"`
+	Feature     string            `json:"feature" validate:"required"`
+	Key         string            `json:"key" validate:"required"`
+	DataType    string            `json:"dataType" validate:"required"`
+	Variation   map[string]string `json:"variation" validate:"required"`
+	// Value is the resolved value, nil if no candidate matched
+	Value *string `json:"value"`
+	// Candidates are all values of the key ordered by rank from the highest
+	Candidates []ExplainCandidateDto `json:"candidates" validate:"required"`
+	// MergeOrder are the indexes of the candidates in the order they are merged into the resolved value of a json key
+	MergeOrder []int `json:"mergeOrder,omitempty"`
+}
+
+type ExplainValueParams struct {
+	ServiceVersionSpecifiers []core.ServiceVersionSpecifier
+	ChangesetID              *uint
+	AsOf                     *time.Time
+	Mode                     string
+	Feature                  string
+	Key                      string
+	Variation                map[uint]string
+	RevealSecrets            bool
+}
+
+// ExplainValue returns the value of the key resolved for the variation together with all candidate values,
+// their rank and whether they matched the variation
+func (s *Service) ExplainValue(ctx context.Context, params ExplainValueParams) (ExplainValueDto, error) {
+	variationHierarchy, err := s.variationHierarchyService.GetVariationHierarchy(ctx)
+	if err != nil {
+		return ExplainValueDto{}, err
+	}
+
+	configuration, err := s.getConfigurationRows(ctx, GetConfigurationParams{
+		ServiceVersionSpecifiers: params.ServiceVersionSpecifiers,
+		ChangesetID:              params.ChangesetID,
+		AsOf:                     params.AsOf,
+		Mode:                     params.Mode,
+	})
+	if err != nil {
+		return ExplainValueDto{}, err
+	}
+
+	variation, err := variationHierarchy.GetVariationStringMap(params.Variation)
+	if err != nil {
+		return ExplainValueDto{}, err
+	}
+
+	explanation := ExplainValueDto{
+		ChangesetID: configuration.ChangesetID,
+		AppliedAt:   &configuration.Timestamp,
+		Feature:     params.Feature,
+		Key:         params.Key,
+		Variation:   variation,
+		Candidates:  []ExplainCandidateDto{},
+	}
+	keyFound := false
+
+	for _, value := range configuration.Rows {
+		if value.FeatureName != params.Feature || value.KeyName != params.Key {
+			continue
+		}
+
+		keyFound = true
+		explanation.DataType = string(value.ValueType)
+
+		valueVariation, err := s.variationContextService.GetVariationContextValues(ctx, value.VariationContextID)
+		if err != nil {
+			return ExplainValueDto{}, err
+		}
+
+		match, unresolved, err := variationHierarchy.Filter(valueVariation, params.Variation)
+		if err != nil {
+			return ExplainValueDto{}, err
+		}
+
+		rank, err := variationHierarchy.GetRank(value.ServiceTypeID, valueVariation)
+		if err != nil {
+			return ExplainValueDto{}, err
+		}
+
+		candidate := ExplainCandidateDto{
+			Data:          value.Data,
+			Rank:          rank,
+			Rollout:       core.NewRollout(value.RolloutPercentage, value.RolloutAttribute),
+			Matched:       match && len(unresolved) == 0,
+			ParentMatches: []string{},
+		}
+
+		candidate.Variation, err = variationHierarchy.GetVariationStringMap(valueVariation)
+		if err != nil {
+			return ExplainValueDto{}, err
+		}
+
+		if match {
+			for propertyID, propertyValue := range valueVariation {
+				if filterValue, ok := params.Variation[propertyID]; ok && filterValue != propertyValue {
+					property, err := variationHierarchy.GetProperty(propertyID)
+					if err != nil {
+						return ExplainValueDto{}, err
+					}
+
+					candidate.ParentMatches = append(candidate.ParentMatches, property.Name)
+				}
+			}
+
+			slices.Sort(candidate.ParentMatches)
+
+			if len(unresolved) > 0 {
+				candidate.Unresolved, err = variationHierarchy.GetVariationStringMap(unresolved)
+				if err != nil {
+					return ExplainValueDto{}, err
+				}
+			}
+		}
+
+		explanation.Candidates = append(explanation.Candidates, candidate)
+	}
+
+	if !keyFound {
+		return ExplainValueDto{}, core.NewServiceError(core.ErrorCodeRecordNotFound, fmt.Sprintf("Key %s of feature %s not found in the configuration", params.Key, params.Feature))
+	}
+
+	slices.SortStableFunc(explanation.Candidates, func(a, b ExplainCandidateDto) int {
+		return b.Rank - a.Rank
+	})
+
+	if explanation.DataType == "json" {
+		// matched values are merged from the lowest rank, so more specific values override less specific ones
+		var merged any
+		for i, candidate := range slices.Backward(explanation.Candidates) {
+			if !candidate.Matched || candidate.Rollout != nil {
+				continue
+			}
+
+			var jsonData any
+			if err := json.Unmarshal([]byte(candidate.Data), &jsonData); err != nil {
+				return ExplainValueDto{}, err
+			}
+
+			if len(explanation.MergeOrder) == 0 {
+				merged = jsonData
+			} else {
+				merged = jsonmerge.Merge(merged, jsonData)
+			}
+
+			explanation.MergeOrder = append(explanation.MergeOrder, i)
+			explanation.Candidates[i].Selected = true
+		}
+
+		if len(explanation.MergeOrder) > 0 {
+			jsonString, err := json.Marshal(merged)
+			if err != nil {
+				return ExplainValueDto{}, err
+			}
+
+			explanation.Value = ptr.To(string(jsonString))
+		}
+	} else {
+		for i, candidate := range explanation.Candidates {
+			if candidate.Matched && candidate.Rollout == nil {
+				explanation.Value = &candidate.Data
+				explanation.Candidates[i].Selected = true
+				break
+			}
+		}
+	}
+
+	for i, candidate := range explanation.Candidates {
+		if explanation.Candidates[i].Data, err = s.secretService.Present(db.ValueTypeKind(explanation.DataType), candidate.Data, params.RevealSecrets); err != nil {
+			return ExplainValueDto{}, err
+		}
+	}
+
+	if explanation.Value, err = s.secretService.PresentOptional(db.ValueTypeKind(explanation.DataType), explanation.Value, params.RevealSecrets); err != nil {
+		return ExplainValueDto{}, err
+	}
+
+	return explanation, nil
+}
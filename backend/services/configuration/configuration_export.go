@@ -0,0 +1,307 @@
+package configuration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/core"
+	"gopkg.in/yaml.v3"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatYaml       ExportFormat = "yaml"
+	ExportFormatEnv        ExportFormat = "env"
+	ExportFormatProperties ExportFormat = "properties"
+	ExportFormatJson       ExportFormat = "json"
+)
+
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportFormatYaml:
+		return "application/yaml"
+	case ExportFormatJson:
+		return "application/json"
+	default:
+		return "text/plain; charset=UTF-8"
+	}
+}
+
+type ExportConfigurationParams struct {
+	ServiceVersionSpecifiers []core.ServiceVersionSpecifier
+	ChangesetID              *uint
+	AsOf                     *time.Time
+	Mode                     string
+	Variation                map[uint]string
+	Format                   ExportFormat
+	RevealSecrets            bool
+}
+
+type exportEntry struct {
+	// Name is the feature and key name in format Feature.Key
+	Name     string
+	DataType string
+	Data     string
+}
+
+// typedValue converts the data to the go type matching its data type, so it is encoded as a number, boolean, list or nested JSON
+func (e exportEntry) typedValue() (any, error) {
+	switch e.DataType {
+	case "integer":
+		return strconv.ParseInt(e.Data, 10, 64)
+	case "decimal":
+		return strconv.ParseFloat(e.Data, 64)
+	case "boolean":
+		return strconv.ParseBool(e.Data)
+	case "json":
+		var value any
+		if err := json.Unmarshal([]byte(e.Data), &value); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	case "string_list":
+		var value []string
+		if err := json.Unmarshal([]byte(e.Data), &value); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	case "integer_list":
+		var value []int64
+		if err := json.Unmarshal([]byte(e.Data), &value); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	default:
+		return e.Data, nil
+	}
+}
+
+// ExportConfiguration returns the resolved configuration encoded in the format, with one entry per key named Feature.Key.
+// Exported files are used as the actual configuration, so the export fails if it contains secrets the caller cannot reveal.
+func (s *Service) ExportConfiguration(ctx context.Context, params ExportConfigurationParams) ([]byte, error) {
+	if !slices.Contains([]ExportFormat{ExportFormatYaml, ExportFormatEnv, ExportFormatProperties, ExportFormatJson}, params.Format) {
+		return nil, core.NewServiceError(core.ErrorCodeInvalidInput, fmt.Sprintf("Unsupported export format %s, expected one of yaml, env, properties, json", params.Format))
+	}
+
+	configuration, err := s.GetConfiguration(ctx, GetConfigurationParams{
+		ServiceVersionSpecifiers: params.ServiceVersionSpecifiers,
+		ChangesetID:              params.ChangesetID,
+		AsOf:                     params.AsOf,
+		Mode:                     params.Mode,
+		Resolve:                  true,
+		Variation:                params.Variation,
+		RevealSecrets:            params.RevealSecrets,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []exportEntry{}
+	for _, feature := range configuration.Features {
+		for _, key := range feature.Keys {
+			if !params.RevealSecrets && db.ValueTypeKind(key.DataType) == db.ValueTypeKindSecret {
+				return nil, core.NewServiceError(core.ErrorCodePermissionDenied, fmt.Sprintf("Key %s.%s is secret and you are not allowed to reveal secrets", feature.Name, key.Name))
+			}
+
+			entries = append(entries, exportEntry{
+				Name:     feature.Name + "." + key.Name,
+				DataType: key.DataType,
+				Data:     key.Values[0].Data,
+			})
+		}
+	}
+
+	slices.SortFunc(entries, func(a, b exportEntry) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	switch params.Format {
+	case ExportFormatYaml:
+		return exportYaml(entries)
+	case ExportFormatEnv:
+		return exportEnv(entries)
+	case ExportFormatProperties:
+		return exportProperties(entries)
+	default:
+		return exportJson(entries)
+	}
+}
+
+// typedValues returns the values by their names, maps are encoded with sorted keys in both YAML and JSON
+func typedValues(entries []exportEntry) (map[string]any, error) {
+	values := make(map[string]any, len(entries))
+
+	for _, entry := range entries {
+		value, err := entry.typedValue()
+		if err != nil {
+			return nil, core.NewServiceError(core.ErrorCodeUnexpectedError, fmt.Sprintf("Invalid %s value of %s: %s", entry.DataType, entry.Name, err))
+		}
+
+		values[entry.Name] = value
+	}
+
+	return values, nil
+}
+
+func exportYaml(entries []exportEntry) ([]byte, error) {
+	values, err := typedValues(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(values); err != nil {
+		return nil, err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func exportJson(entries []exportEntry) ([]byte, error) {
+	values, err := typedValues(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(data, '\n'), nil
+}
+
+// compactJson returns JSON data on a single line, so it fits line based formats
+func compactJson(entry exportEntry) (string, error) {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(entry.Data)); err != nil {
+		return "", core.NewServiceError(core.ErrorCodeUnexpectedError, fmt.Sprintf("Invalid %s value of %s: %s", entry.DataType, entry.Name, err))
+	}
+
+	return compact.String(), nil
+}
+
+// envName converts Feature.Key to FEATURE_KEY, splitting camel case words with an underscore
+func envName(name string) string {
+	var builder strings.Builder
+	runes := []rune(name)
+
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])) {
+				builder.WriteRune('_')
+			}
+			builder.WriteRune(r)
+		case unicode.IsLower(r) || unicode.IsDigit(r):
+			builder.WriteRune(unicode.ToUpper(r))
+		default:
+			builder.WriteRune('_')
+		}
+	}
+
+	return builder.String()
+}
+
+func exportEnv(entries []exportEntry) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	for _, entry := range entries {
+		value := entry.Data
+
+		switch entry.DataType {
+		case "integer", "decimal", "boolean":
+		case "json", "string_list", "integer_list":
+			compact, err := compactJson(entry)
+			if err != nil {
+				return nil, err
+			}
+
+			value = quoteEnv(compact)
+		default:
+			value = quoteEnv(value)
+		}
+
+		fmt.Fprintf(&buffer, "%s=%s\n", envName(entry.Name), value)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func quoteEnv(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`", "\n", `\n`).Replace(value) + `"`
+}
+
+// escapeProperty escapes a key or value of a Java properties file, non ASCII characters are written as unicode escapes
+func escapeProperty(s string, isKey bool) string {
+	var builder strings.Builder
+
+	for i, r := range s {
+		switch {
+		case r == '\\':
+			builder.WriteString(`\\`)
+		case r == '\n':
+			builder.WriteString(`\n`)
+		case r == '\r':
+			builder.WriteString(`\r`)
+		case r == '\t':
+			builder.WriteString(`\t`)
+		case r == '\f':
+			builder.WriteString(`\f`)
+		case r == ' ' && (isKey || i == 0):
+			builder.WriteString(`\ `)
+		case isKey && (r == '=' || r == ':' || r == '#' || r == '!'):
+			builder.WriteRune('\\')
+			builder.WriteRune(r)
+		case r > unicode.MaxASCII || r < 0x20:
+			for _, unit := range utf16.Encode([]rune{r}) {
+				fmt.Fprintf(&builder, `\u%04x`, unit)
+			}
+		default:
+			builder.WriteRune(r)
+		}
+	}
+
+	return builder.String()
+}
+
+func exportProperties(entries []exportEntry) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	for _, entry := range entries {
+		value := entry.Data
+
+		if entry.DataType == "json" || entry.DataType == "string_list" || entry.DataType == "integer_list" {
+			compact, err := compactJson(entry)
+			if err != nil {
+				return nil, err
+			}
+
+			value = compact
+		}
+
+		fmt.Fprintf(&buffer, "%s=%s\n", escapeProperty(entry.Name, true), escapeProperty(value, false))
+	}
+
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,190 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/necroskillz/config-service/util/test"
+	"gotest.tools/v3/assert"
+)
+
+func TestConfigurationExport(t *testing.T) {
+	t.Run("EnvName", func(t *testing.T) {
+		type testCase struct {
+			name   string
+			expect string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			assert.Equal(t, envName(tc.name), tc.expect)
+		}
+
+		testCases := map[string]testCase{
+			"simple":       {name: "Feature.Key", expect: "FEATURE_KEY"},
+			"camel case":   {name: "HttpClient.MaxRetries", expect: "HTTP_CLIENT_MAX_RETRIES"},
+			"lower camel":  {name: "httpClient.maxRetries", expect: "HTTP_CLIENT_MAX_RETRIES"},
+			"digits":       {name: "Oauth2.Timeout30s", expect: "OAUTH2_TIMEOUT30S"},
+			"digit before": {name: "V2Api.Url", expect: "V2_API_URL"},
+			"acronym":      {name: "Api.HTTPTimeout", expect: "API_HTTPTIMEOUT"},
+			"separators":   {name: "My-Feature.Some Key", expect: "MY_FEATURE_SOME_KEY"},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+
+	t.Run("QuoteEnv", func(t *testing.T) {
+		type testCase struct {
+			value  string
+			expect string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			assert.Equal(t, quoteEnv(tc.value), tc.expect)
+		}
+
+		testCases := map[string]testCase{
+			"plain":     {value: "value", expect: `"value"`},
+			"empty":     {value: "", expect: `""`},
+			"quote":     {value: `say "hi"`, expect: `"say \"hi\""`},
+			"backslash": {value: `C:\temp`, expect: `"C:\\temp"`},
+			"dollar":    {value: "$HOME/${USER}", expect: `"\$HOME/\${USER}"`},
+			"backtick":  {value: "`id`", expect: "\"\\`id\\`\""},
+			"newline":   {value: "line1\nline2", expect: `"line1\nline2"`},
+			"non ascii": {value: "čaj ☕", expect: `"čaj ☕"`},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+
+	t.Run("EscapeProperty", func(t *testing.T) {
+		type testCase struct {
+			value  string
+			isKey  bool
+			expect string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			assert.Equal(t, escapeProperty(tc.value, tc.isKey), tc.expect)
+		}
+
+		testCases := map[string]testCase{
+			"plain key":            {value: "Feature.Key", isKey: true, expect: "Feature.Key"},
+			"key with separators":  {value: "a=b:c", isKey: true, expect: `a\=b\:c`},
+			"key with spaces":      {value: "Some Feature.Some Key", isKey: true, expect: `Some\ Feature.Some\ Key`},
+			"key with comments":    {value: "#a!b", isKey: true, expect: `\#a\!b`},
+			"value with separator": {value: "a=b:c #d", isKey: false, expect: "a=b:c #d"},
+			"value leading space":  {value: " a b", isKey: false, expect: `\ a b`},
+			"backslash":            {value: `C:\temp`, isKey: false, expect: `C:\\temp`},
+			"control characters":   {value: "a\nb\rc\td\fe\x01", isKey: false, expect: `a\nb\rc\td\fe\u0001`},
+			"non ascii":            {value: "čaj", isKey: false, expect: `\u010daj`},
+			"surrogate pair":       {value: "☕😀", isKey: false, expect: `\u2615\ud83d\ude00`},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+
+	t.Run("TypedValue", func(t *testing.T) {
+		type testCase struct {
+			dataType    string
+			data        string
+			expect      any
+			expectError bool
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			value, err := exportEntry{Name: "Feature.Key", DataType: tc.dataType, Data: tc.data}.typedValue()
+			if tc.expectError {
+				assert.Assert(t, err != nil)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.DeepEqual(t, value, tc.expect)
+		}
+
+		testCases := map[string]testCase{
+			"string":          {dataType: "string", data: "123", expect: "123"},
+			"integer":         {dataType: "integer", data: "123", expect: int64(123)},
+			"decimal":         {dataType: "decimal", data: "1.5", expect: 1.5},
+			"boolean":         {dataType: "boolean", data: "true", expect: true},
+			"json":            {dataType: "json", data: `{"a": [1, "b"]}`, expect: map[string]any{"a": []any{1.0, "b"}}},
+			"string list":     {dataType: "string_list", data: `["a", "b"]`, expect: []string{"a", "b"}},
+			"integer list":    {dataType: "integer_list", data: `[1, 2]`, expect: []int64{1, 2}},
+			"invalid integer": {dataType: "integer", data: "a", expectError: true},
+			"invalid json":    {dataType: "json", data: "{", expectError: true},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+
+	t.Run("Export", func(t *testing.T) {
+		entries := []exportEntry{
+			{Name: "Feature.Enabled", DataType: "boolean", Data: "true"},
+			{Name: "Feature.Hosts", DataType: "string_list", Data: `["a.com", "b.com"]`},
+			{Name: "Feature.Limits", DataType: "json", Data: "{\n  \"max\": 10,\n  \"name\": \"x\"\n}"},
+			{Name: "Feature.Ports", DataType: "integer_list", Data: `[80, 443]`},
+			{Name: "Feature.Ratio", DataType: "decimal", Data: "0.5"},
+			{Name: "Feature.Title", DataType: "string", Data: "Hello $USER"},
+		}
+
+		type testCase struct {
+			export func([]exportEntry) ([]byte, error)
+			expect string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			data, err := tc.export(entries)
+			assert.NilError(t, err)
+			assert.Equal(t, string(data), tc.expect)
+		}
+
+		testCases := map[string]testCase{
+			"yaml": {export: exportYaml, expect: `Feature.Enabled: true
+Feature.Hosts:
+  - a.com
+  - b.com
+Feature.Limits:
+  max: 10
+  name: x
+Feature.Ports:
+  - 80
+  - 443
+Feature.Ratio: 0.5
+Feature.Title: Hello $USER
+`},
+			"json": {export: exportJson, expect: `{
+  "Feature.Enabled": true,
+  "Feature.Hosts": [
+    "a.com",
+    "b.com"
+  ],
+  "Feature.Limits": {
+    "max": 10,
+    "name": "x"
+  },
+  "Feature.Ports": [
+    80,
+    443
+  ],
+  "Feature.Ratio": 0.5,
+  "Feature.Title": "Hello $USER"
+}
+`},
+			"env": {export: exportEnv, expect: `FEATURE_ENABLED=true
+FEATURE_HOSTS="[\"a.com\",\"b.com\"]"
+FEATURE_LIMITS="{\"max\":10,\"name\":\"x\"}"
+FEATURE_PORTS="[80,443]"
+FEATURE_RATIO=0.5
+FEATURE_TITLE="Hello \$USER"
+`},
+			"properties": {export: exportProperties, expect: `Feature.Enabled=true
+Feature.Hosts=["a.com","b.com"]
+Feature.Limits={"max":10,"name":"x"}
+Feature.Ports=[80,443]
+Feature.Ratio=0.5
+Feature.Title=Hello $USER
+`},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+}
@@ -3,12 +3,16 @@ package configuration
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"slices"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/necroskillz/config-service/db"
 	"github.com/necroskillz/config-service/services/core"
+	"github.com/necroskillz/config-service/services/secret"
+	"github.com/necroskillz/config-service/services/validation"
 	"github.com/necroskillz/config-service/services/variation"
 	"github.com/necroskillz/config-service/util/jsonmerge"
 )
@@ -17,6 +21,7 @@ type Service struct {
 	queries                   *db.Queries
 	variationContextService   *variation.ContextService
 	variationHierarchyService *variation.HierarchyService
+	secretService             *secret.Service
 }
 
 type ServiceVersions []db.GetServiceVersionByNameAndVersionRow
@@ -53,8 +58,8 @@ func (s ServiceVersions) GetServiceTypeIds() []uint {
 	return ids
 }
 
-func NewService(queries *db.Queries, variationContextService *variation.ContextService, variationHierarchyService *variation.HierarchyService) *Service {
-	return &Service{queries: queries, variationContextService: variationContextService, variationHierarchyService: variationHierarchyService}
+func NewService(queries *db.Queries, variationContextService *variation.ContextService, variationHierarchyService *variation.HierarchyService, secretService *secret.Service) *Service {
+	return &Service{queries: queries, variationContextService: variationContextService, variationHierarchyService: variationHierarchyService, secretService: secretService}
 }
 
 func (s *Service) getServiceVersions(ctx context.Context, serviceVersionSpecifiers []core.ServiceVersionSpecifier) (ServiceVersions, error) {
@@ -180,43 +185,78 @@ type FeatureConfigurationDto struct {
 }
 
 type KeyConfigurationDto struct {
-	Name     string                  `json:"name" validate:"required"`
-	DataType string                  `json:"dataType" validate:"required"`
-	Values   []ValueConfigurationDto `json:"values" validate:"required"`
+	Name     string `json:"name" validate:"required"`
+	DataType string `json:"dataType" validate:"required"`
+	// AllowedValues are the values allowed by the enum validator of the key
+	AllowedValues []string                `json:"allowedValues,omitempty"`
+	Values        []ValueConfigurationDto `json:"values" validate:"required"`
 }
 
 type ValueConfigurationDto struct {
 	Data      string            `json:"data" validate:"required"`
 	Variation map[string]string `json:"variation,omitempty"`
 	Rank      int               `json:"rank" validate:"required"`
+	// Rollout is set when the value applies only to a percentage of the subjects, clients fall back to lower ranked values for the rest
+	Rollout *core.Rollout `json:"rollout,omitempty"`
 }
 
 type GetConfigurationParams struct {
 	ServiceVersionSpecifiers []core.ServiceVersionSpecifier
 	ChangesetID              *uint
-	Mode                     string
-	Variation                map[uint]string
+	// AsOf resolves the configuration of the last changeset applied at or before the time
+	AsOf *time.Time
+	Mode string
+	// Resolve requires the variation to specify all properties and returns exactly one value per key,
+	// so clients do not have to rank, match and merge the values themselves. Rolled out values are left out,
+	// because whether they apply depends on the subject.
+	Resolve   bool
+	Variation map[uint]string
+	// RevealSecrets returns the data of secret values decrypted instead of redacted
+	RevealSecrets bool
+	// OmitRedactedSecrets leaves out secret keys instead of redacting them when secrets are not revealed, for clients
+	// that would use the redacted data as the actual value
+	OmitRedactedSecrets bool
 }
 
-func (s *Service) GetConfiguration(ctx context.Context, params GetConfigurationParams) (ConfigurationDto, error) {
+type configurationRows struct {
+	ChangesetID     uint
+	Timestamp       time.Time
+	ServiceVersions ServiceVersions
+	Rows            []db.GetConfigurationRow
+}
+
+// getConfigurationRows resolves the changeset of the configuration and returns all values of the service versions valid in it
+func (s *Service) getConfigurationRows(ctx context.Context, params GetConfigurationParams) (configurationRows, error) {
 	var timestamp time.Time
 	var changesetID uint
 	isProduction := params.Mode == "production"
 	isChangesetApplied := false
 
-	variationHierarchy, err := s.variationHierarchyService.GetVariationHierarchy(ctx)
-	if err != nil {
-		return ConfigurationDto{}, err
+	if params.ChangesetID != nil && params.AsOf != nil {
+		return configurationRows{}, core.NewServiceError(core.ErrorCodeInvalidInput, "Changeset ID and as of time cannot be combined")
+	}
+
+	if params.AsOf != nil {
+		changeset, err := s.queries.GetLastAppliedChangesetAsOf(ctx, *params.AsOf)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return configurationRows{}, core.NewServiceError(core.ErrorCodeRecordNotFound, fmt.Sprintf("No changeset was applied at or before %s", params.AsOf.Format(time.RFC3339)))
+			}
+
+			return configurationRows{}, err
+		}
+
+		params.ChangesetID = &changeset.ID
 	}
 
 	if params.ChangesetID != nil {
 		changeset, err := s.queries.GetChangeset(ctx, *params.ChangesetID)
 		if err != nil {
-			return ConfigurationDto{}, err
+			return configurationRows{}, err
 		}
 
 		if isProduction && changeset.AppliedAt == nil {
-			return ConfigurationDto{}, core.NewServiceError(core.ErrorCodeInvalidOperation, "Getting configuration for production mode requires the changeset to be applied")
+			return configurationRows{}, core.NewServiceError(core.ErrorCodeInvalidOperation, "Getting configuration for production mode requires the changeset to be applied")
 		}
 
 		if changeset.AppliedAt != nil {
@@ -228,7 +268,7 @@ func (s *Service) GetConfiguration(ctx context.Context, params GetConfigurationP
 	} else {
 		lastAppliedChangeset, err := s.queries.GetLastAppliedChangeset(ctx)
 		if err != nil {
-			return ConfigurationDto{}, err
+			return configurationRows{}, err
 		}
 
 		changesetID = lastAppliedChangeset.ID
@@ -238,28 +278,109 @@ func (s *Service) GetConfiguration(ctx context.Context, params GetConfigurationP
 
 	serviceVersions, err := s.getServiceVersions(ctx, params.ServiceVersionSpecifiers)
 	if err != nil {
-		return ConfigurationDto{}, err
+		return configurationRows{}, err
 	}
 
 	if isProduction && !serviceVersions.ArePublished() {
-		return ConfigurationDto{}, core.NewServiceError(core.ErrorCodeInvalidOperation, "Getting configuration for production mode requires all service versions to be published")
+		return configurationRows{}, core.NewServiceError(core.ErrorCodeInvalidOperation, "Getting configuration for production mode requires all service versions to be published")
 	}
 
-	configuration, err := s.queries.GetConfiguration(ctx, db.GetConfigurationParams{
+	rows, err := s.queries.GetConfiguration(ctx, db.GetConfigurationParams{
 		ServiceVersionIds: serviceVersions.GetIds(),
 		Timestamp:         timestamp,
 		IsApplied:         isChangesetApplied,
 		ChangesetID:       changesetID,
 	})
+	if err != nil {
+		return configurationRows{}, err
+	}
+
+	return configurationRows{
+		ChangesetID:     changesetID,
+		Timestamp:       timestamp,
+		ServiceVersions: serviceVersions,
+		Rows:            rows,
+	}, nil
+}
+
+// omitKey returns true for secret keys that are left out instead of redacted
+func omitKey(dataType string, revealSecrets bool, omitRedactedSecrets bool) bool {
+	return omitRedactedSecrets && !revealSecrets && db.ValueTypeKind(dataType) == db.ValueTypeKindSecret
+}
+
+// presentValues replaces the data of secret values with what the caller is allowed to see
+func (s *Service) presentValues(dataType string, values []ValueConfigurationDto, revealSecrets bool) error {
+	for i, value := range values {
+		data, err := s.secretService.Present(db.ValueTypeKind(dataType), value.Data, revealSecrets)
+		if err != nil {
+			return err
+		}
+
+		values[i].Data = data
+	}
+
+	return nil
+}
+
+func (s *Service) GetConfiguration(ctx context.Context, params GetConfigurationParams) (ConfigurationDto, error) {
+	configuration, err := s.getConfiguration(ctx, params)
 	if err != nil {
 		return ConfigurationDto{}, err
 	}
 
+	for i, feature := range configuration.Features {
+		keys := make([]KeyConfigurationDto, 0, len(feature.Keys))
+		for _, key := range feature.Keys {
+			if omitKey(key.DataType, params.RevealSecrets, params.OmitRedactedSecrets) {
+				continue
+			}
+
+			if err := s.presentValues(key.DataType, key.Values, params.RevealSecrets); err != nil {
+				return ConfigurationDto{}, err
+			}
+
+			keys = append(keys, key)
+		}
+
+		configuration.Features[i].Keys = keys
+	}
+
+	return configuration, nil
+}
+
+// getConfiguration returns the configuration with the data of secret values as it is stored
+func (s *Service) getConfiguration(ctx context.Context, params GetConfigurationParams) (ConfigurationDto, error) {
+	variationHierarchy, err := s.variationHierarchyService.GetVariationHierarchy(ctx)
+	if err != nil {
+		return ConfigurationDto{}, err
+	}
+
+	configuration, err := s.getConfigurationRows(ctx, params)
+	if err != nil {
+		return ConfigurationDto{}, err
+	}
+
+	if params.Resolve {
+		// with all properties specified no variation is left unresolved, so the values of each key are reduced to a single value below
+		for _, serviceTypeID := range configuration.ServiceVersions.GetServiceTypeIds() {
+			properties, err := variationHierarchy.GetProperties(serviceTypeID)
+			if err != nil {
+				return ConfigurationDto{}, err
+			}
+
+			for _, property := range properties {
+				if _, ok := params.Variation[property.ID]; !ok {
+					return ConfigurationDto{}, core.NewServiceError(core.ErrorCodeInvalidInput, fmt.Sprintf("Resolving configuration requires a value for every variation property, %s is missing", property.Name))
+				}
+			}
+		}
+	}
+
 	featureIndex := make(map[uint]int)
 	keyIndex := make(map[uint]int)
 	features := []FeatureConfigurationDto{}
 
-	for _, value := range configuration {
+	for _, value := range configuration.Rows {
 		fi, ok := featureIndex[value.FeatureID]
 		if !ok {
 			fi = len(features)
@@ -272,12 +393,21 @@ func (s *Service) GetConfiguration(ctx context.Context, params GetConfigurationP
 
 		ki, ok := keyIndex[value.KeyID]
 		if !ok {
+			var allowedValues []string
+			if value.EnumValues != nil {
+				allowedValues, err = validation.ParseStringListParam(*value.EnumValues)
+				if err != nil {
+					return ConfigurationDto{}, err
+				}
+			}
+
 			ki = len(features[fi].Keys)
 			keyIndex[value.KeyID] = ki
 			features[fi].Keys = append(features[fi].Keys, KeyConfigurationDto{
-				Name:     value.KeyName,
-				DataType: string(value.ValueType),
-				Values:   []ValueConfigurationDto{},
+				Name:          value.KeyName,
+				DataType:      string(value.ValueType),
+				AllowedValues: allowedValues,
+				Values:        []ValueConfigurationDto{},
 			})
 		}
 
@@ -291,7 +421,7 @@ func (s *Service) GetConfiguration(ctx context.Context, params GetConfigurationP
 			return ConfigurationDto{}, err
 		}
 
-		if !match {
+		if !match || (params.Resolve && value.RolloutPercentage != nil) {
 			continue
 		}
 
@@ -309,6 +439,7 @@ func (s *Service) GetConfiguration(ctx context.Context, params GetConfigurationP
 			Data:      value.Data,
 			Variation: variationMap,
 			Rank:      rank,
+			Rollout:   core.NewRollout(value.RolloutPercentage, value.RolloutAttribute),
 		}
 
 		features[fi].Keys[ki].Values = append(features[fi].Keys[ki].Values, valueDto)
@@ -320,13 +451,14 @@ func (s *Service) GetConfiguration(ctx context.Context, params GetConfigurationP
 				return a.Rank - b.Rank
 			})
 
+			// rolled out values are kept separate even without unresolved variation, so clients can fall back to the default value
 			values := make([]ValueConfigurationDto, 1, len(key.Values))
 
 			if key.DataType == "json" {
 				var defaultValue any
 
 				for _, value := range key.Values {
-					if len(value.Variation) == 0 {
+					if len(value.Variation) == 0 && value.Rollout == nil {
 						var jsonData any
 						err := json.Unmarshal([]byte(value.Data), &jsonData)
 						if err != nil {
@@ -353,7 +485,7 @@ func (s *Service) GetConfiguration(ctx context.Context, params GetConfigurationP
 				}
 			} else {
 				for _, value := range key.Values {
-					if len(value.Variation) == 0 {
+					if len(value.Variation) == 0 && value.Rollout == nil {
 						values[0] = value
 					} else {
 						values = append(values, value)
@@ -367,8 +499,8 @@ func (s *Service) GetConfiguration(ctx context.Context, params GetConfigurationP
 	}
 
 	return ConfigurationDto{
-		ChangesetID: changesetID,
+		ChangesetID: configuration.ChangesetID,
 		Features:    features,
-		AppliedAt:   &timestamp,
+		AppliedAt:   &configuration.Timestamp,
 	}, nil
 }
@@ -0,0 +1,614 @@
+package constraint
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/necroskillz/config-service/auth"
+	"github.com/necroskillz/config-service/constants"
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/core"
+	"github.com/necroskillz/config-service/services/secret"
+	"github.com/necroskillz/config-service/services/validation"
+	"github.com/necroskillz/config-service/services/variation"
+	"github.com/necroskillz/config-service/util/ptr"
+	"github.com/necroskillz/config-service/util/validator"
+)
+
+// maxVariations limits the number of variations a constraint is evaluated for, constraints over more variations are
+// reported as violated because they cannot be verified
+const maxVariations = 1000
+
+type Service struct {
+	queries                   *db.Queries
+	currentUserAccessor       *auth.CurrentUserAccessor
+	validator                 *validator.Validator
+	coreService               *core.Service
+	variationContextService   *variation.ContextService
+	variationHierarchyService *variation.HierarchyService
+	secretService             *secret.Service
+}
+
+func NewService(
+	queries *db.Queries,
+	currentUserAccessor *auth.CurrentUserAccessor,
+	validator *validator.Validator,
+	coreService *core.Service,
+	variationContextService *variation.ContextService,
+	variationHierarchyService *variation.HierarchyService,
+	secretService *secret.Service,
+) *Service {
+	return &Service{
+		queries:                   queries,
+		currentUserAccessor:       currentUserAccessor,
+		validator:                 validator,
+		coreService:               coreService,
+		variationContextService:   variationContextService,
+		variationHierarchyService: variationHierarchyService,
+		secretService:             secretService,
+	}
+}
+
+type ConstraintDto struct {
+	ID         uint   `json:"id" validate:"required"`
+	Expression string `json:"expression" validate:"required"`
+	ErrorText  string `json:"errorText" validate:"required"`
+}
+
+// getEditableFeatureVersion returns the feature version, if the current user is an admin of its feature and its constraints
+// can be changed. Constraints are not part of changesets, so they cannot be changed for feature versions linked to
+// a published service version.
+func (s *Service) getEditableFeatureVersion(ctx context.Context, serviceVersionID uint, featureVersionID uint) (db.GetServiceVersionRow, db.GetFeatureVersionRow, error) {
+	serviceVersion, featureVersion, err := s.coreService.GetFeatureVersion(ctx, serviceVersionID, featureVersionID)
+	if err != nil {
+		return db.GetServiceVersionRow{}, db.GetFeatureVersionRow{}, err
+	}
+
+	user := s.currentUserAccessor.GetUser(ctx)
+
+	if user.GetPermissionForFeature(serviceVersion.ServiceID, featureVersion.FeatureID) < constants.PermissionAdmin {
+		return db.GetServiceVersionRow{}, db.GetFeatureVersionRow{}, core.NewServiceError(core.ErrorCodePermissionDenied, "You are not authorized to manage constraints of this feature")
+	}
+
+	if featureVersion.LinkedToPublishedServiceVersion {
+		return db.GetServiceVersionRow{}, db.GetFeatureVersionRow{}, core.NewServiceError(core.ErrorCodeInvalidOperation, "Constraints cannot be changed for a feature version that is linked to a published service version")
+	}
+
+	return serviceVersion, featureVersion, nil
+}
+
+func (s *Service) getConstraint(ctx context.Context, serviceVersionID uint, featureVersionID uint, constraintID uint) (db.GetServiceVersionRow, db.FeatureVersionConstraint, error) {
+	serviceVersion, featureVersion, err := s.getEditableFeatureVersion(ctx, serviceVersionID, featureVersionID)
+	if err != nil {
+		return db.GetServiceVersionRow{}, db.FeatureVersionConstraint{}, err
+	}
+
+	constraint, err := s.queries.GetFeatureVersionConstraint(ctx, constraintID)
+	if err != nil {
+		return db.GetServiceVersionRow{}, db.FeatureVersionConstraint{}, core.NewDbError(err, "Constraint")
+	}
+
+	if constraint.FeatureVersionID != featureVersion.ID {
+		return db.GetServiceVersionRow{}, db.FeatureVersionConstraint{}, core.NewServiceError(core.ErrorCodeRecordNotFound, "Constraint not found")
+	}
+
+	return serviceVersion, constraint, nil
+}
+
+func (s *Service) GetConstraints(ctx context.Context, serviceVersionID uint, featureVersionID uint) ([]ConstraintDto, error) {
+	_, featureVersion, err := s.coreService.GetFeatureVersion(ctx, serviceVersionID, featureVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	constraints, err := s.queries.GetFeatureVersionConstraints(ctx, featureVersion.ID)
+	if err != nil {
+		return nil, core.NewDbError(err, "Constraints")
+	}
+
+	result := make([]ConstraintDto, len(constraints))
+	for i, constraint := range constraints {
+		result[i] = ConstraintDto{
+			ID:         constraint.ID,
+			Expression: constraint.Expression,
+			ErrorText:  ptr.From(constraint.ErrorText),
+		}
+	}
+
+	return result, nil
+}
+
+// getKeys returns the keys of the feature version as seen from the changeset
+func (s *Service) getKeys(ctx context.Context, changesetID uint, featureVersionID uint) ([]db.GetKeysForFeatureVersionRow, error) {
+	keys, err := s.queries.GetKeysForFeatureVersion(ctx, db.GetKeysForFeatureVersionParams{
+		ChangesetID:      changesetID,
+		FeatureVersionID: featureVersionID,
+	})
+	if err != nil {
+		return nil, core.NewDbError(err, "Keys")
+	}
+
+	return keys, nil
+}
+
+// compile compiles the expression against the keys of the feature version, each key is a variable of its value type
+func compile(expression string, keys []db.GetKeysForFeatureVersionRow) (*validator.Expression, error) {
+	variables := make(map[string]validator.ExpressionValueType, len(keys))
+	for _, key := range keys {
+		variables[key.Name] = validation.GetExpressionValueType(key.ValueTypeKind)
+	}
+
+	return validator.CompileExpressionWithVariables(expression, variables)
+}
+
+// validateConstraint validates the constraint and checks that the values of the feature version, as seen from the changeset
+// of the current user, satisfy it
+func (s *Service) validateConstraint(ctx context.Context, serviceVersion db.GetServiceVersionRow, constraint db.FeatureVersionConstraint) error {
+	err := s.validator.
+		Validate(constraint.Expression, "Expression").Required().MaxLength(1000).
+		Validate(ptr.From(constraint.ErrorText), "Error Text").MaxLength(100).
+		Error(ctx)
+	if err != nil {
+		return err
+	}
+
+	hierarchy, err := s.variationHierarchyService.GetVariationHierarchy(ctx)
+	if err != nil {
+		return err
+	}
+
+	user := s.currentUserAccessor.GetUser(ctx)
+
+	state, err := s.getFeatureVersionState(ctx, hierarchy, user.ChangesetID, serviceVersion.ServiceTypeID, constraint.FeatureVersionID)
+	if err != nil {
+		return err
+	}
+
+	expression, err := compile(constraint.Expression, state.keys)
+	if err != nil {
+		return core.NewServiceError(core.ErrorCodeInvalidInput, fmt.Sprintf("Expression must be a valid CEL expression: %s", err))
+	}
+
+	message, err := s.checkConstraint(ctx, hierarchy, state, constraint, expression)
+	if err != nil {
+		return err
+	}
+
+	if message != "" {
+		return core.NewServiceError(core.ErrorCodeInvalidOperation, fmt.Sprintf("Existing values do not satisfy the constraint: %s", message))
+	}
+
+	return nil
+}
+
+type CreateConstraintParams struct {
+	ServiceVersionID uint
+	FeatureVersionID uint
+	Expression       string
+	ErrorText        string
+}
+
+func (s *Service) CreateConstraint(ctx context.Context, params CreateConstraintParams) (uint, error) {
+	serviceVersion, featureVersion, err := s.getEditableFeatureVersion(ctx, params.ServiceVersionID, params.FeatureVersionID)
+	if err != nil {
+		return 0, err
+	}
+
+	constraint := db.FeatureVersionConstraint{
+		FeatureVersionID: featureVersion.ID,
+		Expression:       params.Expression,
+		ErrorText:        ptr.To(params.ErrorText, ptr.NilIfZero()),
+	}
+
+	if err := s.validateConstraint(ctx, serviceVersion, constraint); err != nil {
+		return 0, err
+	}
+
+	return s.queries.CreateFeatureVersionConstraint(ctx, db.CreateFeatureVersionConstraintParams{
+		FeatureVersionID: constraint.FeatureVersionID,
+		Expression:       constraint.Expression,
+		ErrorText:        constraint.ErrorText,
+	})
+}
+
+type UpdateConstraintParams struct {
+	ServiceVersionID uint
+	FeatureVersionID uint
+	ConstraintID     uint
+	Expression       string
+	ErrorText        string
+}
+
+func (s *Service) UpdateConstraint(ctx context.Context, params UpdateConstraintParams) error {
+	serviceVersion, constraint, err := s.getConstraint(ctx, params.ServiceVersionID, params.FeatureVersionID, params.ConstraintID)
+	if err != nil {
+		return err
+	}
+
+	constraint.Expression = params.Expression
+	constraint.ErrorText = ptr.To(params.ErrorText, ptr.NilIfZero())
+
+	if err := s.validateConstraint(ctx, serviceVersion, constraint); err != nil {
+		return err
+	}
+
+	return s.queries.UpdateFeatureVersionConstraint(ctx, db.UpdateFeatureVersionConstraintParams{
+		ConstraintID: constraint.ID,
+		Expression:   constraint.Expression,
+		ErrorText:    constraint.ErrorText,
+	})
+}
+
+func (s *Service) DeleteConstraint(ctx context.Context, serviceVersionID uint, featureVersionID uint, constraintID uint) error {
+	_, constraint, err := s.getConstraint(ctx, serviceVersionID, featureVersionID, constraintID)
+	if err != nil {
+		return err
+	}
+
+	return s.queries.DeleteFeatureVersionConstraint(ctx, constraint.ID)
+}
+
+type keyValue struct {
+	variation map[uint]string
+	rank      int
+	data      string
+	// rolledOut values apply only to some subjects, the rest get a lower ranked value
+	rolledOut bool
+}
+
+// featureVersionState is the view of the keys and values of a feature version from a changeset
+type featureVersionState struct {
+	serviceTypeID uint
+	keys          []db.GetKeysForFeatureVersionRow
+	values        map[string][]keyValue
+}
+
+func (s *Service) getFeatureVersionState(ctx context.Context, hierarchy *variation.Hierarchy, changesetID uint, serviceTypeID uint, featureVersionID uint) (featureVersionState, error) {
+	state := featureVersionState{
+		serviceTypeID: serviceTypeID,
+		values:        map[string][]keyValue{},
+	}
+
+	keys, err := s.getKeys(ctx, changesetID, featureVersionID)
+	if err != nil {
+		return state, err
+	}
+
+	state.keys = keys
+
+	for _, key := range keys {
+		values, err := s.queries.GetVariationValuesForKey(ctx, db.GetVariationValuesForKeyParams{
+			KeyID:       key.ID,
+			ChangesetID: changesetID,
+		})
+		if err != nil {
+			return state, core.NewDbError(err, "VariationValues")
+		}
+
+		for _, value := range values {
+			valueVariation, err := s.variationContextService.GetVariationContextValues(ctx, value.VariationContextID)
+			if err != nil {
+				return state, err
+			}
+
+			rank, err := hierarchy.GetRank(serviceTypeID, valueVariation)
+			if err != nil {
+				return state, err
+			}
+
+			data, err := s.secretService.Open(key.ValueTypeKind, value.Data)
+			if err != nil {
+				return state, err
+			}
+
+			state.values[key.Name] = append(state.values[key.Name], keyValue{variation: valueVariation, rank: rank, data: data, rolledOut: value.RolloutPercentage != nil})
+		}
+	}
+
+	return state, nil
+}
+
+func variationKey(variation map[uint]string) string {
+	parts := make([]string, 0, len(variation))
+	for _, propertyID := range slices.Sorted(maps.Keys(variation)) {
+		parts = append(parts, fmt.Sprintf("%d=%s", propertyID, variation[propertyID]))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// getVariations returns the variations the values of the keys are defined for, together with their combinations,
+// e.g. a value for env=prod and a value for domain=com give the variation env=prod,domain=com. It returns false if
+// there are more than maxVariations variations, the returned variations are then incomplete.
+func getVariations(state featureVersionState, keyNames []string) ([]map[uint]string, bool) {
+	variations := []map[uint]string{{}}
+	seen := map[string]bool{"": true}
+	complete := true
+
+	add := func(variation map[uint]string) {
+		key := variationKey(variation)
+		if seen[key] {
+			return
+		}
+
+		if len(variations) >= maxVariations {
+			complete = false
+			return
+		}
+
+		seen[key] = true
+		variations = append(variations, variation)
+	}
+
+	for _, keyName := range keyNames {
+		for _, value := range state.values[keyName] {
+			add(value.variation)
+		}
+	}
+
+	for i := 1; i < len(variations) && complete; i++ {
+		for j := 1; j < i && complete; j++ {
+			merged := maps.Clone(variations[j])
+			compatible := true
+
+			for propertyID, value := range variations[i] {
+				if existing, ok := merged[propertyID]; ok && existing != value {
+					compatible = false
+					break
+				}
+
+				merged[propertyID] = value
+			}
+
+			if compatible {
+				add(merged)
+			}
+		}
+	}
+
+	return variations, complete
+}
+
+// getEffectiveData returns the data the key can have for the variation. It is the data of the most specific value
+// matching the variation, preceded by the data of more specific rolled out values, because subjects in the rollout get
+// those instead.
+func getEffectiveData(hierarchy *variation.Hierarchy, values []keyValue, variation map[uint]string) ([]string, error) {
+	matching := []keyValue{}
+
+	for _, value := range values {
+		match, unresolved, err := hierarchy.Filter(value.variation, variation)
+		if err != nil {
+			return nil, err
+		}
+
+		if match && len(unresolved) == 0 {
+			matching = append(matching, value)
+		}
+	}
+
+	slices.SortStableFunc(matching, func(a, b keyValue) int {
+		return b.rank - a.rank
+	})
+
+	data := []string{}
+	for _, value := range matching {
+		data = append(data, value.data)
+
+		if !value.rolledOut {
+			return data, nil
+		}
+	}
+
+	// only rolled out values match, subjects outside of the rollout have no value
+	return nil, nil
+}
+
+func formatVariation(hierarchy *variation.Hierarchy, variation map[uint]string) (string, error) {
+	if len(variation) == 0 {
+		return "default", nil
+	}
+
+	variationMap, err := hierarchy.GetVariationStringMap(variation)
+	if err != nil {
+		return "", err
+	}
+
+	parts := make([]string, 0, len(variationMap))
+	for _, property := range slices.Sorted(maps.Keys(variationMap)) {
+		parts = append(parts, fmt.Sprintf("%s=%s", property, variationMap[property]))
+	}
+
+	return strings.Join(parts, ", "), nil
+}
+
+// checkConstraint evaluates the constraint for every variation the referenced keys have values for and returns
+// a message describing the first violation, or an empty string if the constraint holds
+func (s *Service) checkConstraint(ctx context.Context, hierarchy *variation.Hierarchy, state featureVersionState, constraint db.FeatureVersionConstraint, expression *validator.Expression) (string, error) {
+	variations, checkable := getVariations(state, expression.References())
+	if !checkable {
+		// an unchecked variation could violate the constraint, so it is not reported as satisfied
+		return fmt.Sprintf("Constraint %s could not be checked, the keys it references have more than %d variation combinations", constraint.Expression, maxVariations), nil
+	}
+
+	for _, variation := range variations {
+		keyData := make([][]string, len(expression.References()))
+		complete := true
+
+		for i, keyName := range expression.References() {
+			data, err := getEffectiveData(hierarchy, state.values[keyName], variation)
+			if err != nil {
+				return "", err
+			}
+
+			if len(data) == 0 {
+				complete = false
+				break
+			}
+
+			keyData[i] = data
+		}
+
+		if !complete {
+			continue
+		}
+
+		message, err := s.checkCombinations(ctx, hierarchy, constraint, expression, variation, keyData)
+		if err != nil || message != "" {
+			return message, err
+		}
+	}
+
+	return "", nil
+}
+
+// checkCombinations evaluates the constraint for every combination of the data the keys can have for the variation,
+// so both rolled out values and the values they fall back to are checked
+func (s *Service) checkCombinations(ctx context.Context, hierarchy *variation.Hierarchy, constraint db.FeatureVersionConstraint, expression *validator.Expression, variation map[uint]string, keyData [][]string) (string, error) {
+	indexes := make([]int, len(keyData))
+
+	for {
+		data := make(map[string]string, len(keyData))
+		for i, keyName := range expression.References() {
+			data[keyName] = keyData[i][indexes[i]]
+		}
+
+		message, err := s.evalConstraint(ctx, hierarchy, constraint, expression, variation, data)
+		if err != nil || message != "" {
+			return message, err
+		}
+
+		i := 0
+		for ; i < len(indexes); i++ {
+			indexes[i]++
+			if indexes[i] < len(keyData[i]) {
+				break
+			}
+
+			indexes[i] = 0
+		}
+
+		if i == len(indexes) {
+			return "", nil
+		}
+	}
+}
+
+// evalConstraint returns a message describing the violation of the constraint by the data, or an empty string if the
+// constraint holds
+func (s *Service) evalConstraint(ctx context.Context, hierarchy *variation.Hierarchy, constraint db.FeatureVersionConstraint, expression *validator.Expression, variation map[uint]string, data map[string]string) (string, error) {
+	valid, result, err := expression.EvalVariables(ctx, data)
+	if valid {
+		return "", nil
+	}
+
+	variationText, verr := formatVariation(hierarchy, variation)
+	if verr != nil {
+		return "", verr
+	}
+
+	if err != nil {
+		return fmt.Sprintf("Constraint %s could not be checked for variation %s: %s", constraint.Expression, variationText, err), nil
+	}
+
+	if constraint.ErrorText != nil {
+		return strings.ReplaceAll(*constraint.ErrorText, validator.ExpressionResultPlaceholder, result), nil
+	}
+
+	if result == "false" {
+		return fmt.Sprintf("Constraint %s is violated for variation %s", constraint.Expression, variationText), nil
+	}
+
+	return fmt.Sprintf("Constraint %s is violated for variation %s: %s", constraint.Expression, variationText, result), nil
+}
+
+// CheckChangeset evaluates the constraints of the feature versions whose keys or values are changed in the changeset,
+// against the effective values as seen from the changeset. It returns the violation messages by the ID of the change
+// that touches a key used by the violated constraint.
+func (s *Service) CheckChangeset(ctx context.Context, changesetID uint, changes []db.GetChangesetChangesRow) (map[uint]string, error) {
+	violations := map[uint]string{}
+	featureVersionChanges := map[uint][]db.GetChangesetChangesRow{}
+	serviceVersionIDs := map[uint]uint{}
+
+	for _, change := range changes {
+		if (change.Kind != db.ChangesetChangeKindVariationValue && change.Kind != db.ChangesetChangeKindKey) || change.FeatureVersionID == nil {
+			continue
+		}
+
+		featureVersionChanges[*change.FeatureVersionID] = append(featureVersionChanges[*change.FeatureVersionID], change)
+		serviceVersionIDs[*change.FeatureVersionID] = change.ServiceVersionID
+	}
+
+	if len(featureVersionChanges) == 0 {
+		return violations, nil
+	}
+
+	var hierarchy *variation.Hierarchy
+
+	for _, featureVersionID := range slices.Sorted(maps.Keys(featureVersionChanges)) {
+		constraints, err := s.queries.GetFeatureVersionConstraints(ctx, featureVersionID)
+		if err != nil {
+			return nil, core.NewDbError(err, "Constraints")
+		}
+
+		if len(constraints) == 0 {
+			continue
+		}
+
+		if hierarchy == nil {
+			hierarchy, err = s.variationHierarchyService.GetVariationHierarchy(ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		serviceVersion, err := s.queries.GetServiceVersion(ctx, db.GetServiceVersionParams{
+			ServiceVersionID: serviceVersionIDs[featureVersionID],
+			ChangesetID:      changesetID,
+		})
+		if err != nil {
+			return nil, core.NewDbError(err, "ServiceVersion")
+		}
+
+		state, err := s.getFeatureVersionState(ctx, hierarchy, changesetID, serviceVersion.ServiceTypeID, featureVersionID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, constraint := range constraints {
+			var message string
+			var references []string
+
+			// keys may have been deleted or changed type in the changeset, making the constraint invalid
+			expression, err := compile(constraint.Expression, state.keys)
+			if err != nil {
+				message = fmt.Sprintf("Constraint %s is invalid: %s", constraint.Expression, err)
+			} else {
+				references = expression.References()
+
+				message, err = s.checkConstraint(ctx, hierarchy, state, constraint, expression)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if message == "" {
+				continue
+			}
+
+			for _, change := range featureVersionChanges[featureVersionID] {
+				if _, ok := violations[change.ID]; ok {
+					continue
+				}
+
+				if expression == nil || (change.KeyName != nil && slices.Contains(references, *change.KeyName)) {
+					violations[change.ID] = message
+				}
+			}
+		}
+	}
+
+	return violations, nil
+}
@@ -119,3 +119,45 @@ func ParseServiceVersionSpecifiers(serviceVersions []string) ([]ServiceVersionSp
 
 	return serviceVersionSpecifiers, nil
 }
+
+// Rollout limits a value to a percentage of the subjects, which are bucketed by the value of the attribute
+type Rollout struct {
+	Percentage int    `json:"percentage" validate:"required"`
+	Attribute  string `json:"attribute" validate:"required"`
+}
+
+// NewRollout returns the rollout of a stored value, or nil if the value is not rolled out
+func NewRollout(percentage *int, attribute *string) *Rollout {
+	if percentage == nil || attribute == nil {
+		return nil
+	}
+
+	return &Rollout{Percentage: *percentage, Attribute: *attribute}
+}
+
+// PercentageParam returns the percentage to store, nil if the value is not rolled out
+func (r *Rollout) PercentageParam() *int {
+	if r == nil {
+		return nil
+	}
+
+	return &r.Percentage
+}
+
+// AttributeParam returns the attribute to store, nil if the value is not rolled out
+func (r *Rollout) AttributeParam() *string {
+	if r == nil {
+		return nil
+	}
+
+	return &r.Attribute
+}
+
+// Equal returns true if both values are rolled out the same way or neither is rolled out
+func (r *Rollout) Equal(other *Rollout) bool {
+	if r == nil || other == nil {
+		return r == other
+	}
+
+	return *r == *other
+}
@@ -530,6 +530,8 @@ func (s *Service) LinkFeatureVersion(ctx context.Context, serviceVersionID uint,
 type FeatureVersionKeyDataValue struct {
 	Data               string
 	VariationContextID uint
+	RolloutPercentage  *int
+	RolloutAttribute   *string
 }
 
 type FeatureVersionKeyDataValidator struct {
@@ -597,6 +599,8 @@ func (s *Service) getFeatureVersionKeyData(ctx context.Context, featureVersionID
 					{
 						Data:               key.Data,
 						VariationContextID: key.VariationContextID,
+						RolloutPercentage:  key.RolloutPercentage,
+						RolloutAttribute:   key.RolloutAttribute,
 					},
 				},
 				Validators:  []FeatureVersionKeyDataValidator{},
@@ -606,6 +610,8 @@ func (s *Service) getFeatureVersionKeyData(ctx context.Context, featureVersionID
 			existingKey.Values = append(existingKey.Values, FeatureVersionKeyDataValue{
 				Data:               key.Data,
 				VariationContextID: key.VariationContextID,
+				RolloutPercentage:  key.RolloutPercentage,
+				RolloutAttribute:   key.RolloutAttribute,
 			})
 
 			keyMap[key.KeyID] = existingKey
@@ -789,6 +795,8 @@ func (s *Service) CreateFeatureVersion(ctx context.Context, params CreateFeature
 					KeyID:              keyID,
 					VariationContextID: value.VariationContextID,
 					Data:               value.Data,
+					RolloutPercentage:  value.RolloutPercentage,
+					RolloutAttribute:   value.RolloutAttribute,
 				})
 			}
 
@@ -818,6 +826,13 @@ func (s *Service) CreateFeatureVersion(ctx context.Context, params CreateFeature
 			return err
 		}
 
+		if err := tx.CopyFeatureVersionConstraints(ctx, db.CopyFeatureVersionConstraintsParams{
+			NewFeatureVersionID: newFeatureVersionID,
+			FeatureVersionID:    featureVersion.ID,
+		}); err != nil {
+			return err
+		}
+
 		createdVariationValues, err := tx.GetVariationValuesForWipFeatureVersion(ctx, newFeatureVersionID)
 		if err != nil {
 			return err
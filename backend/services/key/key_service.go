@@ -14,6 +14,7 @@ import (
 	"github.com/necroskillz/config-service/db"
 	"github.com/necroskillz/config-service/services/changeset"
 	"github.com/necroskillz/config-service/services/core"
+	"github.com/necroskillz/config-service/services/secret"
 	"github.com/necroskillz/config-service/services/validation"
 	"github.com/necroskillz/config-service/services/variation"
 	"github.com/necroskillz/config-service/util/ptr"
@@ -31,6 +32,7 @@ type Service struct {
 	valueValidatorService     *validation.ValueValidatorService
 	variationHierarchyService *variation.HierarchyService
 	validationService         *validation.Service
+	secretService             *secret.Service
 }
 
 func NewService(
@@ -44,6 +46,7 @@ func NewService(
 	valueValidatorService *validation.ValueValidatorService,
 	variationHierarchyService *variation.HierarchyService,
 	validationService *validation.Service,
+	secretService *secret.Service,
 ) *Service {
 	return &Service{
 		unitOfWorkRunner:          unitOfWorkRunner,
@@ -56,6 +59,7 @@ func NewService(
 		valueValidatorService:     valueValidatorService,
 		variationHierarchyService: variationHierarchyService,
 		validationService:         validationService,
+		secretService:             secretService,
 	}
 }
 
@@ -208,16 +212,58 @@ func (s *Service) validateValidators(vc *validator.Context, validators []validat
 			pvc.Required().MaxLength(500).ValidRegex()
 		case "json_schema":
 			pvc.Required().MaxLength(10000).ValidJsonSchema()
+		case "string_list":
+			pvc.Required().MaxLength(10000).ValidJson().JsonSchema(validation.StringListParameterSchema)
+		case "duration":
+			pvc.Required().MaxLength(50).ValidDuration()
+		case "expression":
+			pvc.Required().MaxLength(1000)
 		}
 	}
 
 	return vc
 }
 
-func (s *Service) validateCreateKey(ctx context.Context, data CreateKeyParams, serviceVersion db.GetServiceVersionRow, featureVersion db.GetFeatureVersionRow) error {
+// validateEnumValidators checks that keys of the enum kind have the list of allowed values and no key has more than one
+func (s *Service) validateEnumValidators(valueTypeKind db.ValueTypeKind, validators []validation.ValidatorDto) error {
+	count := 0
+	for _, validator := range validators {
+		if validator.ValidatorType == db.ValueValidatorTypeEnum {
+			count++
+		}
+	}
+
+	if count > 1 {
+		return core.NewServiceError(core.ErrorCodeInvalidInput, "Only one enum validator is allowed")
+	}
+
+	if valueTypeKind == db.ValueTypeKindEnum && count == 0 {
+		return core.NewServiceError(core.ErrorCodeInvalidInput, "Enum keys require an enum validator with the allowed values")
+	}
+
+	return nil
+}
+
+// validateExpressionValidators checks that the expressions of cel validators compile against the value of the kind
+func (s *Service) validateExpressionValidators(ctx context.Context, valueTypeKind db.ValueTypeKind, validators []validation.ValidatorDto) error {
+	vc := s.validator.Validate(nil, "Validators")
+
+	for i, validator := range validators {
+		if validator.ValidatorType != db.ValueValidatorTypeCel {
+			continue
+		}
+
+		vc.Validate(validator.Parameter, fmt.Sprintf("Validators[%d].Parameter", i)).ValidExpression(validation.GetExpressionValueType(valueTypeKind))
+	}
+
+	return vc.Error(ctx)
+}
+
+// validateCreateKey validates the key and returns its value type
+func (s *Service) validateCreateKey(ctx context.Context, data CreateKeyParams, serviceVersion db.GetServiceVersionRow, featureVersion db.GetFeatureVersionRow) (db.ValueType, error) {
 	user := s.currentUserAccessor.GetUser(ctx)
 	if user.GetPermissionForFeature(serviceVersion.ServiceID, featureVersion.FeatureID) < constants.PermissionAdmin {
-		return core.NewServiceError(core.ErrorCodePermissionDenied, "You are not authorized to create keys for this feature")
+		return db.ValueType{}, core.NewServiceError(core.ErrorCodePermissionDenied, "You are not authorized to create keys for this feature")
 	}
 
 	vc := s.validator.
@@ -225,16 +271,31 @@ func (s *Service) validateCreateKey(ctx context.Context, data CreateKeyParams, s
 		Validate(data.ValueTypeID, "Value Type ID").Min(1).
 		Validate(data.Description, "Description").MaxLength(core.DefaultDescriptionMaxLength)
 
-	s.validateValidators(vc, data.Validators)
+	if err := s.validateValidators(vc, data.Validators).Error(ctx); err != nil {
+		return db.ValueType{}, err
+	}
+
+	valueType, err := s.queries.GetValueType(ctx, data.ValueTypeID)
+	if err != nil {
+		return db.ValueType{}, core.NewDbError(err, "ValueType")
+	}
+
+	if err := s.validateEnumValidators(valueType.Kind, data.Validators); err != nil {
+		return db.ValueType{}, err
+	}
+
+	if err := s.validateExpressionValidators(ctx, valueType.Kind, data.Validators); err != nil {
+		return db.ValueType{}, err
+	}
 
 	valueTypeValidators, err := s.valueValidatorService.GetValueValidators(ctx, nil, &data.ValueTypeID)
 	if err != nil {
-		return err
+		return db.ValueType{}, err
 	}
 
-	validatorFunc, err := s.valueValidatorService.CreateValueValidatorFunc(slices.Concat(valueTypeValidators, data.Validators))
+	validatorFunc, err := s.valueValidatorService.CreateValueValidatorFunc(valueType.Kind, slices.Concat(valueTypeValidators, data.Validators))
 	if err != nil {
-		return err
+		return db.ValueType{}, err
 	}
 
 	vc.Validate(data.DefaultValue, "Default Value").Func(validatorFunc)
@@ -242,16 +303,16 @@ func (s *Service) validateCreateKey(ctx context.Context, data CreateKeyParams, s
 	err = vc.Error(ctx)
 
 	if err != nil {
-		return err
+		return db.ValueType{}, err
 	}
 
 	if taken, err := s.validationService.IsKeyNameTaken(ctx, featureVersion.ID, data.Name); err != nil {
-		return err
+		return db.ValueType{}, err
 	} else if taken {
-		return core.NewServiceError(core.ErrorCodeInvalidOperation, "Key name is already taken")
+		return db.ValueType{}, core.NewServiceError(core.ErrorCodeInvalidOperation, "Key name is already taken")
 	}
 
-	return nil
+	return valueType, nil
 }
 
 func (s *Service) CreateKey(ctx context.Context, params CreateKeyParams) (uint, error) {
@@ -260,7 +321,12 @@ func (s *Service) CreateKey(ctx context.Context, params CreateKeyParams) (uint,
 		return 0, err
 	}
 
-	err = s.validateCreateKey(ctx, params, serviceVersion, featureVersion)
+	valueType, err := s.validateCreateKey(ctx, params, serviceVersion, featureVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	defaultValue, err := s.secretService.Seal(valueType.Kind, params.DefaultValue)
 	if err != nil {
 		return 0, err
 	}
@@ -312,7 +378,7 @@ func (s *Service) CreateKey(ctx context.Context, params CreateKeyParams) (uint,
 
 		variationValueID, err := tx.CreateVariationValue(ctx, db.CreateVariationValueParams{
 			KeyID:              keyID,
-			Data:               params.DefaultValue,
+			Data:               defaultValue,
 			VariationContextID: defaultVariationContextID,
 		})
 		if err != nil {
@@ -355,7 +421,17 @@ func (s *Service) validateUpdateKey(ctx context.Context, data UpdateKeyParams, s
 		Validate(data.Description, "Description").MaxLength(core.DefaultDescriptionMaxLength)
 
 	if hasValidatorsChanges {
-		s.validateValidators(vc, data.Validators)
+		if err := s.validateValidators(vc, data.Validators).Error(ctx); err != nil {
+			return err
+		}
+
+		if err := s.validateEnumValidators(key.ValueTypeKind, data.Validators); err != nil {
+			return err
+		}
+
+		if err := s.validateExpressionValidators(ctx, key.ValueTypeKind, data.Validators); err != nil {
+			return err
+		}
 
 		if key.CreatedInChangesetID != user.ChangesetID {
 			changesCount, err := s.queries.GetRelatedKeyChangesCount(ctx, db.GetRelatedKeyChangesCountParams{
@@ -389,7 +465,7 @@ func (s *Service) validateUpdateKey(ctx context.Context, data UpdateKeyParams, s
 			return err
 		}
 
-		validatorFunc, err := s.valueValidatorService.CreateValueValidatorFunc(slices.Concat(valueTypeValidators, data.Validators))
+		validatorFunc, err := s.valueValidatorService.CreateValueValidatorFunc(key.ValueTypeKind, slices.Concat(valueTypeValidators, data.Validators))
 		if err != nil {
 			return err
 		}
@@ -415,7 +491,12 @@ func (s *Service) validateUpdateKey(ctx context.Context, data UpdateKeyParams, s
 				valueNameBuilder.WriteString("Default")
 			}
 
-			vc.Validate(variationValue.Data, valueNameBuilder.String()).Func(validatorFunc)
+			data, err := s.secretService.Open(key.ValueTypeKind, variationValue.Data)
+			if err != nil {
+				return err
+			}
+
+			vc.Validate(data, valueNameBuilder.String()).Func(validatorFunc)
 		}
 	}
 
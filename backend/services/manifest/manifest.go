@@ -0,0 +1,91 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/core"
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest declaratively describes the features, keys and values of a service version
+type Manifest struct {
+	Features []FeatureManifest `yaml:"features" json:"features" validate:"required"`
+}
+
+type FeatureManifest struct {
+	Name        string        `yaml:"name" json:"name" validate:"required"`
+	Description string        `yaml:"description" json:"description" validate:"required"`
+	Keys        []KeyManifest `yaml:"keys" json:"keys" validate:"required"`
+}
+
+type KeyManifest struct {
+	Name        string `yaml:"name" json:"name" validate:"required"`
+	Description string `yaml:"description" json:"description"`
+	// ValueType is the name of the value type
+	ValueType  string              `yaml:"valueType" json:"valueType" validate:"required"`
+	Validators []ValidatorManifest `yaml:"validators" json:"validators"`
+	// Values must contain the default value, which has no variation
+	Values []ValueManifest `yaml:"values" json:"values" validate:"required"`
+}
+
+type ValidatorManifest struct {
+	ValidatorType db.ValueValidatorType `yaml:"validatorType" json:"validatorType" validate:"required"`
+	Parameter     string                `yaml:"parameter" json:"parameter"`
+	ErrorText     string                `yaml:"errorText" json:"errorText"`
+}
+
+type ValueManifest struct {
+	Variation map[string]string `yaml:"variation" json:"variation"`
+	Data      Data              `yaml:"data" json:"data" validate:"required"`
+	// Rollout limits the value to a percentage of the subjects, the default value cannot be rolled out
+	Rollout *core.Rollout `yaml:"rollout,omitempty" json:"rollout,omitempty"`
+}
+
+// Data is the value data. Scalars are used as is, objects and arrays are encoded as JSON, so json values can be
+// written as nested YAML.
+type Data string
+
+func (d *Data) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		if node.Tag == "!!null" {
+			*d = ""
+		} else {
+			*d = Data(node.Value)
+		}
+
+		return nil
+	}
+
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	*d = Data(data)
+
+	return nil
+}
+
+// Parse reads a manifest in YAML or JSON format
+func Parse(data []byte) (Manifest, error) {
+	var manifest Manifest
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&manifest); err != nil && !errors.Is(err, io.EOF) {
+		return Manifest{}, core.NewServiceError(core.ErrorCodeInvalidInput, fmt.Sprintf("Invalid manifest: %s", err))
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,809 @@
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/necroskillz/config-service/auth"
+	"github.com/necroskillz/config-service/constants"
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/core"
+	"github.com/necroskillz/config-service/services/feature"
+	"github.com/necroskillz/config-service/services/key"
+	"github.com/necroskillz/config-service/services/secret"
+	"github.com/necroskillz/config-service/services/validation"
+	"github.com/necroskillz/config-service/services/value"
+	"github.com/necroskillz/config-service/services/valuetype"
+	"github.com/necroskillz/config-service/services/variation"
+	"github.com/necroskillz/config-service/util/validator"
+)
+
+type Service struct {
+	coreService               *core.Service
+	featureService            *feature.Service
+	keyService                *key.Service
+	valueService              *value.Service
+	valueTypeService          *valuetype.Service
+	valueValidatorService     *validation.ValueValidatorService
+	validationService         *validation.Service
+	variationHierarchyService *variation.HierarchyService
+	validator                 *validator.Validator
+	secretService             *secret.Service
+	currentUserAccessor       *auth.CurrentUserAccessor
+	unitOfWorkRunner          db.UnitOfWorkRunner
+}
+
+func NewService(
+	coreService *core.Service,
+	featureService *feature.Service,
+	keyService *key.Service,
+	valueService *value.Service,
+	valueTypeService *valuetype.Service,
+	valueValidatorService *validation.ValueValidatorService,
+	validationService *validation.Service,
+	variationHierarchyService *variation.HierarchyService,
+	validator *validator.Validator,
+	secretService *secret.Service,
+	currentUserAccessor *auth.CurrentUserAccessor,
+	unitOfWorkRunner db.UnitOfWorkRunner,
+) *Service {
+	return &Service{
+		coreService:               coreService,
+		featureService:            featureService,
+		keyService:                keyService,
+		valueService:              valueService,
+		valueTypeService:          valueTypeService,
+		valueValidatorService:     valueValidatorService,
+		validationService:         validationService,
+		variationHierarchyService: variationHierarchyService,
+		validator:                 validator,
+		secretService:             secretService,
+		currentUserAccessor:       currentUserAccessor,
+		unitOfWorkRunner:          unitOfWorkRunner,
+	}
+}
+
+type ImportChangeKind string
+
+const (
+	ImportChangeKindCreateFeature ImportChangeKind = "create_feature"
+	ImportChangeKindCreateKey     ImportChangeKind = "create_key"
+	ImportChangeKindCreateValue   ImportChangeKind = "create_value"
+	ImportChangeKindUpdateValue   ImportChangeKind = "update_value"
+	ImportChangeKindUpdateFeature ImportChangeKind = "update_feature"
+	ImportChangeKindUpdateKey     ImportChangeKind = "update_key"
+	ImportChangeKindDeleteValue   ImportChangeKind = "delete_value"
+	ImportChangeKindDeleteKey     ImportChangeKind = "delete_key"
+	ImportChangeKindUnlinkFeature ImportChangeKind = "unlink_feature"
+)
+
+// DriftKind is a part of the service version that is not in the manifest and is left as it is, because the import does not prune
+type DriftKind string
+
+const (
+	DriftKindUnmanagedFeature DriftKind = "unmanaged_feature"
+	DriftKindUnmanagedKey     DriftKind = "unmanaged_key"
+	DriftKindUnmanagedValue   DriftKind = "unmanaged_value"
+)
+
+type DriftDto struct {
+	Kind      DriftKind         `json:"kind" validate:"required"`
+	Feature   string            `json:"feature" validate:"required"`
+	Key       *string           `json:"key,omitempty"`
+	Variation map[string]string `json:"variation,omitempty"`
+	// Data is the data of an unmanaged value
+	Data *string `json:"data,omitempty"`
+}
+
+type ImportChangeDto struct {
+	Kind       ImportChangeKind  `json:"kind" validate:"required"`
+	Feature    string            `json:"feature" validate:"required"`
+	Key        *string           `json:"key,omitempty"`
+	Variation  map[string]string `json:"variation,omitempty"`
+	OldData    *string           `json:"oldData,omitempty"`
+	NewData    *string           `json:"newData,omitempty"`
+	OldRollout *core.Rollout     `json:"oldRollout,omitempty"`
+	NewRollout *core.Rollout     `json:"newRollout,omitempty"`
+}
+
+type ImportResultDto struct {
+	DryRun  bool              `json:"dryRun" validate:"required"`
+	Changes []ImportChangeDto `json:"changes" validate:"required"`
+	// Drift are the features, keys and values that are not in the manifest and are not removed by the import
+	Drift []DriftDto `json:"drift" validate:"required"`
+}
+
+type ImportParams struct {
+	ServiceVersionID uint
+	Manifest         Manifest
+	// DryRun validates the manifest and returns the changes without making them
+	DryRun bool
+	// Prune removes the values and keys that are not in the manifest and unlinks the features that are not in it
+	Prune bool
+}
+
+type plannedChange struct {
+	ImportChangeDto
+	apply func(ctx context.Context) error
+}
+
+// importPlan collects the changes, which are applied in order. IDs of created features and keys are shared
+// through pointers with the changes that depend on them.
+type importPlan struct {
+	user           *auth.User
+	serviceVersion db.GetServiceVersionRow
+	hierarchy      *variation.Hierarchy
+	valueTypes     map[string]valuetype.ValueTypeDto
+	revealSecrets  bool
+	prune          bool
+	changes        []plannedChange
+	drift          []DriftDto
+}
+
+func (p *importPlan) add(change ImportChangeDto, apply func(ctx context.Context) error) {
+	p.changes = append(p.changes, plannedChange{ImportChangeDto: change, apply: apply})
+}
+
+// withContext prefixes the message of service errors with the part of the manifest the error is about
+func withContext(err error, context string) error {
+	var serviceError *core.ServiceError
+	if errors.As(err, &serviceError) {
+		return core.NewServiceError(serviceError.Code, fmt.Sprintf("%s: %s", context, serviceError.Message)).WithErr(err)
+	}
+
+	return err
+}
+
+func variationString(variation map[string]string) string {
+	parts := make([]string, 0, len(variation))
+	for _, property := range slices.Sorted(maps.Keys(variation)) {
+		parts = append(parts, property+"="+variation[property])
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// Import creates the features, keys and values of the manifest that do not exist in the service version and updates
+// descriptions, validators and values that differ from it, in the open changeset of the current user. The features, keys
+// and values missing from the manifest are removed when pruning, otherwise they are reported as drift.
+// The changes are made with the feature, key and value services, so all their validation and permission checks apply.
+// Permissions are also checked while planning, so a dry run fails for imports the user is not allowed to make, and the
+// changes are applied in a single transaction, so a failed import leaves the changeset as it was.
+func (s *Service) Import(ctx context.Context, params ImportParams) (ImportResultDto, error) {
+	plan, err := s.plan(ctx, params)
+	if err != nil {
+		return ImportResultDto{}, err
+	}
+
+	result := ImportResultDto{
+		DryRun:  params.DryRun,
+		Changes: make([]ImportChangeDto, len(plan.changes)),
+		Drift:   plan.drift,
+	}
+
+	for i, change := range plan.changes {
+		result.Changes[i] = change.ImportChangeDto
+	}
+
+	if params.DryRun {
+		return result, nil
+	}
+
+	err = s.unitOfWorkRunner.RunInContext(ctx, func(ctx context.Context) error {
+		for _, change := range plan.changes {
+			context := change.Feature
+			if change.Key != nil {
+				context += "." + *change.Key
+			}
+
+			if err := change.apply(ctx); err != nil {
+				return withContext(err, context)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return ImportResultDto{}, err
+	}
+
+	return result, nil
+}
+
+func (s *Service) plan(ctx context.Context, params ImportParams) (*importPlan, error) {
+	serviceVersion, err := s.coreService.GetServiceVersion(ctx, params.ServiceVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	hierarchy, err := s.variationHierarchyService.GetVariationHierarchy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	valueTypes, err := s.valueTypeService.GetValueTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &importPlan{
+		user:           s.currentUserAccessor.GetUser(ctx),
+		serviceVersion: serviceVersion,
+		hierarchy:      hierarchy,
+		valueTypes:     make(map[string]valuetype.ValueTypeDto, len(valueTypes)),
+		revealSecrets:  s.secretService.CanReveal(ctx),
+		prune:          params.Prune,
+		drift:          []DriftDto{},
+	}
+
+	for _, valueType := range valueTypes {
+		plan.valueTypes[valueType.Name] = valueType
+	}
+
+	features, err := s.featureService.GetServiceFeatures(ctx, params.ServiceVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	existingFeatures := make(map[string]feature.FeatureVersionItemDto, len(features))
+	for _, feature := range features {
+		existingFeatures[feature.Name] = feature
+	}
+
+	visited := make(map[string]bool, len(params.Manifest.Features))
+
+	for _, featureManifest := range params.Manifest.Features {
+		if visited[featureManifest.Name] {
+			return nil, core.NewServiceError(core.ErrorCodeInvalidInput, fmt.Sprintf("Feature %s is declared more than once", featureManifest.Name))
+		}
+
+		visited[featureManifest.Name] = true
+
+		if err := s.planFeature(ctx, plan, featureManifest, existingFeatures); err != nil {
+			return nil, withContext(err, featureManifest.Name)
+		}
+	}
+
+	for _, feature := range features {
+		if visited[feature.Name] {
+			continue
+		}
+
+		if !plan.prune {
+			plan.drift = append(plan.drift, DriftDto{Kind: DriftKindUnmanagedFeature, Feature: feature.Name})
+			continue
+		}
+
+		if err := s.planUnlinkFeature(ctx, plan, feature); err != nil {
+			return nil, withContext(err, feature.Name)
+		}
+	}
+
+	return plan, nil
+}
+
+func (s *Service) planUnlinkFeature(ctx context.Context, plan *importPlan, existingFeature feature.FeatureVersionItemDto) error {
+	if plan.user.GetPermissionForService(plan.serviceVersion.ServiceID) != constants.PermissionAdmin {
+		return core.NewServiceError(core.ErrorCodePermissionDenied, "You are not authorized to unlink features for this service")
+	}
+
+	_, _, link, err := s.coreService.GetFeatureVersionWithLink(ctx, plan.serviceVersion.ID, existingFeature.ID)
+	if err != nil {
+		return err
+	}
+
+	if plan.serviceVersion.Published && link.CreatedInChangesetID != plan.user.ChangesetID {
+		return core.NewServiceError(core.ErrorCodeInvalidOperation, "Features cannot be unlinked from a published service version")
+	}
+
+	plan.add(ImportChangeDto{
+		Kind:    ImportChangeKindUnlinkFeature,
+		Feature: existingFeature.Name,
+	}, func(ctx context.Context) error {
+		return s.featureService.UnlinkFeatureVersion(ctx, plan.serviceVersion.ID, existingFeature.ID)
+	})
+
+	return nil
+}
+
+func (s *Service) planFeature(ctx context.Context, plan *importPlan, featureManifest FeatureManifest, existingFeatures map[string]feature.FeatureVersionItemDto) error {
+	featureVersionID := new(uint)
+	existingKeys := map[string]key.KeyItemDto{}
+	// a new feature is created by a service admin, who can create its keys
+	canCreateKeys := true
+
+	if existingFeature, ok := existingFeatures[featureManifest.Name]; ok {
+		*featureVersionID = existingFeature.ID
+
+		_, featureVersion, err := s.coreService.GetFeatureVersion(ctx, plan.serviceVersion.ID, existingFeature.ID)
+		if err != nil {
+			return err
+		}
+
+		canCreateKeys = plan.user.GetPermissionForFeature(plan.serviceVersion.ServiceID, featureVersion.FeatureID) >= constants.PermissionAdmin
+
+		if existingFeature.Description != featureManifest.Description {
+			if plan.user.GetPermissionForService(plan.serviceVersion.ServiceID) != constants.PermissionAdmin {
+				return core.NewServiceError(core.ErrorCodePermissionDenied, "You are not authorized to update features for this service")
+			}
+
+			plan.add(ImportChangeDto{
+				Kind:    ImportChangeKindUpdateFeature,
+				Feature: featureManifest.Name,
+			}, func(ctx context.Context) error {
+				return s.featureService.UpdateFeature(ctx, feature.UpdateFeatureParams{
+					ServiceVersionID: plan.serviceVersion.ID,
+					FeatureVersionID: existingFeature.ID,
+					Description:      featureManifest.Description,
+				})
+			})
+		}
+
+		keys, err := s.keyService.GetFeatureKeys(ctx, plan.serviceVersion.ID, existingFeature.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			existingKeys[key.Name] = key
+		}
+	} else {
+		if plan.user.GetPermissionForService(plan.serviceVersion.ServiceID) != constants.PermissionAdmin {
+			return core.NewServiceError(core.ErrorCodePermissionDenied, "You are not authorized to create features for this service")
+		}
+
+		if taken, err := s.validationService.IsFeatureNameTaken(ctx, featureManifest.Name); err != nil {
+			return err
+		} else if taken {
+			return core.NewServiceError(core.ErrorCodeInvalidOperation, "Feature name is already taken by another service")
+		}
+
+		plan.add(ImportChangeDto{
+			Kind:    ImportChangeKindCreateFeature,
+			Feature: featureManifest.Name,
+		}, func(ctx context.Context) error {
+			var err error
+			*featureVersionID, err = s.featureService.CreateFeature(ctx, feature.CreateFeatureParams{
+				ServiceVersionID: plan.serviceVersion.ID,
+				Name:             featureManifest.Name,
+				Description:      featureManifest.Description,
+			})
+
+			return err
+		})
+	}
+
+	visited := make(map[string]bool, len(featureManifest.Keys))
+
+	for _, keyManifest := range featureManifest.Keys {
+		if visited[keyManifest.Name] {
+			return core.NewServiceError(core.ErrorCodeInvalidInput, fmt.Sprintf("Key %s is declared more than once", keyManifest.Name))
+		}
+
+		visited[keyManifest.Name] = true
+
+		existingKey, ok := existingKeys[keyManifest.Name]
+
+		var err error
+		if ok {
+			err = s.planExistingKey(ctx, plan, featureManifest.Name, *featureVersionID, keyManifest, existingKey)
+		} else if !canCreateKeys {
+			err = core.NewServiceError(core.ErrorCodePermissionDenied, "You are not authorized to create keys for this feature")
+		} else {
+			err = s.planNewKey(ctx, plan, featureManifest.Name, featureVersionID, keyManifest)
+		}
+
+		if err != nil {
+			return withContext(err, keyManifest.Name)
+		}
+	}
+
+	for _, existingKey := range slices.SortedFunc(maps.Values(existingKeys), func(a, b key.KeyItemDto) int { return strings.Compare(a.Name, b.Name) }) {
+		if visited[existingKey.Name] {
+			continue
+		}
+
+		if !plan.prune {
+			plan.drift = append(plan.drift, DriftDto{Kind: DriftKindUnmanagedKey, Feature: featureManifest.Name, Key: &existingKey.Name})
+			continue
+		}
+
+		if plan.user.GetPermissionForService(plan.serviceVersion.ServiceID) != constants.PermissionAdmin {
+			return withContext(core.NewServiceError(core.ErrorCodePermissionDenied, "You are not authorized to delete keys for this service"), existingKey.Name)
+		}
+
+		plan.add(ImportChangeDto{
+			Kind:    ImportChangeKindDeleteKey,
+			Feature: featureManifest.Name,
+			Key:     &existingKey.Name,
+		}, func(ctx context.Context) error {
+			return s.keyService.DeleteKey(ctx, plan.serviceVersion.ID, *featureVersionID, existingKey.ID)
+		})
+	}
+
+	return nil
+}
+
+// presentData returns the data of a value of the kind as it is shown in the import result
+func (s *Service) presentData(plan *importPlan, kind db.ValueTypeKind, data string) (*string, error) {
+	presented, err := s.secretService.Present(kind, data, plan.revealSecrets)
+	if err != nil {
+		return nil, err
+	}
+
+	return &presented, nil
+}
+
+// sameKeyValidators returns whether the validators of the key, without the built-in validators of its value type,
+// are the validators of the manifest in any order
+func sameKeyValidators(validators []validation.ValidatorDto, manifestValidators []ValidatorManifest) bool {
+	existing := []ValidatorManifest{}
+	for _, validator := range validators {
+		if !validator.IsBuiltIn {
+			existing = append(existing, ValidatorManifest{ValidatorType: validator.ValidatorType, Parameter: validator.Parameter, ErrorText: validator.ErrorText})
+		}
+	}
+
+	compare := func(a, b ValidatorManifest) int {
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+
+	return slices.Equal(slices.SortedFunc(slices.Values(existing), compare), slices.SortedFunc(slices.Values(manifestValidators), compare))
+}
+
+func validatorDtos(validators []ValidatorManifest) []validation.ValidatorDto {
+	dtos := make([]validation.ValidatorDto, len(validators))
+	for i, validator := range validators {
+		dtos[i] = validation.ValidatorDto{
+			ValidatorType: validator.ValidatorType,
+			Parameter:     validator.Parameter,
+			ErrorText:     validator.ErrorText,
+		}
+	}
+
+	return dtos
+}
+
+type plannedValue struct {
+	Variation   map[string]string
+	VariationID map[uint]string
+	Data        string
+	Rollout     *core.Rollout
+}
+
+// planValues validates the variations and data of the values of a key
+func (s *Service) planValues(ctx context.Context, plan *importPlan, fieldName string, keyManifest KeyManifest, valueTypeKind db.ValueTypeKind, validators []validation.ValidatorDto) ([]plannedValue, error) {
+	validatorFunc, err := s.valueValidatorService.CreateValueValidatorFunc(valueTypeKind, validators)
+	if err != nil {
+		return nil, core.NewServiceError(core.ErrorCodeInvalidInput, err.Error())
+	}
+
+	values := make([]plannedValue, 0, len(keyManifest.Values))
+	visited := make(map[string]bool, len(keyManifest.Values))
+	hasDefault := false
+
+	for _, valueManifest := range keyManifest.Values {
+		variation := valueManifest.Variation
+		if variation == nil {
+			variation = map[string]string{}
+		}
+
+		variationKey := variationString(variation)
+		if visited[variationKey] {
+			return nil, core.NewServiceError(core.ErrorCodeInvalidInput, fmt.Sprintf("Value with variation [%s] is declared more than once", variationKey))
+		}
+
+		visited[variationKey] = true
+		hasDefault = hasDefault || len(variation) == 0
+
+		if len(variation) == 0 && valueManifest.Rollout != nil {
+			return nil, core.NewServiceError(core.ErrorCodeInvalidInput, "Default value cannot be rolled out")
+		}
+
+		if err := plan.hierarchy.ValidateStringVariation(plan.serviceVersion.ServiceTypeID, variation); err != nil {
+			return nil, err
+		}
+
+		variationID, err := plan.hierarchy.GetVariationIDMap(variation)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.validator.
+			Validate(string(valueManifest.Data), fmt.Sprintf("%s [%s]", fieldName, variationKey)).Func(validatorFunc).
+			Error(ctx); err != nil {
+			return nil, err
+		}
+
+		values = append(values, plannedValue{
+			Variation:   variation,
+			VariationID: variationID,
+			Data:        string(valueManifest.Data),
+			Rollout:     valueManifest.Rollout,
+		})
+	}
+
+	if !hasDefault {
+		return nil, core.NewServiceError(core.ErrorCodeInvalidInput, "Default value without variation is required")
+	}
+
+	return values, nil
+}
+
+func (s *Service) planNewKey(ctx context.Context, plan *importPlan, featureName string, featureVersionID *uint, keyManifest KeyManifest) error {
+	valueType, ok := plan.valueTypes[keyManifest.ValueType]
+	if !ok {
+		return core.NewServiceError(core.ErrorCodeInvalidInput, fmt.Sprintf("Value type %s does not exist", keyManifest.ValueType))
+	}
+
+	keyValidators := validatorDtos(keyManifest.Validators)
+
+	validators := make([]validation.ValidatorDto, 0, len(valueType.Validators)+len(keyValidators))
+	for _, validator := range valueType.Validators {
+		validators = append(validators, validator.ValidatorDto)
+	}
+
+	values, err := s.planValues(ctx, plan, featureName+"."+keyManifest.Name, keyManifest, valueType.Kind, append(validators, keyValidators...))
+	if err != nil {
+		return err
+	}
+
+	defaultIndex := slices.IndexFunc(values, func(v plannedValue) bool { return len(v.Variation) == 0 })
+	keyID := new(uint)
+
+	defaultData, err := s.presentData(plan, valueType.Kind, values[defaultIndex].Data)
+	if err != nil {
+		return err
+	}
+
+	plan.add(ImportChangeDto{
+		Kind:    ImportChangeKindCreateKey,
+		Feature: featureName,
+		Key:     &keyManifest.Name,
+		NewData: defaultData,
+	}, func(ctx context.Context) error {
+		var err error
+		*keyID, err = s.keyService.CreateKey(ctx, key.CreateKeyParams{
+			ServiceVersionID: plan.serviceVersion.ID,
+			FeatureVersionID: *featureVersionID,
+			Name:             keyManifest.Name,
+			Description:      keyManifest.Description,
+			DefaultValue:     values[defaultIndex].Data,
+			ValueTypeID:      valueType.ID,
+			Validators:       keyValidators,
+		})
+
+		return err
+	})
+
+	for i, v := range values {
+		if i == defaultIndex {
+			continue
+		}
+
+		newData, err := s.presentData(plan, valueType.Kind, v.Data)
+		if err != nil {
+			return err
+		}
+
+		plan.add(ImportChangeDto{
+			Kind:       ImportChangeKindCreateValue,
+			Feature:    featureName,
+			Key:        &keyManifest.Name,
+			Variation:  v.Variation,
+			NewData:    newData,
+			NewRollout: v.Rollout,
+		}, func(ctx context.Context) error {
+			_, err := s.valueService.CreateValue(ctx, value.CreateValueParams{
+				ServiceVersionID: plan.serviceVersion.ID,
+				FeatureVersionID: *featureVersionID,
+				KeyID:            *keyID,
+				Data:             v.Data,
+				Variation:        v.VariationID,
+				Rollout:          v.Rollout,
+			})
+
+			return err
+		})
+	}
+
+	return nil
+}
+
+func (s *Service) planExistingKey(ctx context.Context, plan *importPlan, featureName string, featureVersionID uint, keyManifest KeyManifest, existingKey key.KeyItemDto) error {
+	if existingKey.ValueTypeName != keyManifest.ValueType {
+		return core.NewServiceError(core.ErrorCodeInvalidOperation, fmt.Sprintf("Key has value type %s, but the manifest declares %s", existingKey.ValueTypeName, keyManifest.ValueType))
+	}
+
+	_, featureVersion, keyRow, err := s.coreService.GetKey(ctx, plan.serviceVersion.ID, featureVersionID, existingKey.ID)
+	if err != nil {
+		return err
+	}
+
+	validators, err := s.valueValidatorService.GetValueValidators(ctx, &keyRow.ID, &keyRow.ValueTypeID)
+	if err != nil {
+		return err
+	}
+
+	sameValidators := sameKeyValidators(validators, keyManifest.Validators)
+	keyValidators := validatorDtos(keyManifest.Validators)
+
+	// the values are validated with the validators of the manifest, which replace the validators of the key
+	builtInValidators := slices.DeleteFunc(slices.Clone(validators), func(validator validation.ValidatorDto) bool { return !validator.IsBuiltIn })
+	values, err := s.planValues(ctx, plan, featureName+"."+keyManifest.Name, keyManifest, keyRow.ValueTypeKind, append(builtInValidators, keyValidators...))
+	if err != nil {
+		return err
+	}
+
+	if existingKey.Description != keyManifest.Description || !sameValidators {
+		if plan.user.GetPermissionForKey(plan.serviceVersion.ServiceID, featureVersion.FeatureID, keyRow.ID) < constants.PermissionAdmin {
+			return core.NewServiceError(core.ErrorCodePermissionDenied, "You are not authorized to update this key")
+		}
+
+		updateParams := key.UpdateKeyParams{
+			ServiceVersionID: plan.serviceVersion.ID,
+			FeatureVersionID: featureVersionID,
+			KeyID:            existingKey.ID,
+			Description:      keyManifest.Description,
+		}
+
+		if !sameValidators {
+			updateParams.Validators = keyValidators
+		}
+
+		// the key is updated before its values, because validators cannot be updated when the changeset has changes of the key
+		plan.add(ImportChangeDto{
+			Kind:    ImportChangeKindUpdateKey,
+			Feature: featureName,
+			Key:     &keyManifest.Name,
+		}, func(ctx context.Context) error {
+			return s.keyService.UpdateKey(ctx, updateParams)
+		})
+	}
+
+	existingValues, err := s.valueService.GetKeyValues(ctx, plan.serviceVersion.ID, featureVersionID, existingKey.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, existingValue := range existingValues {
+		if slices.ContainsFunc(values, func(v plannedValue) bool { return maps.Equal(existingValue.Variation, v.VariationID) }) {
+			continue
+		}
+
+		variation, err := plan.hierarchy.GetVariationStringMap(existingValue.Variation)
+		if err != nil {
+			return err
+		}
+
+		if !plan.prune {
+			plan.drift = append(plan.drift, DriftDto{
+				Kind:      DriftKindUnmanagedValue,
+				Feature:   featureName,
+				Key:       &keyManifest.Name,
+				Variation: variation,
+				Data:      &existingValue.Data,
+			})
+
+			continue
+		}
+
+		if plan.user.GetPermissionForValue(plan.serviceVersion.ServiceID, featureVersion.FeatureID, keyRow.ID, existingValue.Variation) < constants.PermissionEditor {
+			return core.NewServiceError(core.ErrorCodePermissionDenied, fmt.Sprintf("You are not authorized to delete the value with variation [%s] of this key", variationString(variation)))
+		}
+
+		plan.add(ImportChangeDto{
+			Kind:       ImportChangeKindDeleteValue,
+			Feature:    featureName,
+			Key:        &keyManifest.Name,
+			Variation:  variation,
+			OldData:    &existingValue.Data,
+			OldRollout: existingValue.Rollout,
+		}, func(ctx context.Context) error {
+			return s.valueService.DeleteValue(ctx, value.DeleteValueParams{
+				ServiceVersionID: plan.serviceVersion.ID,
+				FeatureVersionID: featureVersionID,
+				KeyID:            existingKey.ID,
+				ValueID:          existingValue.ID,
+			})
+		})
+	}
+
+	for _, v := range values {
+		existingIndex := slices.IndexFunc(existingValues, func(existing value.VariationValueDto) bool {
+			return maps.Equal(existing.Variation, v.VariationID)
+		})
+
+		newData, err := s.presentData(plan, keyRow.ValueTypeKind, v.Data)
+		if err != nil {
+			return err
+		}
+
+		if existingIndex == -1 {
+			if plan.user.GetPermissionForValue(plan.serviceVersion.ServiceID, featureVersion.FeatureID, keyRow.ID, v.VariationID) < constants.PermissionEditor {
+				return core.NewServiceError(core.ErrorCodePermissionDenied, fmt.Sprintf("You are not authorized to add a value with variation [%s] to this key", variationString(v.Variation)))
+			}
+
+			plan.add(ImportChangeDto{
+				Kind:       ImportChangeKindCreateValue,
+				Feature:    featureName,
+				Key:        &keyManifest.Name,
+				Variation:  v.Variation,
+				NewData:    newData,
+				NewRollout: v.Rollout,
+			}, func(ctx context.Context) error {
+				_, err := s.valueService.CreateValue(ctx, value.CreateValueParams{
+					ServiceVersionID: plan.serviceVersion.ID,
+					FeatureVersionID: featureVersionID,
+					KeyID:            existingKey.ID,
+					Data:             v.Data,
+					Variation:        v.VariationID,
+					Rollout:          v.Rollout,
+				})
+
+				return err
+			})
+
+			continue
+		}
+
+		existingValue := existingValues[existingIndex]
+		existingData := existingValue.Data
+
+		if keyRow.ValueTypeKind == db.ValueTypeKindSecret {
+			// key values may have the data of secret values redacted, so the stored data is decrypted for the comparison
+			_, _, _, variationValue, err := s.coreService.GetVariationValue(ctx, plan.serviceVersion.ID, featureVersionID, existingKey.ID, existingValue.ID)
+			if err != nil {
+				return err
+			}
+
+			existingData, err = s.secretService.Open(keyRow.ValueTypeKind, variationValue.Data)
+			if err != nil {
+				return err
+			}
+		}
+
+		if existingData == v.Data && existingValue.Rollout.Equal(v.Rollout) {
+			continue
+		}
+
+		if plan.user.GetPermissionForValue(plan.serviceVersion.ServiceID, featureVersion.FeatureID, keyRow.ID, v.VariationID) < constants.PermissionEditor {
+			return core.NewServiceError(core.ErrorCodePermissionDenied, fmt.Sprintf("You are not authorized to edit the value with variation [%s] of this key", variationString(v.Variation)))
+		}
+
+		oldData, err := s.presentData(plan, keyRow.ValueTypeKind, existingData)
+		if err != nil {
+			return err
+		}
+
+		plan.add(ImportChangeDto{
+			Kind:       ImportChangeKindUpdateValue,
+			Feature:    featureName,
+			Key:        &keyManifest.Name,
+			Variation:  v.Variation,
+			OldData:    oldData,
+			NewData:    newData,
+			OldRollout: existingValue.Rollout,
+			NewRollout: v.Rollout,
+		}, func(ctx context.Context) error {
+			_, err := s.valueService.UpdateValue(ctx, value.UpdateValueParams{
+				ServiceVersionID: plan.serviceVersion.ID,
+				FeatureVersionID: featureVersionID,
+				KeyID:            existingKey.ID,
+				ValueID:          existingValue.ID,
+				Data:             v.Data,
+				Variation:        v.VariationID,
+				Rollout:          v.Rollout,
+				ClearRollout:     v.Rollout == nil,
+			})
+
+			return err
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package membership
+
+import (
+	"context"
+	"time"
+
+	"github.com/necroskillz/config-service/auth"
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/core"
+)
+
+type ApiTokenDto struct {
+	ID          uint             `json:"id" validate:"required"`
+	CreatedAt   time.Time        `json:"createdAt" validate:"required"`
+	Name        string           `json:"name" validate:"required"`
+	TokenPrefix string           `json:"tokenPrefix" validate:"required"`
+	Scope       db.ApiTokenScope `json:"scope" validate:"required"`
+	ExpiresAt   *time.Time       `json:"expiresAt"`
+	LastUsedAt  *time.Time       `json:"lastUsedAt"`
+	RevokedAt   *time.Time       `json:"revokedAt"`
+}
+
+// CreatedApiTokenDto contains the plain text token, which is only returned when the token is created
+type CreatedApiTokenDto struct {
+	ID    uint   `json:"id" validate:"required"`
+	Token string `json:"token" validate:"required"`
+}
+
+// validateManageApiTokens checks that the current user can manage tokens of the user. Users manage their own tokens,
+// global administrators manage tokens of service accounts and can inspect and revoke tokens of anyone.
+func (s *Service) validateManageApiTokens(ctx context.Context, userID uint, create bool) error {
+	currentUser := auth.GetUserFromContext(ctx)
+
+	user, err := s.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return core.NewDbError(err, "User")
+	}
+
+	if currentUser.ID == user.ID {
+		return nil
+	}
+
+	if currentUser.IsGlobalAdmin && (user.ServiceAccount || !create) {
+		return nil
+	}
+
+	return core.NewServiceError(core.ErrorCodePermissionDenied, "You are not authorized to manage API tokens of this user")
+}
+
+func (s *Service) GetApiTokens(ctx context.Context, userID uint) ([]ApiTokenDto, error) {
+	if err := s.validateManageApiTokens(ctx, userID, false); err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.queries.GetApiTokens(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ApiTokenDto, len(tokens))
+	for i, token := range tokens {
+		result[i] = ApiTokenDto{
+			ID:          token.ID,
+			CreatedAt:   token.CreatedAt,
+			Name:        token.Name,
+			TokenPrefix: token.TokenPrefix,
+			Scope:       token.Scope,
+			ExpiresAt:   token.ExpiresAt,
+			LastUsedAt:  token.LastUsedAt,
+			RevokedAt:   token.RevokedAt,
+		}
+	}
+
+	return result, nil
+}
+
+type CreateApiTokenParams struct {
+	UserID    uint
+	Name      string
+	Scope     db.ApiTokenScope
+	ExpiresAt *time.Time
+}
+
+func (s *Service) validateCreateApiToken(ctx context.Context, params CreateApiTokenParams) error {
+	if err := s.validateManageApiTokens(ctx, params.UserID, true); err != nil {
+		return err
+	}
+
+	if err := s.validator.
+		Validate(params.Name, "Name").Required().MaxLength(100).
+		Error(ctx); err != nil {
+		return err
+	}
+
+	if params.Scope != db.ApiTokenScopeRead && params.Scope != db.ApiTokenScopeWrite {
+		return core.NewServiceError(core.ErrorCodeInvalidInput, "Scope must be read or write")
+	}
+
+	if params.ExpiresAt != nil && !params.ExpiresAt.After(time.Now()) {
+		return core.NewServiceError(core.ErrorCodeInvalidInput, "Expiration must be in the future")
+	}
+
+	return nil
+}
+
+func (s *Service) CreateApiToken(ctx context.Context, params CreateApiTokenParams) (CreatedApiTokenDto, error) {
+	if err := s.validateCreateApiToken(ctx, params); err != nil {
+		return CreatedApiTokenDto{}, err
+	}
+
+	token, displayPrefix, err := auth.GenerateApiToken()
+	if err != nil {
+		return CreatedApiTokenDto{}, core.NewServiceError(core.ErrorCodeUnexpectedError, "Failed to generate API token")
+	}
+
+	tokenID, err := s.queries.CreateApiToken(ctx, db.CreateApiTokenParams{
+		UserID:      params.UserID,
+		Name:        params.Name,
+		TokenHash:   auth.HashApiToken(token),
+		TokenPrefix: displayPrefix,
+		Scope:       params.Scope,
+		ExpiresAt:   params.ExpiresAt,
+	})
+	if err != nil {
+		return CreatedApiTokenDto{}, err
+	}
+
+	return CreatedApiTokenDto{
+		ID:    tokenID,
+		Token: token,
+	}, nil
+}
+
+func (s *Service) RevokeApiToken(ctx context.Context, userID uint, tokenID uint) error {
+	if err := s.validateManageApiTokens(ctx, userID, false); err != nil {
+		return err
+	}
+
+	token, err := s.queries.GetApiToken(ctx, tokenID)
+	if err != nil {
+		return core.NewDbError(err, "ApiToken")
+	}
+
+	if token.UserID != userID {
+		return core.NewServiceError(core.ErrorCodeRecordNotFound, "ApiToken not found")
+	}
+
+	return s.queries.RevokeApiToken(ctx, tokenID)
+}
+
+type CreateServiceAccountParams struct {
+	Name string
+}
+
+func (s *Service) validateCreateServiceAccount(ctx context.Context, data CreateServiceAccountParams) error {
+	user := auth.GetUserFromContext(ctx)
+	if !user.IsGlobalAdmin {
+		return core.NewServiceError(core.ErrorCodePermissionDenied, "You are not authorized to create a service account")
+	}
+
+	if err := s.validator.
+		Validate(data.Name, "Name").Required().MinLength(1).MaxLength(100).Regex(`^[\w\-_\.]+$`).
+		Error(ctx); err != nil {
+		return err
+	}
+
+	if taken, err := s.validationService.IsUsernameTaken(ctx, data.Name); err != nil {
+		return err
+	} else if taken {
+		return core.NewServiceError(core.ErrorCodeInvalidInput, "Username already exists")
+	}
+
+	return nil
+}
+
+// CreateServiceAccount creates a user that cannot log in with a password and authenticates only with API tokens.
+// Permissions are granted to service accounts the same way as to other users.
+func (s *Service) CreateServiceAccount(ctx context.Context, params CreateServiceAccountParams) (uint, error) {
+	if err := s.validateCreateServiceAccount(ctx, params); err != nil {
+		return 0, err
+	}
+
+	return s.queries.CreateServiceAccount(ctx, params.Name)
+}
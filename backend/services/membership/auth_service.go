@@ -3,6 +3,7 @@ package membership
 import (
 	"context"
 
+	"github.com/necroskillz/config-service/auth"
 	"github.com/necroskillz/config-service/constants"
 	"github.com/necroskillz/config-service/db"
 	"github.com/necroskillz/config-service/services/core"
@@ -29,6 +30,11 @@ func (s *AuthService) Authenticate(ctx context.Context, name, password string) (
 		return 0, core.NewDbError(err, "User")
 	}
 
+	// service accounts can only authenticate with API tokens
+	if user.ServiceAccount {
+		return 0, core.NewServiceError(core.ErrorCodeInvalidPassword, "Invalid password")
+	}
+
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
 	if err != nil {
 		return 0, core.NewServiceError(core.ErrorCodeInvalidPassword, "Invalid password")
@@ -37,10 +43,32 @@ func (s *AuthService) Authenticate(ctx context.Context, name, password string) (
 	return user.ID, nil
 }
 
+type ApiTokenAuthentication struct {
+	UserID uint
+	Scope  db.ApiTokenScope
+}
+
+func (s *AuthService) AuthenticateApiToken(ctx context.Context, token string) (ApiTokenAuthentication, error) {
+	apiToken, err := s.queries.GetActiveApiTokenByHash(ctx, auth.HashApiToken(token))
+	if err != nil {
+		return ApiTokenAuthentication{}, core.NewDbError(err, "ApiToken")
+	}
+
+	if err := s.queries.TouchApiToken(ctx, apiToken.ID); err != nil {
+		return ApiTokenAuthentication{}, err
+	}
+
+	return ApiTokenAuthentication{
+		UserID: apiToken.UserID,
+		Scope:  apiToken.Scope,
+	}, nil
+}
+
 type User struct {
 	ID                  uint
 	Username            string
 	GlobalAdministrator bool
+	RevealSecrets       bool
 	Permissions         []Permission
 }
 
@@ -100,6 +128,7 @@ func (s *AuthService) GetUser(ctx context.Context, id uint) (User, error) {
 		ID:                  dbUser.ID,
 		Username:            dbUser.Name,
 		GlobalAdministrator: dbUser.GlobalAdministrator,
+		RevealSecrets:       dbUser.RevealSecrets,
 		Permissions:         permissions,
 	}, nil
 }
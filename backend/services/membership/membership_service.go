@@ -131,6 +131,8 @@ type PermissionDto struct {
 type UserDto struct {
 	Username            string          `json:"username" validate:"required"`
 	GlobalAdministrator bool            `json:"globalAdministrator" validate:"required"`
+	RevealSecrets       bool            `json:"revealSecrets" validate:"required"`
+	ServiceAccount      bool            `json:"serviceAccount" validate:"required"`
 	Groups              []UserGroupDto  `json:"groups" validate:"required"`
 	Permissions         []PermissionDto `json:"permissions" validate:"required"`
 }
@@ -215,6 +217,8 @@ func (s *Service) GetUser(ctx context.Context, userID uint) (UserDto, error) {
 	return UserDto{
 		Username:            user.Name,
 		GlobalAdministrator: user.GlobalAdministrator,
+		RevealSecrets:       user.RevealSecrets,
+		ServiceAccount:      user.ServiceAccount,
 		Groups:              userGroups,
 		Permissions:         userPermissions,
 	}, nil
@@ -222,6 +226,7 @@ func (s *Service) GetUser(ctx context.Context, userID uint) (UserDto, error) {
 
 type UpdateUserParams struct {
 	GlobalAdministrator bool
+	RevealSecrets       bool
 }
 
 func (s *Service) validateUpdateUser(ctx context.Context, userID uint) error {
@@ -246,6 +251,7 @@ func (s *Service) UpdateUser(ctx context.Context, userID uint, params UpdateUser
 	return s.queries.UpdateUser(ctx, db.UpdateUserParams{
 		ID:                  userID,
 		GlobalAdministrator: params.GlobalAdministrator,
+		RevealSecrets:       params.RevealSecrets,
 	})
 }
 
@@ -253,6 +259,7 @@ type CreateUserParams struct {
 	Username            string
 	Password            string
 	GlobalAdministrator bool
+	RevealSecrets       bool
 }
 
 func (s *Service) validateCreateUser(ctx context.Context, data CreateUserParams) error {
@@ -291,6 +298,7 @@ func (s *Service) CreateUser(ctx context.Context, params CreateUserParams) (uint
 		Name:                params.Username,
 		Password:            string(passwordHash),
 		GlobalAdministrator: params.GlobalAdministrator,
+		RevealSecrets:       params.RevealSecrets,
 	})
 	if err != nil {
 		return 0, err
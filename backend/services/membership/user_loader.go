@@ -0,0 +1,69 @@
+package membership
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/necroskillz/config-service/auth"
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/changeset"
+	"github.com/necroskillz/config-service/services/variation"
+)
+
+// UserLoader builds the user with permissions that is stored in the context of authenticated requests and background jobs
+type UserLoader struct {
+	authService               *AuthService
+	variationHierarchyService *variation.HierarchyService
+	changesetService          *changeset.Service
+}
+
+func NewUserLoader(authService *AuthService, variationHierarchyService *variation.HierarchyService, changesetService *changeset.Service) *UserLoader {
+	return &UserLoader{
+		authService:               authService,
+		variationHierarchyService: variationHierarchyService,
+		changesetService:          changesetService,
+	}
+}
+
+func (l *UserLoader) LoadUser(ctx context.Context, userID uint) (*auth.User, error) {
+	user, err := l.authService.GetUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	variationHierarchy, err := l.variationHierarchyService.GetVariationHierarchy(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variation hierarchy: %w", err)
+	}
+
+	changesetId, err := l.changesetService.GetOpenChangesetIDForUser(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open changeset for user: %w", err)
+	}
+
+	userBuilder := auth.NewUserBuilder(variationHierarchy)
+	userBuilder.WithBasicInfo(user.ID, user.Username, user.GlobalAdministrator)
+	userBuilder.WithRevealSecrets(user.RevealSecrets)
+	userBuilder.WithChangesetID(changesetId)
+
+	for _, permission := range user.Permissions {
+		userBuilder.WithPermission(permission.ServiceID, permission.FeatureID, permission.KeyID, permission.Variation, permission.Permission)
+	}
+
+	return userBuilder.User(), nil
+}
+
+// LoadUserForApiToken loads the user that owns the API token, the scope of the token is enforced by the caller
+func (l *UserLoader) LoadUserForApiToken(ctx context.Context, token string) (*auth.User, db.ApiTokenScope, error) {
+	authentication, err := l.authService.AuthenticateApiToken(ctx, token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	user, err := l.LoadUser(ctx, authentication.UserID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return user, authentication.Scope, nil
+}
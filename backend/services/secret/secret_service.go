@@ -0,0 +1,122 @@
+package secret
+
+import (
+	"context"
+
+	"github.com/necroskillz/config-service/auth"
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/core"
+	"github.com/necroskillz/config-service/util/envelope"
+)
+
+// RedactedData replaces the data of secret values for callers that are not allowed to see it
+const RedactedData = "********"
+
+// Service encrypts the data of secret values before it is stored and decides who gets to see it decrypted
+type Service struct {
+	encryptor           *envelope.Encryptor
+	currentUserAccessor *auth.CurrentUserAccessor
+}
+
+// NewService creates the service, without an encryptor secret values cannot be stored or decrypted
+func NewService(encryptor *envelope.Encryptor, currentUserAccessor *auth.CurrentUserAccessor) *Service {
+	return &Service{
+		encryptor:           encryptor,
+		currentUserAccessor: currentUserAccessor,
+	}
+}
+
+func (s *Service) getEncryptor() (*envelope.Encryptor, error) {
+	if s.encryptor == nil {
+		return nil, core.NewServiceError(core.ErrorCodeInvalidOperation, "Secret values are not available, SECRET_ENCRYPTION_KEY is not configured")
+	}
+
+	return s.encryptor, nil
+}
+
+// Seal returns the data to store for a value of the kind. Data of secret values is encrypted, unless one of the
+// existing stored values has the same data, in which case it is reused, so unchanged values compare equal.
+// Redacted data sent back unchanged keeps the first existing stored value.
+func (s *Service) Seal(kind db.ValueTypeKind, data string, existing ...string) (string, error) {
+	if kind != db.ValueTypeKindSecret {
+		return data, nil
+	}
+
+	encryptor, err := s.getEncryptor()
+	if err != nil {
+		return "", err
+	}
+
+	if data == RedactedData && len(existing) > 0 && existing[0] != "" {
+		return existing[0], nil
+	}
+
+	for _, existingData := range existing {
+		if existingData == "" {
+			continue
+		}
+
+		if opened, err := s.Open(kind, existingData); err == nil && opened == data {
+			return existingData, nil
+		}
+	}
+
+	sealed, err := encryptor.Seal(data)
+	if err != nil {
+		return "", core.NewServiceError(core.ErrorCodeUnexpectedError, "Failed to encrypt secret value").WithErr(err)
+	}
+
+	return sealed, nil
+}
+
+// Open returns the decrypted data of a stored value of the kind
+func (s *Service) Open(kind db.ValueTypeKind, data string) (string, error) {
+	if kind != db.ValueTypeKindSecret || !envelope.IsSealed(data) {
+		return data, nil
+	}
+
+	encryptor, err := s.getEncryptor()
+	if err != nil {
+		return "", err
+	}
+
+	opened, err := encryptor.Open(data)
+	if err != nil {
+		return "", core.NewServiceError(core.ErrorCodeUnexpectedError, "Failed to decrypt secret value").WithErr(err)
+	}
+
+	return opened, nil
+}
+
+// CanReveal returns true if the current user is allowed to see decrypted secret values
+func (s *Service) CanReveal(ctx context.Context) bool {
+	return s.currentUserAccessor.GetUser(ctx).CanRevealSecrets
+}
+
+// Present returns the data of a stored value of the kind as it is returned to the caller. Secret values are decrypted
+// if reveal is set and redacted otherwise.
+func (s *Service) Present(kind db.ValueTypeKind, data string, reveal bool) (string, error) {
+	if kind != db.ValueTypeKindSecret {
+		return data, nil
+	}
+
+	if !reveal {
+		return RedactedData, nil
+	}
+
+	return s.Open(kind, data)
+}
+
+// PresentOptional is Present for data that may be missing
+func (s *Service) PresentOptional(kind db.ValueTypeKind, data *string, reveal bool) (*string, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	presented, err := s.Present(kind, *data, reveal)
+	if err != nil {
+		return nil, err
+	}
+
+	return &presented, nil
+}
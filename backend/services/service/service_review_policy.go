@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/core"
+)
+
+type ReviewPolicyDto struct {
+	RequiredApprovals     int     `json:"requiredApprovals" validate:"required"`
+	AllowSelfApproval     bool    `json:"allowSelfApproval" validate:"required"`
+	RequiredUserGroupID   *uint   `json:"requiredUserGroupId"`
+	RequiredUserGroupName *string `json:"requiredUserGroupName"`
+}
+
+func (s *Service) GetReviewPolicy(ctx context.Context, serviceVersionID uint) (ReviewPolicyDto, error) {
+	serviceVersion, err := s.coreService.GetServiceVersion(ctx, serviceVersionID)
+	if err != nil {
+		return ReviewPolicyDto{}, err
+	}
+
+	policy, err := s.queries.GetServiceReviewPolicy(ctx, serviceVersion.ServiceID)
+	if err != nil {
+		return ReviewPolicyDto{}, core.NewDbError(err, "ReviewPolicy")
+	}
+
+	return ReviewPolicyDto{
+		RequiredApprovals:     policy.RequiredApprovals,
+		AllowSelfApproval:     policy.AllowSelfApproval,
+		RequiredUserGroupID:   policy.RequiredUserGroupID,
+		RequiredUserGroupName: policy.RequiredUserGroupName,
+	}, nil
+}
+
+type UpdateReviewPolicyParams struct {
+	ServiceVersionID    uint
+	RequiredApprovals   int
+	AllowSelfApproval   bool
+	RequiredUserGroupID *uint
+}
+
+func (s *Service) validateUpdateReviewPolicy(ctx context.Context, data UpdateReviewPolicyParams) error {
+	err := s.validator.
+		Validate(data.RequiredApprovals, "Required Approvals").Min(1).Max(10).
+		Error(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	user := s.currentUserAccessor.GetUser(ctx)
+
+	// review policies restrict service admins, so only global administrators can change them
+	if !user.IsGlobalAdmin {
+		return core.NewServiceError(core.ErrorCodePermissionDenied, "You are not authorized to change review policies")
+	}
+
+	if data.RequiredUserGroupID != nil {
+		if _, err := s.queries.GetGroupByID(ctx, *data.RequiredUserGroupID); err != nil {
+			return core.NewDbError(err, "Group")
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) UpdateReviewPolicy(ctx context.Context, data UpdateReviewPolicyParams) error {
+	serviceVersion, err := s.coreService.GetServiceVersion(ctx, data.ServiceVersionID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateUpdateReviewPolicy(ctx, data); err != nil {
+		return err
+	}
+
+	return s.queries.UpsertServiceReviewPolicy(ctx, db.UpsertServiceReviewPolicyParams{
+		ServiceID:           serviceVersion.ServiceID,
+		RequiredApprovals:   data.RequiredApprovals,
+		AllowSelfApproval:   data.AllowSelfApproval,
+		RequiredUserGroupID: data.RequiredUserGroupID,
+	})
+}
+
+func (s *Service) DeleteReviewPolicy(ctx context.Context, serviceVersionID uint) error {
+	serviceVersion, err := s.coreService.GetServiceVersion(ctx, serviceVersionID)
+	if err != nil {
+		return err
+	}
+
+	user := s.currentUserAccessor.GetUser(ctx)
+
+	if !user.IsGlobalAdmin {
+		return core.NewServiceError(core.ErrorCodePermissionDenied, "You are not authorized to change review policies")
+	}
+
+	return s.queries.DeleteServiceReviewPolicy(ctx, serviceVersion.ServiceID)
+}
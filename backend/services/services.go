@@ -1,16 +1,26 @@
 package services
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
 	"github.com/dgraph-io/ristretto/v2"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/necroskillz/config-service/auth"
 	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/cacheinvalidation"
 	"github.com/necroskillz/config-service/services/changeset"
+	"github.com/necroskillz/config-service/services/clientcredential"
 	"github.com/necroskillz/config-service/services/configuration"
+	"github.com/necroskillz/config-service/services/constraint"
 	"github.com/necroskillz/config-service/services/core"
 	"github.com/necroskillz/config-service/services/feature"
 	"github.com/necroskillz/config-service/services/key"
+	"github.com/necroskillz/config-service/services/manifest"
 	"github.com/necroskillz/config-service/services/membership"
+	"github.com/necroskillz/config-service/services/secret"
 	"github.com/necroskillz/config-service/services/service"
 	"github.com/necroskillz/config-service/services/servicetype"
 	"github.com/necroskillz/config-service/services/validation"
@@ -18,6 +28,8 @@ import (
 	"github.com/necroskillz/config-service/services/valuetype"
 	"github.com/necroskillz/config-service/services/variation"
 	"github.com/necroskillz/config-service/services/variationproperty"
+	"github.com/necroskillz/config-service/services/webhook"
+	"github.com/necroskillz/config-service/util/envelope"
 	"github.com/necroskillz/config-service/util/validator"
 )
 
@@ -39,11 +51,49 @@ type Services struct {
 	VariationContextService   *variation.ContextService
 	ValidationService         *validation.Service
 	ChangesetService          *changeset.Service
+	ChangesetEventBroker      *changeset.EventBroker
+	ChangesetScheduler        *changeset.Scheduler
+	CacheInvalidationService  *cacheinvalidation.Service
+	CacheInvalidationListener *cacheinvalidation.Listener
 	MembershipService         *membership.Service
+	UserLoader                *membership.UserLoader
+	WebhookService            *webhook.Service
+	WebhookDispatcher         *webhook.Dispatcher
+	ClientCredentialService   *clientcredential.Service
+	ManifestService           *manifest.Service
+	SecretService             *secret.Service
+	ConstraintService         *constraint.Service
+}
+
+// newSecretEncryptor creates the encryptor of secret values from the SECRET_ENCRYPTION_KEY, secret values are not
+// available if it is not configured. An invalid key is an error, stored secret values could not be decrypted with it.
+func newSecretEncryptor() (*envelope.Encryptor, error) {
+	encodedKey := os.Getenv("SECRET_ENCRYPTION_KEY")
+	if encodedKey == "" {
+		slog.Warn("SECRET_ENCRYPTION_KEY is not configured, secret values are not available")
+		return nil, nil
+	}
+
+	key, err := envelope.ParseKey(encodedKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SECRET_ENCRYPTION_KEY: %w", err)
+	}
+
+	encryptor, err := envelope.NewEncryptor(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SECRET_ENCRYPTION_KEY: %w", err)
+	}
+
+	return encryptor, nil
 }
 
-func InitializeServices(dbpool *pgxpool.Pool, cache *ristretto.Cache[string, any]) *Services {
-	queries := db.New(dbpool)
+func InitializeServices(dbpool *pgxpool.Pool, cache *ristretto.Cache[string, any]) (*Services, error) {
+	secretEncryptor, err := newSecretEncryptor()
+	if err != nil {
+		return nil, err
+	}
+
+	queries := db.New(db.NewContextDBTX(dbpool))
 	currentUserAccessor := auth.NewCurrentUserAccessor()
 
 	unitOfWorkRunner := db.NewPgxUnitOfWorkRunner(dbpool, queries)
@@ -51,19 +101,38 @@ func InitializeServices(dbpool *pgxpool.Pool, cache *ristretto.Cache[string, any
 	validator := validator.New()
 	valueTypeService := valuetype.NewService(queries, valueValidatorService)
 	coreService := core.NewService(queries, currentUserAccessor)
-	variationHierarchyService := variation.NewHierarchyService(queries, cache)
+	secretService := secret.NewService(secretEncryptor, currentUserAccessor)
+	cacheInvalidationService := cacheinvalidation.NewService(queries)
+	variationHierarchyService := variation.NewHierarchyService(queries, cache, cacheInvalidationService)
 	variationContextService := variation.NewContextService(queries, variationHierarchyService, unitOfWorkRunner, cache)
 	validationService := validation.NewService(queries, variationContextService, variationHierarchyService, currentUserAccessor, coreService)
 	serviceTypeService := servicetype.NewService(unitOfWorkRunner, queries, validator, validationService, currentUserAccessor, variationHierarchyService)
-	changesetService := changeset.NewService(queries, variationContextService, unitOfWorkRunner, currentUserAccessor, validator)
+	changesetEventBroker := changeset.NewEventBroker()
+	constraintService := constraint.NewService(queries, currentUserAccessor, validator, coreService, variationContextService, variationHierarchyService, secretService)
+	changesetService := changeset.NewService(queries, variationContextService, unitOfWorkRunner, currentUserAccessor, validator, changesetEventBroker, cacheInvalidationService, secretService, constraintService)
 	serviceService := service.NewService(queries, unitOfWorkRunner, changesetService, currentUserAccessor, validator, coreService, validationService)
 	authService := membership.NewAuthService(queries, variationContextService, validationService, validator)
 	featureService := feature.NewService(unitOfWorkRunner, queries, changesetService, currentUserAccessor, validator, coreService, validationService)
-	keyService := key.NewService(unitOfWorkRunner, variationContextService, queries, changesetService, currentUserAccessor, validator, coreService, valueValidatorService, variationHierarchyService, validationService)
-	valueService := value.NewService(unitOfWorkRunner, variationContextService, variationHierarchyService, queries, changesetService, currentUserAccessor, validator, coreService, validationService, valueValidatorService)
+	keyService := key.NewService(unitOfWorkRunner, variationContextService, queries, changesetService, currentUserAccessor, validator, coreService, valueValidatorService, variationHierarchyService, validationService, secretService)
+	valueService := value.NewService(unitOfWorkRunner, variationContextService, variationHierarchyService, queries, changesetService, currentUserAccessor, validator, coreService, validationService, valueValidatorService, secretService)
 	variationPropertyService := variationproperty.NewService(queries, variationHierarchyService, validator, validationService, currentUserAccessor, unitOfWorkRunner)
-	configurationService := configuration.NewService(queries, variationContextService, variationHierarchyService)
+	configurationService := configuration.NewService(queries, variationContextService, variationHierarchyService, secretService)
 	membershipService := membership.NewService(queries, variationContextService, validationService, variationHierarchyService, validator, coreService)
+	userLoader := membership.NewUserLoader(authService, variationHierarchyService, changesetService)
+	changesetScheduler := changeset.NewScheduler(changesetService, userLoader.LoadUser)
+	webhookService := webhook.NewService(queries, currentUserAccessor, validator, coreService)
+	webhookDispatcher := webhook.NewDispatcher(queries)
+	clientCredentialService := clientcredential.NewService(queries, unitOfWorkRunner, currentUserAccessor, validator, validationService, coreService)
+	manifestService := manifest.NewService(coreService, featureService, keyService, valueService, valueTypeService, valueValidatorService, validationService, variationHierarchyService, validator, secretService, currentUserAccessor, unitOfWorkRunner)
+	cacheInvalidationListener := cacheinvalidation.NewListener(dbpool, cacheInvalidationService, cacheinvalidation.ListenerHandlers{
+		OnVariationChanged: func(ctx context.Context) {
+			variationHierarchyService.ClearLocalCache()
+			variationContextService.ClearLocalCache()
+		},
+		OnChangesetApplied: func(ctx context.Context, changesetID uint) {
+			changesetEventBroker.Publish(changeset.AppliedEvent{ChangesetID: changesetID})
+		},
+	})
 
 	return &Services{
 		ValueService:              valueService,
@@ -83,6 +152,17 @@ func InitializeServices(dbpool *pgxpool.Pool, cache *ristretto.Cache[string, any
 		VariationContextService:   variationContextService,
 		ValidationService:         validationService,
 		ChangesetService:          changesetService,
+		ChangesetEventBroker:      changesetEventBroker,
+		ChangesetScheduler:        changesetScheduler,
+		CacheInvalidationService:  cacheInvalidationService,
+		CacheInvalidationListener: cacheInvalidationListener,
 		MembershipService:         membershipService,
-	}
+		UserLoader:                userLoader,
+		WebhookService:            webhookService,
+		WebhookDispatcher:         webhookDispatcher,
+		ClientCredentialService:   clientCredentialService,
+		ManifestService:           manifestService,
+		SecretService:             secretService,
+		ConstraintService:         constraintService,
+	}, nil
 }
@@ -251,3 +251,16 @@ func (s *Service) IsGroupNameTaken(ctx context.Context, name string) (bool, erro
 
 	return true, nil
 }
+
+func (s *Service) IsClientCredentialNameTaken(ctx context.Context, name string) (bool, error) {
+	_, err := s.queries.GetClientCredentialByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
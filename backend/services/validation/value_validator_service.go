@@ -2,8 +2,10 @@ package validation
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/necroskillz/config-service/db"
 	"github.com/necroskillz/config-service/util/ptr"
@@ -18,8 +20,14 @@ const (
 	ValueValidatorParameterTypeFloat      ValueValidatorParameterType = "float"
 	ValueValidatorParameterTypeRegex      ValueValidatorParameterType = "regex"
 	ValueValidatorParameterTypeJsonSchema ValueValidatorParameterType = "json_schema"
+	ValueValidatorParameterTypeStringList ValueValidatorParameterType = "string_list"
+	ValueValidatorParameterTypeDuration   ValueValidatorParameterType = "duration"
+	ValueValidatorParameterTypeExpression ValueValidatorParameterType = "expression"
 )
 
+// StringListParameterSchema is the JSON schema of string list parameters, which are a JSON array of distinct strings
+const StringListParameterSchema = `{"type":"array","items":{"type":"string","minLength":1},"minItems":1,"uniqueItems":true}`
+
 type ValidatorDto struct {
 	ValidatorType db.ValueValidatorType `json:"validatorType" validate:"required"`
 	Parameter     string                `json:"parameter" validate:"required"`
@@ -36,6 +44,21 @@ func NewValidatorDto(validator db.ValueValidator) ValidatorDto {
 	}
 }
 
+// expressionValueTypes is the CEL type of the value of each kind, see validator.CompileExpression
+var expressionValueTypes = map[db.ValueTypeKind]validator.ExpressionValueType{
+	db.ValueTypeKindString:      validator.ExpressionValueTypeString,
+	db.ValueTypeKindInteger:     validator.ExpressionValueTypeInt,
+	db.ValueTypeKindDecimal:     validator.ExpressionValueTypeDouble,
+	db.ValueTypeKindBoolean:     validator.ExpressionValueTypeBool,
+	db.ValueTypeKindJson:        validator.ExpressionValueTypeDyn,
+	db.ValueTypeKindEnum:        validator.ExpressionValueTypeString,
+	db.ValueTypeKindSecret:      validator.ExpressionValueTypeString,
+	db.ValueTypeKindStringList:  validator.ExpressionValueTypeStringList,
+	db.ValueTypeKindIntegerList: validator.ExpressionValueTypeIntList,
+	db.ValueTypeKindDuration:    validator.ExpressionValueTypeDuration,
+	db.ValueTypeKindUrl:         validator.ExpressionValueTypeString,
+}
+
 type ValueValidatorService struct {
 	allowedKeyValidators    map[db.ValueTypeKind][]db.ValueValidatorType
 	valueValidators         map[db.ValueValidatorType]ValueValidatorFunc
@@ -46,27 +69,43 @@ type ValueValidatorService struct {
 func NewValueValidatorService(queries *db.Queries) *ValueValidatorService {
 	s := &ValueValidatorService{
 		allowedKeyValidators: map[db.ValueTypeKind][]db.ValueValidatorType{
-			db.ValueTypeKindString:  {db.ValueValidatorTypeRequired, db.ValueValidatorTypeMinLength, db.ValueValidatorTypeMaxLength, db.ValueValidatorTypeRegex, db.ValueValidatorTypeValidRegex},
-			db.ValueTypeKindInteger: {db.ValueValidatorTypeMin, db.ValueValidatorTypeMax, db.ValueValidatorTypeRegex},
-			db.ValueTypeKindDecimal: {db.ValueValidatorTypeMinDecimal, db.ValueValidatorTypeMaxDecimal, db.ValueValidatorTypeRegex},
-			db.ValueTypeKindBoolean: {},
-			db.ValueTypeKindJson:    {db.ValueValidatorTypeRegex, db.ValueValidatorTypeJsonSchema},
+			db.ValueTypeKindString:      {db.ValueValidatorTypeRequired, db.ValueValidatorTypeMinLength, db.ValueValidatorTypeMaxLength, db.ValueValidatorTypeRegex, db.ValueValidatorTypeValidRegex, db.ValueValidatorTypeEnum, db.ValueValidatorTypeCel},
+			db.ValueTypeKindInteger:     {db.ValueValidatorTypeMin, db.ValueValidatorTypeMax, db.ValueValidatorTypeRegex, db.ValueValidatorTypeCel},
+			db.ValueTypeKindDecimal:     {db.ValueValidatorTypeMinDecimal, db.ValueValidatorTypeMaxDecimal, db.ValueValidatorTypeRegex, db.ValueValidatorTypeCel},
+			db.ValueTypeKindBoolean:     {db.ValueValidatorTypeCel},
+			db.ValueTypeKindJson:        {db.ValueValidatorTypeRegex, db.ValueValidatorTypeJsonSchema, db.ValueValidatorTypeCel},
+			db.ValueTypeKindEnum:        {db.ValueValidatorTypeEnum, db.ValueValidatorTypeCel},
+			db.ValueTypeKindSecret:      {db.ValueValidatorTypeRequired, db.ValueValidatorTypeMinLength, db.ValueValidatorTypeMaxLength, db.ValueValidatorTypeRegex, db.ValueValidatorTypeCel},
+			db.ValueTypeKindStringList:  {db.ValueValidatorTypeMinItems, db.ValueValidatorTypeMaxItems, db.ValueValidatorTypeCel},
+			db.ValueTypeKindIntegerList: {db.ValueValidatorTypeMinItems, db.ValueValidatorTypeMaxItems, db.ValueValidatorTypeCel},
+			db.ValueTypeKindDuration:    {db.ValueValidatorTypeMinDuration, db.ValueValidatorTypeMaxDuration, db.ValueValidatorTypeCel},
+			db.ValueTypeKindUrl:         {db.ValueValidatorTypeRegex, db.ValueValidatorTypeCel},
 		},
 		valueValidators: map[db.ValueValidatorType]ValueValidatorFunc{},
 		validatorParameterTypes: map[db.ValueValidatorType]ValueValidatorParameterType{
-			db.ValueValidatorTypeRequired:     ValueValidatorParameterTypeNone,
-			db.ValueValidatorTypeMin:          ValueValidatorParameterTypeInteger,
-			db.ValueValidatorTypeMax:          ValueValidatorParameterTypeInteger,
-			db.ValueValidatorTypeMinDecimal:   ValueValidatorParameterTypeFloat,
-			db.ValueValidatorTypeMaxDecimal:   ValueValidatorParameterTypeFloat,
-			db.ValueValidatorTypeMinLength:    ValueValidatorParameterTypeInteger,
-			db.ValueValidatorTypeMaxLength:    ValueValidatorParameterTypeInteger,
-			db.ValueValidatorTypeRegex:        ValueValidatorParameterTypeRegex,
-			db.ValueValidatorTypeJsonSchema:   ValueValidatorParameterTypeJsonSchema,
-			db.ValueValidatorTypeValidJson:    ValueValidatorParameterTypeNone,
-			db.ValueValidatorTypeValidInteger: ValueValidatorParameterTypeNone,
-			db.ValueValidatorTypeValidDecimal: ValueValidatorParameterTypeNone,
-			db.ValueValidatorTypeValidRegex:   ValueValidatorParameterTypeNone,
+			db.ValueValidatorTypeRequired:         ValueValidatorParameterTypeNone,
+			db.ValueValidatorTypeMin:              ValueValidatorParameterTypeInteger,
+			db.ValueValidatorTypeMax:              ValueValidatorParameterTypeInteger,
+			db.ValueValidatorTypeMinDecimal:       ValueValidatorParameterTypeFloat,
+			db.ValueValidatorTypeMaxDecimal:       ValueValidatorParameterTypeFloat,
+			db.ValueValidatorTypeMinLength:        ValueValidatorParameterTypeInteger,
+			db.ValueValidatorTypeMaxLength:        ValueValidatorParameterTypeInteger,
+			db.ValueValidatorTypeRegex:            ValueValidatorParameterTypeRegex,
+			db.ValueValidatorTypeJsonSchema:       ValueValidatorParameterTypeJsonSchema,
+			db.ValueValidatorTypeValidJson:        ValueValidatorParameterTypeNone,
+			db.ValueValidatorTypeValidInteger:     ValueValidatorParameterTypeNone,
+			db.ValueValidatorTypeValidDecimal:     ValueValidatorParameterTypeNone,
+			db.ValueValidatorTypeValidRegex:       ValueValidatorParameterTypeNone,
+			db.ValueValidatorTypeEnum:             ValueValidatorParameterTypeStringList,
+			db.ValueValidatorTypeValidStringList:  ValueValidatorParameterTypeNone,
+			db.ValueValidatorTypeValidIntegerList: ValueValidatorParameterTypeNone,
+			db.ValueValidatorTypeValidDuration:    ValueValidatorParameterTypeNone,
+			db.ValueValidatorTypeValidUrl:         ValueValidatorParameterTypeNone,
+			db.ValueValidatorTypeMinItems:         ValueValidatorParameterTypeInteger,
+			db.ValueValidatorTypeMaxItems:         ValueValidatorParameterTypeInteger,
+			db.ValueValidatorTypeMinDuration:      ValueValidatorParameterTypeDuration,
+			db.ValueValidatorTypeMaxDuration:      ValueValidatorParameterTypeDuration,
+			db.ValueValidatorTypeCel:              ValueValidatorParameterTypeExpression,
 		},
 		queries: queries,
 	}
@@ -76,7 +115,7 @@ func NewValueValidatorService(queries *db.Queries) *ValueValidatorService {
 	return s
 }
 
-type ValueValidatorFunc func(param string, errorText string) (func(v *validator.Context) *validator.Context, error)
+type ValueValidatorFunc func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error)
 
 func (s *ValueValidatorService) parseIntParam(param string) (int, error) {
 	parsed, err := strconv.Atoi(param)
@@ -96,14 +135,38 @@ func (s *ValueValidatorService) parseFloatParam(param string) (float64, error) {
 	return parsed, nil
 }
 
+func (s *ValueValidatorService) parseDurationParam(param string) (time.Duration, error) {
+	parsed, err := time.ParseDuration(param)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse duration parameter: %w", err)
+	}
+
+	return parsed, nil
+}
+
+// GetExpressionValueType returns the CEL type of the value variable of cel validators for keys of the kind
+func GetExpressionValueType(valueTypeKind db.ValueTypeKind) validator.ExpressionValueType {
+	return expressionValueTypes[valueTypeKind]
+}
+
+// ParseStringListParam parses the allowed values of an enum validator
+func ParseStringListParam(param string) ([]string, error) {
+	var values []string
+	if err := json.Unmarshal([]byte(param), &values); err != nil {
+		return nil, fmt.Errorf("failed to parse string list parameter: %w", err)
+	}
+
+	return values, nil
+}
+
 func (s *ValueValidatorService) registerValueValidators() {
-	s.registerValueValidator(db.ValueValidatorTypeRequired, func(param string, errorText string) (func(v *validator.Context) *validator.Context, error) {
+	s.registerValueValidator(db.ValueValidatorTypeRequired, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
 		return func(v *validator.Context) *validator.Context {
 			return v.Required()
 		}, nil
 	})
 
-	s.registerValueValidator(db.ValueValidatorTypeMin, func(param string, errorText string) (func(v *validator.Context) *validator.Context, error) {
+	s.registerValueValidator(db.ValueValidatorTypeMin, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
 		min, err := s.parseIntParam(param)
 		if err != nil {
 			return nil, err
@@ -114,7 +177,7 @@ func (s *ValueValidatorService) registerValueValidators() {
 		}, nil
 	})
 
-	s.registerValueValidator(db.ValueValidatorTypeMax, func(param string, errorText string) (func(v *validator.Context) *validator.Context, error) {
+	s.registerValueValidator(db.ValueValidatorTypeMax, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
 		max, err := s.parseIntParam(param)
 		if err != nil {
 			return nil, err
@@ -125,7 +188,7 @@ func (s *ValueValidatorService) registerValueValidators() {
 		}, nil
 	})
 
-	s.registerValueValidator(db.ValueValidatorTypeMinDecimal, func(param string, errorText string) (func(v *validator.Context) *validator.Context, error) {
+	s.registerValueValidator(db.ValueValidatorTypeMinDecimal, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
 		min, err := s.parseFloatParam(param)
 		if err != nil {
 			return nil, err
@@ -136,7 +199,7 @@ func (s *ValueValidatorService) registerValueValidators() {
 		}, nil
 	})
 
-	s.registerValueValidator(db.ValueValidatorTypeMaxDecimal, func(param string, errorText string) (func(v *validator.Context) *validator.Context, error) {
+	s.registerValueValidator(db.ValueValidatorTypeMaxDecimal, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
 		max, err := s.parseFloatParam(param)
 		if err != nil {
 			return nil, err
@@ -147,7 +210,7 @@ func (s *ValueValidatorService) registerValueValidators() {
 		}, nil
 	})
 
-	s.registerValueValidator(db.ValueValidatorTypeMinLength, func(param string, errorText string) (func(v *validator.Context) *validator.Context, error) {
+	s.registerValueValidator(db.ValueValidatorTypeMinLength, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
 		minLength, err := s.parseIntParam(param)
 		if err != nil {
 			return nil, err
@@ -158,7 +221,7 @@ func (s *ValueValidatorService) registerValueValidators() {
 		}, nil
 	})
 
-	s.registerValueValidator(db.ValueValidatorTypeMaxLength, func(param string, errorText string) (func(v *validator.Context) *validator.Context, error) {
+	s.registerValueValidator(db.ValueValidatorTypeMaxLength, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
 		maxLength, err := s.parseIntParam(param)
 		if err != nil {
 			return nil, err
@@ -169,41 +232,136 @@ func (s *ValueValidatorService) registerValueValidators() {
 		}, nil
 	})
 
-	s.registerValueValidator(db.ValueValidatorTypeRegex, func(param string, errorText string) (func(v *validator.Context) *validator.Context, error) {
+	s.registerValueValidator(db.ValueValidatorTypeRegex, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
 		return func(v *validator.Context) *validator.Context {
 			return v.Regex(param)
 		}, nil
 	})
 
-	s.registerValueValidator(db.ValueValidatorTypeJsonSchema, func(param string, errorText string) (func(v *validator.Context) *validator.Context, error) {
+	s.registerValueValidator(db.ValueValidatorTypeJsonSchema, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
 		return func(v *validator.Context) *validator.Context {
 			return v.JsonSchema(param)
 		}, nil
 	})
 
-	s.registerValueValidator(db.ValueValidatorTypeValidJson, func(param string, errorText string) (func(v *validator.Context) *validator.Context, error) {
+	s.registerValueValidator(db.ValueValidatorTypeValidJson, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
 		return func(v *validator.Context) *validator.Context {
 			return v.ValidJson()
 		}, nil
 	})
 
-	s.registerValueValidator(db.ValueValidatorTypeValidInteger, func(param string, errorText string) (func(v *validator.Context) *validator.Context, error) {
+	s.registerValueValidator(db.ValueValidatorTypeValidInteger, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
 		return func(v *validator.Context) *validator.Context {
 			return v.ValidInteger()
 		}, nil
 	})
 
-	s.registerValueValidator(db.ValueValidatorTypeValidDecimal, func(param string, errorText string) (func(v *validator.Context) *validator.Context, error) {
+	s.registerValueValidator(db.ValueValidatorTypeValidDecimal, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
 		return func(v *validator.Context) *validator.Context {
 			return v.ValidFloat()
 		}, nil
 	})
 
-	s.registerValueValidator(db.ValueValidatorTypeValidRegex, func(param string, errorText string) (func(v *validator.Context) *validator.Context, error) {
+	s.registerValueValidator(db.ValueValidatorTypeValidRegex, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
 		return func(v *validator.Context) *validator.Context {
 			return v.ValidRegex()
 		}, nil
 	})
+
+	s.registerValueValidator(db.ValueValidatorTypeEnum, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
+		values, err := ParseStringListParam(param)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(v *validator.Context) *validator.Context {
+			return v.OneOf(values, errorText)
+		}, nil
+	})
+	s.registerValueValidator(db.ValueValidatorTypeValidStringList, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
+		return func(v *validator.Context) *validator.Context {
+			return v.ValidStringList()
+		}, nil
+	})
+
+	s.registerValueValidator(db.ValueValidatorTypeValidIntegerList, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
+		return func(v *validator.Context) *validator.Context {
+			return v.ValidIntList()
+		}, nil
+	})
+
+	s.registerValueValidator(db.ValueValidatorTypeValidDuration, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
+		return func(v *validator.Context) *validator.Context {
+			return v.ValidDuration()
+		}, nil
+	})
+
+	s.registerValueValidator(db.ValueValidatorTypeValidUrl, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
+		return func(v *validator.Context) *validator.Context {
+			return v.ValidURL()
+		}, nil
+	})
+
+	s.registerValueValidator(db.ValueValidatorTypeMinItems, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
+		minItems, err := s.parseIntParam(param)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(v *validator.Context) *validator.Context {
+			return v.MinItems(minItems)
+		}, nil
+	})
+
+	s.registerValueValidator(db.ValueValidatorTypeMaxItems, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
+		maxItems, err := s.parseIntParam(param)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(v *validator.Context) *validator.Context {
+			return v.MaxItems(maxItems)
+		}, nil
+	})
+
+	s.registerValueValidator(db.ValueValidatorTypeMinDuration, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
+		min, err := s.parseDurationParam(param)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(v *validator.Context) *validator.Context {
+			return v.MinDuration(min)
+		}, nil
+	})
+
+	s.registerValueValidator(db.ValueValidatorTypeMaxDuration, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
+		max, err := s.parseDurationParam(param)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(v *validator.Context) *validator.Context {
+			return v.MaxDuration(max)
+		}, nil
+	})
+
+	s.registerValueValidator(db.ValueValidatorTypeCel, func(param string, errorText string, valueTypeKind db.ValueTypeKind) (func(v *validator.Context) *validator.Context, error) {
+		expression, err := validator.CompileExpression(param, GetExpressionValueType(valueTypeKind))
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile CEL expression: %w", err)
+		}
+
+		if valueTypeKind == db.ValueTypeKindSecret {
+			return func(v *validator.Context) *validator.Context {
+				return v.SecretExpression(expression, errorText)
+			}, nil
+		}
+
+		return func(v *validator.Context) *validator.Context {
+			return v.Expression(expression, errorText)
+		}, nil
+	})
 }
 
 func (s *ValueValidatorService) registerValueValidator(validatorType db.ValueValidatorType, validatorFunc ValueValidatorFunc) {
@@ -224,7 +382,7 @@ type ValueValidatorParams struct {
 	ErrorText     *string
 }
 
-func (s *ValueValidatorService) CreateValueValidatorFunc(params []ValidatorDto) (validator.ValidatorFunc, error) {
+func (s *ValueValidatorService) CreateValueValidatorFunc(valueTypeKind db.ValueTypeKind, params []ValidatorDto) (validator.ValidatorFunc, error) {
 	fns := make([]validator.ValidatorFunc, len(params))
 
 	for i, param := range params {
@@ -233,7 +391,7 @@ func (s *ValueValidatorService) CreateValueValidatorFunc(params []ValidatorDto)
 			return nil, fmt.Errorf("validator type %s not found", param.ValidatorType)
 		}
 
-		fn, err := validatorFunc(param.Parameter, param.ErrorText)
+		fn, err := validatorFunc(param.Parameter, param.ErrorText, valueTypeKind)
 		if err != nil {
 			return nil, err
 		}
@@ -11,6 +11,7 @@ import (
 	"github.com/necroskillz/config-service/db"
 	"github.com/necroskillz/config-service/services/changeset"
 	"github.com/necroskillz/config-service/services/core"
+	"github.com/necroskillz/config-service/services/secret"
 	"github.com/necroskillz/config-service/services/validation"
 	"github.com/necroskillz/config-service/services/variation"
 	"github.com/necroskillz/config-service/util/validator"
@@ -27,6 +28,7 @@ type Service struct {
 	coreService               *core.Service
 	validationService         *validation.Service
 	valueValidatorService     *validation.ValueValidatorService
+	secretService             *secret.Service
 }
 
 func NewService(
@@ -40,6 +42,7 @@ func NewService(
 	coreService *core.Service,
 	validationService *validation.Service,
 	valueValidatorService *validation.ValueValidatorService,
+	secretService *secret.Service,
 ) *Service {
 	return &Service{
 		unitOfWorkRunner:          unitOfWorkRunner,
@@ -52,6 +55,7 @@ func NewService(
 		coreService:               coreService,
 		validationService:         validationService,
 		valueValidatorService:     valueValidatorService,
+		secretService:             secretService,
 	}
 }
 
@@ -62,6 +66,7 @@ type VariationValueDto struct {
 	CanEdit   bool            `json:"canEdit" validate:"required"`
 	Rank      int             `json:"rank" validate:"required"`
 	Order     []int           `json:"order" validate:"required"`
+	Rollout   *core.Rollout   `json:"rollout,omitempty"`
 }
 
 func (s *Service) GetKeyValues(ctx context.Context, serviceVersionID uint, featureVersionID uint, keyID uint) ([]VariationValueDto, error) {
@@ -85,6 +90,8 @@ func (s *Service) GetKeyValues(ctx context.Context, serviceVersionID uint, featu
 		return nil, err
 	}
 
+	revealSecrets := s.secretService.CanReveal(ctx)
+
 	variationValues := make([]VariationValueDto, len(values))
 	for i, value := range values {
 		variation, err := s.variationContextService.GetVariationContextValues(ctx, value.VariationContextID)
@@ -102,13 +109,19 @@ func (s *Service) GetKeyValues(ctx context.Context, serviceVersionID uint, featu
 			return nil, err
 		}
 
+		data, err := s.secretService.Present(key.ValueTypeKind, value.Data, revealSecrets)
+		if err != nil {
+			return nil, err
+		}
+
 		variationValues[i] = VariationValueDto{
 			ID:        value.ID,
-			Data:      value.Data,
+			Data:      data,
 			Variation: variation,
 			CanEdit:   user.GetPermissionForValue(serviceVersion.ServiceID, featureVersion.FeatureID, key.ID, variation) >= constants.PermissionEditor,
 			Rank:      rank,
 			Order:     order,
+			Rollout:   core.NewRollout(value.RolloutPercentage, value.RolloutAttribute),
 		}
 	}
 
@@ -136,15 +149,17 @@ type CreateValueParams struct {
 	KeyID            uint
 	Data             string
 	Variation        map[uint]string
+	// Rollout limits the value to a percentage of the subjects, the value is not rolled out when nil
+	Rollout *core.Rollout
 }
 
-func (s *Service) valueDataValidator(ctx context.Context, valueTypeID uint, keyID uint) (validator.ValidatorFunc, error) {
-	valueValidators, err := s.valueValidatorService.GetValueValidators(ctx, &keyID, &valueTypeID)
+func (s *Service) valueDataValidator(ctx context.Context, key db.GetKeyRow) (validator.ValidatorFunc, error) {
+	valueValidators, err := s.valueValidatorService.GetValueValidators(ctx, &key.ID, &key.ValueTypeID)
 	if err != nil {
 		return nil, err
 	}
 
-	validatorFunc, err := s.valueValidatorService.CreateValueValidatorFunc(valueValidators)
+	validatorFunc, err := s.valueValidatorService.CreateValueValidatorFunc(key.ValueTypeKind, valueValidators)
 	if err != nil {
 		return nil, err
 	}
@@ -152,20 +167,44 @@ func (s *Service) valueDataValidator(ctx context.Context, valueTypeID uint, keyI
 	return validatorFunc, nil
 }
 
+// validateRollout validates the rollout of a value. Subjects outside of the rollout fall back to less specific values,
+// so the default value cannot be rolled out.
+func (s *Service) validateRollout(vc *validator.Context, rollout *core.Rollout, variation map[uint]string) (*validator.Context, error) {
+	if rollout == nil {
+		return vc, nil
+	}
+
+	if len(variation) == 0 {
+		return vc, core.NewServiceError(core.ErrorCodeInvalidInput, "Default value cannot be rolled out")
+	}
+
+	return vc.
+		Validate(rollout.Percentage, "Rollout Percentage").Min(0).Max(100).
+		Validate(rollout.Attribute, "Rollout Attribute").Required().MaxLength(100).Regex(`^[a-zA-Z_][\w_]*$`), nil
+}
+
+// isDeletedValue returns true if the data and rollout are the same as of the value deleted in the changeset, so the value is restored
+func isDeletedValue(deleteChange db.GetDeleteChangeForVariationContextIDRow, data string, rollout *core.Rollout) bool {
+	return deleteChange.VariationValueData == data && rollout.Equal(core.NewRollout(deleteChange.VariationValueRolloutPercentage, deleteChange.VariationValueRolloutAttribute))
+}
+
 func (s *Service) validateCreateValue(ctx context.Context, data CreateValueParams, serviceVersion db.GetServiceVersionRow, featureVersion db.GetFeatureVersionRow, key db.GetKeyRow) error {
 	err := s.validationService.CanAddValueInternal(ctx, serviceVersion, featureVersion, key, data.Variation)
 	if err != nil {
 		return err
 	}
 
-	validatorFunc, err := s.valueDataValidator(ctx, key.ValueTypeID, key.ID)
+	validatorFunc, err := s.valueDataValidator(ctx, key)
 	if err != nil {
 		return err
 	}
 
-	return s.validator.
-		Validate(data.Data, "Data").Func(validatorFunc).
-		Error(ctx)
+	vc, err := s.validateRollout(s.validator.Validate(data.Data, "Data").Func(validatorFunc), data.Rollout, data.Variation)
+	if err != nil {
+		return err
+	}
+
+	return vc.Error(ctx)
 }
 
 func (s *Service) CreateValue(ctx context.Context, data CreateValueParams) (NewValueInfo, error) {
@@ -199,6 +238,11 @@ func (s *Service) CreateValue(ctx context.Context, data CreateValueParams) (NewV
 		return NewValueInfo{}, err
 	}
 
+	storedData, err := s.secretService.Seal(key.ValueTypeKind, data.Data, existingDeleteChange.VariationValueData)
+	if err != nil {
+		return NewValueInfo{}, err
+	}
+
 	var variationValueID uint
 
 	err = s.unitOfWorkRunner.Run(ctx, func(tx *db.Queries) error {
@@ -212,11 +256,13 @@ func (s *Service) CreateValue(ctx context.Context, data CreateValueParams) (NewV
 				return err
 			}
 
-			if existingDeleteChange.VariationValueData != data.Data {
+			if !isDeletedValue(existingDeleteChange, storedData, data.Rollout) {
 				variationValueID, err = tx.CreateVariationValue(ctx, db.CreateVariationValueParams{
 					KeyID:              data.KeyID,
 					VariationContextID: variationContextID,
-					Data:               data.Data,
+					Data:               storedData,
+					RolloutPercentage:  data.Rollout.PercentageParam(),
+					RolloutAttribute:   data.Rollout.AttributeParam(),
 				})
 				if err != nil {
 					return err
@@ -237,7 +283,9 @@ func (s *Service) CreateValue(ctx context.Context, data CreateValueParams) (NewV
 			variationValueID, err = tx.CreateVariationValue(ctx, db.CreateVariationValueParams{
 				KeyID:              data.KeyID,
 				VariationContextID: variationContextID,
-				Data:               data.Data,
+				Data:               storedData,
+				RolloutPercentage:  data.Rollout.PercentageParam(),
+				RolloutAttribute:   data.Rollout.AttributeParam(),
 			})
 			if err != nil {
 				return err
@@ -353,6 +401,10 @@ type UpdateValueParams struct {
 	ValueID          uint
 	Data             string
 	Variation        map[uint]string
+	// Rollout limits the value to a percentage of the subjects, the existing rollout is kept when nil
+	Rollout *core.Rollout
+	// ClearRollout makes a rolled out value apply to all subjects
+	ClearRollout bool
 }
 
 func (s *Service) validateUpdateValue(ctx context.Context, data UpdateValueParams, serviceVersion db.GetServiceVersionRow, featureVersion db.GetFeatureVersionRow, key db.GetKeyRow, value db.VariationValue) error {
@@ -361,14 +413,17 @@ func (s *Service) validateUpdateValue(ctx context.Context, data UpdateValueParam
 		return err
 	}
 
-	validatorFunc, err := s.valueDataValidator(ctx, key.ValueTypeID, key.ID)
+	validatorFunc, err := s.valueDataValidator(ctx, key)
 	if err != nil {
 		return err
 	}
 
-	v := s.validator.
+	v, err := s.validateRollout(s.validator.
 		Validate(data.Data, "Data").Func(validatorFunc).
-		Validate(data.Variation, "Variation").Required()
+		Validate(data.Variation, "Variation").Required(), data.Rollout, data.Variation)
+	if err != nil {
+		return err
+	}
 
 	return v.Error(ctx)
 }
@@ -380,6 +435,7 @@ type UpdateValueState struct {
 	Value                db.VariationValue
 	VariationContextID   uint
 	Data                 string
+	Rollout              *core.Rollout
 	ExistingChange       *db.GetChangeForVariationValueRow
 	ExistingDeleteChange *db.GetDeleteChangeForVariationContextIDRow
 	ChangesetID          uint
@@ -419,11 +475,13 @@ func (s *NoExistingChangeStrategy) handleWithSameVariationAlreadyHavingDeletedCh
 		return 0, err
 	}
 
-	if state.ExistingDeleteChange.VariationValueData != state.Data {
+	if !isDeletedValue(*state.ExistingDeleteChange, state.Data, state.Rollout) {
 		variationValueID, err := tx.CreateVariationValue(ctx, db.CreateVariationValueParams{
 			KeyID:              state.Key.ID,
 			VariationContextID: state.VariationContextID,
 			Data:               state.Data,
+			RolloutPercentage:  state.Rollout.PercentageParam(),
+			RolloutAttribute:   state.Rollout.AttributeParam(),
 		})
 		if err != nil {
 			return 0, err
@@ -451,6 +509,8 @@ func (s *NoExistingChangeStrategy) handleDefault(ctx context.Context, tx *db.Que
 		KeyID:              state.Key.ID,
 		VariationContextID: state.VariationContextID,
 		Data:               state.Data,
+		RolloutPercentage:  state.Rollout.PercentageParam(),
+		RolloutAttribute:   state.Rollout.AttributeParam(),
 	})
 	if err != nil {
 		return 0, err
@@ -515,11 +575,13 @@ func (s *ExistingUpdateChangeStrategy) handleWithSameVariationAlreadyHavingDelet
 		return 0, err
 	}
 
-	if state.ExistingDeleteChange.VariationValueData != state.Data {
+	if !isDeletedValue(*state.ExistingDeleteChange, state.Data, state.Rollout) {
 		if err := tx.UpdateVariationValue(ctx, db.UpdateVariationValueParams{
 			VariationValueID:   state.Value.ID,
 			VariationContextID: state.VariationContextID,
 			Data:               state.Data,
+			RolloutPercentage:  state.Rollout.PercentageParam(),
+			RolloutAttribute:   state.Rollout.AttributeParam(),
 		}); err != nil {
 			return 0, err
 		}
@@ -544,6 +606,8 @@ func (s *ExistingUpdateChangeStrategy) handleDefault(ctx context.Context, tx *db
 		VariationValueID:   state.Value.ID,
 		VariationContextID: state.VariationContextID,
 		Data:               state.Data,
+		RolloutPercentage:  state.Rollout.PercentageParam(),
+		RolloutAttribute:   state.Rollout.AttributeParam(),
 	}); err != nil {
 		return 0, err
 	}
@@ -599,11 +663,13 @@ func (s *ExistingCreateChangeStrategy) handleWithSameVariationAlreadyHavingDelet
 		return 0, err
 	}
 
-	if state.ExistingDeleteChange.VariationValueData != state.Data {
+	if !isDeletedValue(*state.ExistingDeleteChange, state.Data, state.Rollout) {
 		if err := tx.UpdateVariationValue(ctx, db.UpdateVariationValueParams{
 			VariationValueID:   state.Value.ID,
 			VariationContextID: state.VariationContextID,
 			Data:               state.Data,
+			RolloutPercentage:  state.Rollout.PercentageParam(),
+			RolloutAttribute:   state.Rollout.AttributeParam(),
 		}); err != nil {
 			return 0, err
 		}
@@ -634,6 +700,8 @@ func (s *ExistingCreateChangeStrategy) handleDefault(ctx context.Context, tx *db
 		VariationValueID:   state.Value.ID,
 		VariationContextID: state.VariationContextID,
 		Data:               state.Data,
+		RolloutPercentage:  state.Rollout.PercentageParam(),
+		RolloutAttribute:   state.Rollout.AttributeParam(),
 	}); err != nil {
 		return 0, err
 	}
@@ -677,6 +745,15 @@ func (s *Service) UpdateValue(ctx context.Context, params UpdateValueParams) (Ne
 		return NewValueInfo{}, err
 	}
 
+	if params.Rollout != nil && params.ClearRollout {
+		return NewValueInfo{}, core.NewServiceError(core.ErrorCodeInvalidInput, "Rollout cannot be set and cleared at the same time")
+	}
+
+	// clients that don't know about rollouts must not turn a rolled out value into one that applies to all subjects
+	if params.Rollout == nil && !params.ClearRollout {
+		params.Rollout = core.NewRollout(value.RolloutPercentage, value.RolloutAttribute)
+	}
+
 	if err := s.validateUpdateValue(ctx, params, serviceVersion, featureVersion, key, value); err != nil {
 		return NewValueInfo{}, err
 	}
@@ -686,7 +763,13 @@ func (s *Service) UpdateValue(ctx context.Context, params UpdateValueParams) (Ne
 		return NewValueInfo{}, err
 	}
 
-	if value.VariationContextID == variationContextID && value.Data == params.Data {
+	// secret values are encrypted, the stored data is reused if the decrypted data did not change
+	storedData, err := s.secretService.Seal(key.ValueTypeKind, params.Data, value.Data)
+	if err != nil {
+		return NewValueInfo{}, err
+	}
+
+	if value.VariationContextID == variationContextID && value.Data == storedData && params.Rollout.Equal(core.NewRollout(value.RolloutPercentage, value.RolloutAttribute)) {
 		order, err := variationHierarchy.GetOrder(serviceVersion.ServiceTypeID, params.Variation)
 		if err != nil {
 			return NewValueInfo{}, err
@@ -712,13 +795,22 @@ func (s *Service) UpdateValue(ctx context.Context, params UpdateValueParams) (Ne
 		return NewValueInfo{}, err
 	}
 
+	if existingDeleteChange.ID != 0 {
+		// restoring the deleted value is detected by comparing its stored data
+		storedData, err = s.secretService.Seal(key.ValueTypeKind, params.Data, existingDeleteChange.VariationValueData, value.Data)
+		if err != nil {
+			return NewValueInfo{}, err
+		}
+	}
+
 	state := &UpdateValueState{
 		ServiceVersion:     serviceVersion,
 		FeatureVersion:     featureVersion,
 		Key:                key,
 		Value:              value,
 		VariationContextID: variationContextID,
-		Data:               params.Data,
+		Data:               storedData,
+		Rollout:            params.Rollout,
 	}
 
 	if existingChange.ID != 0 {
@@ -890,13 +982,18 @@ func (s *Service) RevalidateValue(ctx context.Context, changeID uint) error {
 		return err
 	}
 
-	validatorFunc, err := s.valueDataValidator(ctx, key.ValueTypeID, key.ID)
+	validatorFunc, err := s.valueDataValidator(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	data, err := s.secretService.Open(key.ValueTypeKind, value.Data)
 	if err != nil {
 		return err
 	}
 
 	err = s.validator.
-		Validate(value.Data, "Data").Func(validatorFunc).
+		Validate(data, "Data").Func(validatorFunc).
 		Error(ctx)
 
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"sync/atomic"
 
 	"github.com/dgraph-io/ristretto/v2"
 	"github.com/jackc/pgx/v5"
@@ -18,6 +19,8 @@ type ContextService struct {
 	variationHierarchyService *HierarchyService
 	unitOfWorkRunner          db.UnitOfWorkRunner
 	cache                     *ristretto.Cache[string, any]
+	// generation is part of every cache key, incrementing it evicts all variation context entries
+	generation atomic.Uint64
 }
 
 func NewContextService(queries *db.Queries, variationHierarchyService *HierarchyService, unitOfWorkRunner db.UnitOfWorkRunner, cache *ristretto.Cache[string, any]) *ContextService {
@@ -29,11 +32,11 @@ func NewContextService(queries *db.Queries, variationHierarchyService *Hierarchy
 	}
 }
 
-func getVariationContextIdCacheKey(variationValues []uint) string {
+func (s *ContextService) getVariationContextIdCacheKey(variationValues []uint) string {
 	slices.Sort(variationValues)
 
 	var sb strings.Builder
-	sb.WriteString("variation_context_id")
+	sb.WriteString(fmt.Sprintf("variation_context_id:%d", s.generation.Load()))
 	for _, valueID := range variationValues {
 		sb.WriteString(fmt.Sprintf(":%d", valueID))
 	}
@@ -41,8 +44,12 @@ func getVariationContextIdCacheKey(variationValues []uint) string {
 	return sb.String()
 }
 
-func getVariationContextValuesCacheKey(variationContextID uint) string {
-	return fmt.Sprintf("variation_context_values:%d", variationContextID)
+func (s *ContextService) getVariationContextValuesCacheKey(variationContextID uint) string {
+	return fmt.Sprintf("variation_context_values:%d:%d", s.generation.Load(), variationContextID)
+}
+
+func (s *ContextService) ClearLocalCache() {
+	s.generation.Add(1)
 }
 
 func (s *ContextService) getIDsFromVariation(ctx context.Context, variation map[uint]string) ([]uint, error) {
@@ -69,7 +76,7 @@ func (s *ContextService) getIDsFromVariation(ctx context.Context, variation map[
 }
 
 func (s *ContextService) GetVariationContextValues(ctx context.Context, variationContextID uint) (map[uint]string, error) {
-	valuesCacheKey := getVariationContextValuesCacheKey(variationContextID)
+	valuesCacheKey := s.getVariationContextValuesCacheKey(variationContextID)
 	cachedValues, exists := s.cache.Get(valuesCacheKey)
 
 	if exists {
@@ -89,7 +96,7 @@ func (s *ContextService) GetVariationContextValues(ctx context.Context, variatio
 	}
 
 	s.cache.Set(valuesCacheKey, variationContext, int64(len(valueIds)*3))
-	s.cache.Set(getVariationContextIdCacheKey(valueIds), variationContextID, 1)
+	s.cache.Set(s.getVariationContextIdCacheKey(valueIds), variationContextID, 1)
 
 	return variationContext, nil
 }
@@ -100,7 +107,7 @@ func (s *ContextService) GetVariationContextID(ctx context.Context, variation ma
 		return 0, err
 	}
 
-	cacheKey := getVariationContextIdCacheKey(ids)
+	cacheKey := s.getVariationContextIdCacheKey(ids)
 	cachedID, exists := s.cache.Get(cacheKey)
 
 	if exists {
@@ -145,7 +152,10 @@ func (s *ContextService) GetVariationContextID(ctx context.Context, variation ma
 		return 0, err
 	}
 
-	s.cache.Set(cacheKey, contextID, 1)
+	// a context created in a transaction that is rolled back later must not stay cached
+	if !db.InTransaction(ctx) {
+		s.cache.Set(cacheKey, contextID, 1)
+	}
 
 	return contextID, nil
 }
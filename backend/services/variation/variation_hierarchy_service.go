@@ -2,19 +2,22 @@ package variation
 
 import (
 	"context"
+	"log/slog"
 	"time"
 
 	"github.com/dgraph-io/ristretto/v2"
 	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/cacheinvalidation"
 )
 
 type HierarchyService struct {
-	queries *db.Queries
-	cache   *ristretto.Cache[string, any]
+	queries                  *db.Queries
+	cache                    *ristretto.Cache[string, any]
+	cacheInvalidationService *cacheinvalidation.Service
 }
 
-func NewHierarchyService(queries *db.Queries, cache *ristretto.Cache[string, any]) *HierarchyService {
-	return &HierarchyService{queries: queries, cache: cache}
+func NewHierarchyService(queries *db.Queries, cache *ristretto.Cache[string, any], cacheInvalidationService *cacheinvalidation.Service) *HierarchyService {
+	return &HierarchyService{queries: queries, cache: cache, cacheInvalidationService: cacheInvalidationService}
 }
 
 type GetHierarchyConfig struct {
@@ -65,6 +68,15 @@ func (s *HierarchyService) GetVariationHierarchy(ctx context.Context, options ..
 	return variationHierarchy, nil
 }
 
+// ClearCache evicts the variation hierarchy in this instance and notifies other instances to do the same
 func (s *HierarchyService) ClearCache(ctx context.Context) {
+	s.ClearLocalCache()
+
+	if err := s.cacheInvalidationService.Notify(ctx, nil, cacheinvalidation.Event{Kind: cacheinvalidation.EventKindVariationChanged}); err != nil {
+		slog.ErrorContext(ctx, "failed to notify other instances about variation hierarchy change", "error", err)
+	}
+}
+
+func (s *HierarchyService) ClearLocalCache() {
 	s.cache.Del(variationHierarchyCacheKey)
 }
@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/util/ptr"
+)
+
+const (
+	dispatcherInterval  = 5 * time.Second
+	deliveryBatchSize   = 20
+	deliveryTimeout     = 10 * time.Second
+	maxDeliveryAttempts = 8
+	initialRetryDelay   = 30 * time.Second
+
+	SignatureHeader = "X-Config-Service-Signature"
+	EventHeader     = "X-Config-Service-Event"
+	DeliveryHeader  = "X-Config-Service-Delivery"
+)
+
+// Sign returns the value of the signature header, a hex encoded HMAC-SHA256 of the payload keyed by the webhook secret
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryDelay doubles the delay after each failed attempt, so all attempts span roughly an hour
+func retryDelay(attempts int) time.Duration {
+	return initialRetryDelay << (attempts - 1)
+}
+
+// Dispatcher periodically sends pending webhook deliveries and reschedules the failed ones.
+// Deliveries are claimed with a lease, so multiple instances can run the dispatcher at the same time.
+type Dispatcher struct {
+	queries *db.Queries
+	client  *http.Client
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+func NewDispatcher(queries *db.Queries) *Dispatcher {
+	return &Dispatcher{
+		queries: queries,
+		client:  &http.Client{Timeout: deliveryTimeout},
+	}
+}
+
+func (d *Dispatcher) Start(ctx context.Context) {
+	dispatcherCtx, cancel := context.WithCancel(ctx)
+	d.cancel = cancel
+	d.done = make(chan struct{})
+
+	go func() {
+		defer close(d.done)
+
+		ticker := time.NewTicker(dispatcherInterval)
+		defer ticker.Stop()
+
+		for {
+			d.deliverDue(dispatcherCtx)
+
+			select {
+			case <-dispatcherCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (d *Dispatcher) Stop() {
+	if d.cancel == nil {
+		return
+	}
+
+	d.cancel()
+	<-d.done
+}
+
+func (d *Dispatcher) deliverDue(ctx context.Context) {
+	now := time.Now()
+
+	deliveries, err := d.queries.ClaimDueWebhookDeliveries(ctx, db.ClaimDueWebhookDeliveriesParams{
+		Now: now,
+		// if the instance dies mid delivery, the delivery is picked up again after the lease expires
+		LeaseUntil: now.Add(deliveryTimeout * 3),
+		Limit:      deliveryBatchSize,
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.ErrorContext(ctx, "failed to claim webhook deliveries", "error", err)
+		}
+
+		return
+	}
+
+	// the deliveries are sent concurrently, so the whole batch finishes within the client timeout and none of them
+	// outlives the lease and gets claimed again by another instance
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			result := d.deliver(ctx, delivery)
+
+			if err := d.queries.SetWebhookDeliveryResult(ctx, result); err != nil {
+				slog.ErrorContext(ctx, "failed to save webhook delivery result", "deliveryID", delivery.ID, "error", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery db.ClaimDueWebhookDeliveriesRow) db.SetWebhookDeliveryResultParams {
+	result := db.SetWebhookDeliveryResultParams{
+		DeliveryID: delivery.ID,
+		Status:     db.WebhookDeliveryStatusSucceeded,
+	}
+
+	err := d.send(ctx, delivery, &result)
+	if err == nil {
+		return result
+	}
+
+	result.LastError = ptr.To(err.Error())
+
+	if delivery.Attempts >= maxDeliveryAttempts {
+		result.Status = db.WebhookDeliveryStatusFailed
+	} else {
+		result.Status = db.WebhookDeliveryStatusPending
+		result.NextAttemptAt = ptr.To(time.Now().Add(retryDelay(delivery.Attempts)))
+	}
+
+	return result
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery db.ClaimDueWebhookDeliveriesRow, result *db.SetWebhookDeliveryResultParams) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "config-service-webhooks")
+	req.Header.Set(EventHeader, string(delivery.Event))
+	req.Header.Set(DeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	result.ResponseStatus = ptr.To(resp.StatusCode)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,303 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/necroskillz/config-service/auth"
+	"github.com/necroskillz/config-service/constants"
+	"github.com/necroskillz/config-service/db"
+	"github.com/necroskillz/config-service/services/core"
+	"github.com/necroskillz/config-service/util/validator"
+)
+
+var EventTypes = []db.WebhookEventType{
+	db.WebhookEventTypeChangesetCommitted,
+	db.WebhookEventTypeChangesetApplied,
+	db.WebhookEventTypeChangesetStashed,
+	db.WebhookEventTypeChangesetCommented,
+}
+
+type Service struct {
+	queries             *db.Queries
+	currentUserAccessor *auth.CurrentUserAccessor
+	validator           *validator.Validator
+	coreService         *core.Service
+}
+
+func NewService(
+	queries *db.Queries,
+	currentUserAccessor *auth.CurrentUserAccessor,
+	validator *validator.Validator,
+	coreService *core.Service,
+) *Service {
+	return &Service{
+		queries:             queries,
+		currentUserAccessor: currentUserAccessor,
+		validator:           validator,
+		coreService:         coreService,
+	}
+}
+
+type WebhookDto struct {
+	ID        uint                  `json:"id" validate:"required"`
+	CreatedAt time.Time             `json:"createdAt" validate:"required"`
+	Url       string                `json:"url" validate:"required"`
+	Events    []db.WebhookEventType `json:"events" validate:"required"`
+	Enabled   bool                  `json:"enabled" validate:"required"`
+}
+
+func toEventTypes(events []string) []db.WebhookEventType {
+	eventTypes := make([]db.WebhookEventType, len(events))
+	for i, event := range events {
+		eventTypes[i] = db.WebhookEventType(event)
+	}
+
+	return eventTypes
+}
+
+func fromEventTypes(eventTypes []db.WebhookEventType) []string {
+	events := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		events[i] = string(eventType)
+	}
+
+	return events
+}
+
+// getServiceIDForAdmin returns the ID of the service the service version belongs to, if the current user is its admin.
+// Webhooks are managed per service, the service version is only used for url consistency.
+func (s *Service) getServiceIDForAdmin(ctx context.Context, serviceVersionID uint) (uint, error) {
+	serviceVersion, err := s.coreService.GetServiceVersion(ctx, serviceVersionID)
+	if err != nil {
+		return 0, err
+	}
+
+	user := s.currentUserAccessor.GetUser(ctx)
+
+	if user.GetPermissionForService(serviceVersion.ServiceID) < constants.PermissionAdmin {
+		return 0, core.NewServiceError(core.ErrorCodePermissionDenied, "You are not authorized to manage webhooks of this service")
+	}
+
+	return serviceVersion.ServiceID, nil
+}
+
+func (s *Service) getWebhook(ctx context.Context, serviceVersionID uint, webhookID uint) (db.GetWebhookRow, error) {
+	serviceID, err := s.getServiceIDForAdmin(ctx, serviceVersionID)
+	if err != nil {
+		return db.GetWebhookRow{}, err
+	}
+
+	webhook, err := s.queries.GetWebhook(ctx, webhookID)
+	if err != nil {
+		return db.GetWebhookRow{}, core.NewDbError(err, "Webhook")
+	}
+
+	if webhook.ServiceID != serviceID {
+		return db.GetWebhookRow{}, core.NewServiceError(core.ErrorCodeRecordNotFound, "Webhook not found")
+	}
+
+	return webhook, nil
+}
+
+func (s *Service) GetWebhooks(ctx context.Context, serviceVersionID uint) ([]WebhookDto, error) {
+	serviceID, err := s.getServiceIDForAdmin(ctx, serviceVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.queries.GetWebhooks(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]WebhookDto, len(webhooks))
+	for i, webhook := range webhooks {
+		result[i] = WebhookDto{
+			ID:        webhook.ID,
+			CreatedAt: webhook.CreatedAt,
+			Url:       webhook.Url,
+			Events:    toEventTypes(webhook.Events),
+			Enabled:   webhook.Enabled,
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Service) GetWebhook(ctx context.Context, serviceVersionID uint, webhookID uint) (WebhookDto, error) {
+	webhook, err := s.getWebhook(ctx, serviceVersionID, webhookID)
+	if err != nil {
+		return WebhookDto{}, err
+	}
+
+	return WebhookDto{
+		ID:        webhook.ID,
+		CreatedAt: webhook.CreatedAt,
+		Url:       webhook.Url,
+		Events:    toEventTypes(webhook.Events),
+		Enabled:   webhook.Enabled,
+	}, nil
+}
+
+func (s *Service) validateWebhook(ctx context.Context, url string, secret *string, events []db.WebhookEventType) error {
+	vc := s.validator.
+		Validate(url, "Url").Required().MaxLength(2000).Regex(`^https?://\S+$`)
+
+	if secret != nil {
+		vc = vc.Validate(*secret, "Secret").Required().MinLength(16).MaxLength(256)
+	}
+
+	if err := vc.Error(ctx); err != nil {
+		return err
+	}
+
+	if len(events) == 0 {
+		return core.NewServiceError(core.ErrorCodeInvalidInput, "At least one event is required")
+	}
+
+	for _, event := range events {
+		if !slices.Contains(EventTypes, event) {
+			return core.NewServiceError(core.ErrorCodeInvalidInput, fmt.Sprintf("Unknown event %s", event))
+		}
+	}
+
+	return nil
+}
+
+type CreateWebhookParams struct {
+	ServiceVersionID uint
+	Url              string
+	Secret           string
+	Events           []db.WebhookEventType
+	Enabled          bool
+}
+
+func (s *Service) CreateWebhook(ctx context.Context, params CreateWebhookParams) (uint, error) {
+	serviceID, err := s.getServiceIDForAdmin(ctx, params.ServiceVersionID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.validateWebhook(ctx, params.Url, &params.Secret, params.Events); err != nil {
+		return 0, err
+	}
+
+	return s.queries.CreateWebhook(ctx, db.CreateWebhookParams{
+		ServiceID: serviceID,
+		Url:       params.Url,
+		Secret:    params.Secret,
+		Events:    fromEventTypes(params.Events),
+		Enabled:   params.Enabled,
+	})
+}
+
+type UpdateWebhookParams struct {
+	ServiceVersionID uint
+	WebhookID        uint
+	Url              string
+	// Secret is kept unchanged when nil
+	Secret  *string
+	Events  []db.WebhookEventType
+	Enabled bool
+}
+
+func (s *Service) UpdateWebhook(ctx context.Context, params UpdateWebhookParams) error {
+	if _, err := s.getWebhook(ctx, params.ServiceVersionID, params.WebhookID); err != nil {
+		return err
+	}
+
+	if err := s.validateWebhook(ctx, params.Url, params.Secret, params.Events); err != nil {
+		return err
+	}
+
+	return s.queries.UpdateWebhook(ctx, db.UpdateWebhookParams{
+		WebhookID: params.WebhookID,
+		Url:       params.Url,
+		Secret:    params.Secret,
+		Events:    fromEventTypes(params.Events),
+		Enabled:   params.Enabled,
+	})
+}
+
+func (s *Service) DeleteWebhook(ctx context.Context, serviceVersionID uint, webhookID uint) error {
+	if _, err := s.getWebhook(ctx, serviceVersionID, webhookID); err != nil {
+		return err
+	}
+
+	return s.queries.DeleteWebhook(ctx, webhookID)
+}
+
+type WebhookDeliveryDto struct {
+	ID             uint                     `json:"id" validate:"required"`
+	CreatedAt      time.Time                `json:"createdAt" validate:"required"`
+	ChangesetID    uint                     `json:"changesetId" validate:"required"`
+	Event          db.WebhookEventType      `json:"event" validate:"required"`
+	Payload        json.RawMessage          `json:"payload" validate:"required" swaggertype:"object"`
+	Status         db.WebhookDeliveryStatus `json:"status" validate:"required"`
+	Attempts       int                      `json:"attempts" validate:"required"`
+	NextAttemptAt  *time.Time               `json:"nextAttemptAt"`
+	LastAttemptAt  *time.Time               `json:"lastAttemptAt"`
+	ResponseStatus *int                     `json:"responseStatus"`
+	LastError      *string                  `json:"lastError"`
+}
+
+type DeliveryFilter struct {
+	ServiceVersionID uint
+	WebhookID        uint
+	Page             int
+	PageSize         int
+}
+
+func (s *Service) GetWebhookDeliveries(ctx context.Context, filter DeliveryFilter) (core.PaginatedResult[WebhookDeliveryDto], error) {
+	if filter.Page < 1 {
+		return core.PaginatedResult[WebhookDeliveryDto]{}, core.NewServiceError(core.ErrorCodeInvalidOperation, "Page must be 1 or greater")
+	}
+
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		return core.PaginatedResult[WebhookDeliveryDto]{}, core.NewServiceError(core.ErrorCodeInvalidOperation, "Page size must be between 1 and 100")
+	}
+
+	if _, err := s.getWebhook(ctx, filter.ServiceVersionID, filter.WebhookID); err != nil {
+		return core.PaginatedResult[WebhookDeliveryDto]{}, err
+	}
+
+	deliveries, err := s.queries.GetWebhookDeliveries(ctx, db.GetWebhookDeliveriesParams{
+		WebhookID: filter.WebhookID,
+		Limit:     filter.PageSize,
+		Offset:    (filter.Page - 1) * filter.PageSize,
+	})
+	if err != nil {
+		return core.PaginatedResult[WebhookDeliveryDto]{}, core.NewDbError(err, "WebhookDeliveries")
+	}
+
+	items := make([]WebhookDeliveryDto, len(deliveries))
+	for i, delivery := range deliveries {
+		items[i] = WebhookDeliveryDto{
+			ID:             delivery.ID,
+			CreatedAt:      delivery.CreatedAt,
+			ChangesetID:    delivery.ChangesetID,
+			Event:          delivery.Event,
+			Payload:        delivery.Payload,
+			Status:         delivery.Status,
+			Attempts:       delivery.Attempts,
+			NextAttemptAt:  delivery.NextAttemptAt,
+			LastAttemptAt:  delivery.LastAttemptAt,
+			ResponseStatus: delivery.ResponseStatus,
+			LastError:      delivery.LastError,
+		}
+	}
+
+	var total int
+	if len(deliveries) > 0 {
+		total = deliveries[0].TotalCount
+	}
+
+	return core.PaginatedResult[WebhookDeliveryDto]{
+		Items:      items,
+		TotalCount: total,
+	}, nil
+}
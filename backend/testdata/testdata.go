@@ -57,8 +57,11 @@ type Manager struct {
 	AuthService               *membership.AuthService
 }
 
-func NewManager(dbpool *pgxpool.Pool, cache *ristretto.Cache[string, any], metrics *metrics.Metrics) *Manager {
-	svc := services.InitializeServices(dbpool, cache)
+func NewManager(dbpool *pgxpool.Pool, cache *ristretto.Cache[string, any], metrics *metrics.Metrics) (*Manager, error) {
+	svc, err := services.InitializeServices(dbpool, cache)
+	if err != nil {
+		return nil, err
+	}
 
 	changeScopes := make([]*ChangeScope, 3)
 	changeScopes[0] = &ChangeScope{
@@ -110,7 +113,7 @@ func NewManager(dbpool *pgxpool.Pool, cache *ristretto.Cache[string, any], metri
 		VariationPropertyService:  svc.VariationPropertyService,
 		ServiceTypeService:        svc.ServiceTypeService,
 		AuthService:               svc.AuthService,
-	}
+	}, nil
 }
 
 type RunOptions struct {
@@ -539,6 +542,14 @@ func (m *Manager) createData(ctx context.Context, valueTypeKind db.ValueTypeKind
 		}
 
 		return fmt.Sprintf(`["%s", "%s"]`, m.Rng.Noun(), m.Rng.Noun())
+	case db.ValueTypeKindStringList:
+		return fmt.Sprintf(`["%s","%s"]`, m.Rng.Noun(), m.Rng.Noun())
+	case db.ValueTypeKindIntegerList:
+		return fmt.Sprintf("[%d,%d]", m.Rng.Intn(1000), m.Rng.Intn(1000))
+	case db.ValueTypeKindDuration:
+		return fmt.Sprintf("%ds", m.Rng.Intn(3600))
+	case db.ValueTypeKindUrl:
+		return fmt.Sprintf("https://%s.example.com", m.Rng.Noun())
 	}
 
 	panic("invalid value type kind")
@@ -0,0 +1,150 @@
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Prefix marks data sealed by an Encryptor, the version allows changing the format without breaking stored data
+const Prefix = "enc:v1:"
+
+// KeySize is the size of the master key and of the data keys, AES-256 is used for both
+const KeySize = 32
+
+var ErrInvalidData = errors.New("invalid sealed data")
+
+// Encryptor seals data with envelope encryption. Every value is encrypted with its own random data key, which is
+// encrypted with the master key and stored together with the value.
+type Encryptor struct {
+	master cipher.AEAD
+}
+
+func NewEncryptor(masterKey []byte) (*Encryptor, error) {
+	if len(masterKey) != KeySize {
+		return nil, fmt.Errorf("master key must be %d bytes, got %d", KeySize, len(masterKey))
+	}
+
+	master, err := newAEAD(masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Encryptor{master: master}, nil
+}
+
+// ParseKey decodes a base64 encoded master key
+func ParseKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("master key is not valid base64: %w", err)
+	}
+
+	return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the plaintext with a random nonce, which is prepended to the ciphertext
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrInvalidData
+	}
+
+	plaintext, err := aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrInvalidData
+	}
+
+	return plaintext, nil
+}
+
+// Seal encrypts the data and returns it in format enc:v1:<encrypted data key>.<encrypted data>
+func (e *Encryptor) Seal(data string) (string, error) {
+	dataKey := make([]byte, KeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	encryptedData, err := seal(aead, []byte(data))
+	if err != nil {
+		return "", err
+	}
+
+	encryptedKey, err := seal(e.master, dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	return Prefix + base64.RawStdEncoding.EncodeToString(encryptedKey) + "." + base64.RawStdEncoding.EncodeToString(encryptedData), nil
+}
+
+// Open decrypts data sealed by Seal
+func (e *Encryptor) Open(sealed string) (string, error) {
+	encoded, ok := strings.CutPrefix(sealed, Prefix)
+	if !ok {
+		return "", ErrInvalidData
+	}
+
+	encodedKey, encodedData, ok := strings.Cut(encoded, ".")
+	if !ok {
+		return "", ErrInvalidData
+	}
+
+	encryptedKey, err := base64.RawStdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return "", ErrInvalidData
+	}
+
+	encryptedData, err := base64.RawStdEncoding.DecodeString(encodedData)
+	if err != nil {
+		return "", ErrInvalidData
+	}
+
+	dataKey, err := open(e.master, encryptedKey)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", ErrInvalidData
+	}
+
+	data, err := open(aead, encryptedData)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// IsSealed returns true if the data is in the format produced by Seal
+func IsSealed(data string) bool {
+	return strings.HasPrefix(data, Prefix)
+}
@@ -0,0 +1,91 @@
+package envelope
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/necroskillz/config-service/util/test"
+	"gotest.tools/v3/assert"
+)
+
+func TestEnvelope(t *testing.T) {
+	masterKey := bytes.Repeat([]byte{1}, KeySize)
+
+	encryptor, err := NewEncryptor(masterKey)
+	assert.NilError(t, err)
+
+	t.Run("Seal and open", func(t *testing.T) {
+		run := func(t *testing.T, data string) {
+			sealed, err := encryptor.Seal(data)
+			assert.NilError(t, err)
+			assert.Assert(t, IsSealed(sealed))
+
+			opened, err := encryptor.Open(sealed)
+			assert.NilError(t, err)
+			assert.Equal(t, data, opened)
+		}
+
+		testCases := map[string]string{
+			"empty":   "",
+			"ascii":   "password",
+			"unicode": "heslo 🔑",
+			"long":    strings.Repeat("secret", 1000),
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+
+	t.Run("Seal uses a new data key every time", func(t *testing.T) {
+		a, err := encryptor.Seal("password")
+		assert.NilError(t, err)
+
+		b, err := encryptor.Seal("password")
+		assert.NilError(t, err)
+
+		assert.Assert(t, a != b)
+	})
+
+	t.Run("Open invalid data", func(t *testing.T) {
+		sealed, err := encryptor.Seal("password")
+		assert.NilError(t, err)
+
+		otherEncryptor, err := NewEncryptor(bytes.Repeat([]byte{2}, KeySize))
+		assert.NilError(t, err)
+
+		encodedKey, encodedData, _ := strings.Cut(strings.TrimPrefix(sealed, Prefix), ".")
+		tamperedData := []byte(encodedData)
+		tamperedData[len(tamperedData)-1] ^= 1
+
+		run := func(t *testing.T, open func() (string, error)) {
+			_, err := open()
+			assert.ErrorIs(t, err, ErrInvalidData)
+		}
+
+		testCases := map[string]func() (string, error){
+			"plain text":         func() (string, error) { return encryptor.Open("password") },
+			"missing separator":  func() (string, error) { return encryptor.Open(Prefix + encodedKey) },
+			"invalid base64":     func() (string, error) { return encryptor.Open(Prefix + "!." + encodedData) },
+			"truncated key":      func() (string, error) { return encryptor.Open(Prefix + "AA." + encodedData) },
+			"tampered data":      func() (string, error) { return encryptor.Open(Prefix + encodedKey + "." + string(tamperedData)) },
+			"another master key": func() (string, error) { return otherEncryptor.Open(sealed) },
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+
+	t.Run("Invalid master key", func(t *testing.T) {
+		_, err := NewEncryptor([]byte("short"))
+		assert.ErrorContains(t, err, "master key must be 32 bytes")
+	})
+
+	t.Run("Parse key", func(t *testing.T) {
+		key, err := ParseKey(base64.StdEncoding.EncodeToString(masterKey) + "\n")
+		assert.NilError(t, err)
+		assert.DeepEqual(t, masterKey, key)
+
+		_, err = ParseKey("not base64!")
+		assert.ErrorContains(t, err, "not valid base64")
+	})
+}
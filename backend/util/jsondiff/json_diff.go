@@ -0,0 +1,81 @@
+package jsondiff
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type Operation string
+
+const (
+	OperationAdded   Operation = "added"
+	OperationRemoved Operation = "removed"
+	OperationChanged Operation = "changed"
+)
+
+type Change struct {
+	// Path is a JSON pointer (RFC 6901) to the changed value
+	Path      string    `json:"path" validate:"required"`
+	Operation Operation `json:"operation" validate:"required"`
+	Old       any       `json:"old"`
+	New       any       `json:"new"`
+}
+
+// Diff returns the structural changes between two unmarshaled JSON values. Objects are compared by property,
+// arrays by index, any other difference is reported as a change of the whole value.
+func Diff(a, b any) []Change {
+	return diff("", a, b, []Change{})
+}
+
+func escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func diff(path string, a, b any, changes []Change) []Change {
+	switch a := a.(type) {
+	case map[string]any:
+		if b, ok := b.(map[string]any); ok {
+			for _, k := range slices.Sorted(maps.Keys(a)) {
+				if bv, ok := b[k]; ok {
+					changes = diff(path+"/"+escape(k), a[k], bv, changes)
+				} else {
+					changes = append(changes, Change{Path: path + "/" + escape(k), Operation: OperationRemoved, Old: a[k]})
+				}
+			}
+
+			for _, k := range slices.Sorted(maps.Keys(b)) {
+				if _, ok := a[k]; !ok {
+					changes = append(changes, Change{Path: path + "/" + escape(k), Operation: OperationAdded, New: b[k]})
+				}
+			}
+
+			return changes
+		}
+	case []any:
+		if b, ok := b.([]any); ok {
+			for i := range max(len(a), len(b)) {
+				itemPath := path + "/" + strconv.Itoa(i)
+
+				switch {
+				case i >= len(b):
+					changes = append(changes, Change{Path: itemPath, Operation: OperationRemoved, Old: a[i]})
+				case i >= len(a):
+					changes = append(changes, Change{Path: itemPath, Operation: OperationAdded, New: b[i]})
+				default:
+					changes = diff(itemPath, a[i], b[i], changes)
+				}
+			}
+
+			return changes
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		changes = append(changes, Change{Path: path, Operation: OperationChanged, Old: a, New: b})
+	}
+
+	return changes
+}
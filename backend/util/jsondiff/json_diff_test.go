@@ -0,0 +1,53 @@
+package jsondiff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/necroskillz/config-service/util/test"
+	"gotest.tools/v3/assert"
+)
+
+func TestJsonDiff(t *testing.T) {
+	t.Run("Diff", func(t *testing.T) {
+		type testCase struct {
+			a      string
+			b      string
+			expect []Change
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			var a any
+			var b any
+			err := json.Unmarshal([]byte(tc.a), &a)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = json.Unmarshal([]byte(tc.b), &b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.DeepEqual(t, tc.expect, Diff(a, b))
+		}
+
+		testCases := map[string]testCase{
+			"equal":               {a: `{"a": [1, {"b": true}]}`, b: `{"a": [1, {"b": true}]}`, expect: []Change{}},
+			"scalar":              {a: `1`, b: `2`, expect: []Change{{Path: "", Operation: OperationChanged, Old: 1.0, New: 2.0}}},
+			"type mismatch":       {a: `{"a": 1}`, b: `{"a": "1"}`, expect: []Change{{Path: "/a", Operation: OperationChanged, Old: 1.0, New: "1"}}},
+			"object to array":     {a: `{"a": 1}`, b: `[1]`, expect: []Change{{Path: "", Operation: OperationChanged, Old: map[string]any{"a": 1.0}, New: []any{1.0}}}},
+			"added property":      {a: `{"a": 1}`, b: `{"a": 1, "b": 2}`, expect: []Change{{Path: "/b", Operation: OperationAdded, New: 2.0}}},
+			"removed property":    {a: `{"a": 1, "b": 2}`, b: `{"a": 1}`, expect: []Change{{Path: "/b", Operation: OperationRemoved, Old: 2.0}}},
+			"null property":       {a: `{"a": 1}`, b: `{"a": null}`, expect: []Change{{Path: "/a", Operation: OperationChanged, Old: 1.0, New: nil}}},
+			"nested object":       {a: `{"a": {"b": "b", "c": "c"}}`, b: `{"a": {"b": "d", "c": "c"}}`, expect: []Change{{Path: "/a/b", Operation: OperationChanged, Old: "b", New: "d"}}},
+			"escaped property":    {a: `{"a/b": 1, "c~d": 1}`, b: `{"a/b": 2, "c~d": 2}`, expect: []Change{{Path: "/a~1b", Operation: OperationChanged, Old: 1.0, New: 2.0}, {Path: "/c~0d", Operation: OperationChanged, Old: 1.0, New: 2.0}}},
+			"array item changed":  {a: `[1, 2]`, b: `[1, 3]`, expect: []Change{{Path: "/1", Operation: OperationChanged, Old: 2.0, New: 3.0}}},
+			"array item added":    {a: `[1]`, b: `[1, 2]`, expect: []Change{{Path: "/1", Operation: OperationAdded, New: 2.0}}},
+			"array item removed":  {a: `{"a": [1, 2]}`, b: `{"a": [1]}`, expect: []Change{{Path: "/a/1", Operation: OperationRemoved, Old: 2.0}}},
+			"properties in order": {a: `{"b": 1, "a": 1}`, b: `{"c": 1, "b": 2}`, expect: []Change{{Path: "/a", Operation: OperationRemoved, Old: 1.0}, {Path: "/b", Operation: OperationChanged, Old: 1.0, New: 2.0}, {Path: "/c", Operation: OperationAdded, New: 1.0}}},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+}
@@ -0,0 +1,190 @@
+package validator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+)
+
+// ExpressionValueType is the CEL type of the value variable an expression is evaluated against
+type ExpressionValueType string
+
+const (
+	ExpressionValueTypeString     ExpressionValueType = "string"
+	ExpressionValueTypeInt        ExpressionValueType = "int"
+	ExpressionValueTypeDouble     ExpressionValueType = "double"
+	ExpressionValueTypeBool       ExpressionValueType = "bool"
+	ExpressionValueTypeDyn        ExpressionValueType = "dyn"
+	ExpressionValueTypeStringList ExpressionValueType = "list(string)"
+	ExpressionValueTypeIntList    ExpressionValueType = "list(int)"
+	ExpressionValueTypeDuration   ExpressionValueType = "duration"
+)
+
+const (
+	expressionValueVariable = "value"
+	// expressionCostLimit stops runaway expressions, e.g. nested comprehensions over large lists
+	expressionCostLimit = 100000
+	// ExpressionResultPlaceholder is replaced by the expression result in the error text of expression validators
+	ExpressionResultPlaceholder = "{0}"
+	// HiddenExpressionResult replaces the expression result in the error text of expressions validating secret values
+	HiddenExpressionResult = "********"
+)
+
+var ErrExpressionEvalError = errors.New("unable to evaluate expression")
+
+var expressionCelTypes = map[ExpressionValueType]*cel.Type{
+	ExpressionValueTypeString:     cel.StringType,
+	ExpressionValueTypeInt:        cel.IntType,
+	ExpressionValueTypeDouble:     cel.DoubleType,
+	ExpressionValueTypeBool:       cel.BoolType,
+	ExpressionValueTypeDyn:        cel.DynType,
+	ExpressionValueTypeStringList: cel.ListType(cel.StringType),
+	ExpressionValueTypeIntList:    cel.ListType(cel.IntType),
+	ExpressionValueTypeDuration:   cel.DurationType,
+}
+
+// Expression is a compiled CEL expression. The expression is valid if it evaluates to true or to an empty string,
+// a string result is the reason the value is invalid.
+type Expression struct {
+	source     string
+	variables  map[string]ExpressionValueType
+	references []string
+	program    cel.Program
+}
+
+// CompileExpression parses and type checks the source against a value variable of the value type
+func CompileExpression(source string, valueType ExpressionValueType) (*Expression, error) {
+	return CompileExpressionWithVariables(source, map[string]ExpressionValueType{expressionValueVariable: valueType})
+}
+
+// CompileExpressionWithVariables parses and type checks the source against the variables of the value types
+func CompileExpressionWithVariables(source string, variables map[string]ExpressionValueType) (*Expression, error) {
+	options := make([]cel.EnvOption, 0, len(variables))
+	for name, valueType := range variables {
+		celType, ok := expressionCelTypes[valueType]
+		if !ok {
+			return nil, fmt.Errorf("unsupported expression value type %s", valueType)
+		}
+
+		options = append(options, cel.Variable(name, celType))
+	}
+
+	env, err := cel.NewEnv(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ast, issues := env.Compile(source)
+	if issues != nil && issues.Err() != nil {
+		// the default error message spans several lines with a snippet of the source, only the reasons are kept
+		messages := make([]string, len(issues.Errors()))
+		for i, issue := range issues.Errors() {
+			messages[i] = fmt.Sprintf("%d:%d: %s", issue.Location.Line(), issue.Location.Column()+1, issue.Message)
+		}
+
+		return nil, errors.New(strings.Join(messages, "; "))
+	}
+
+	outputType := ast.OutputType()
+	if !outputType.IsExactType(cel.BoolType) && !outputType.IsExactType(cel.StringType) && !outputType.IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("expression must evaluate to bool or string, got %s", outputType)
+	}
+
+	program, err := env.Program(ast, cel.CostLimit(expressionCostLimit), cel.InterruptCheckFrequency(100))
+	if err != nil {
+		return nil, err
+	}
+
+	references := []string{}
+	for _, reference := range ast.NativeRep().ReferenceMap() {
+		if _, ok := variables[reference.Name]; ok && !slices.Contains(references, reference.Name) {
+			references = append(references, reference.Name)
+		}
+	}
+	slices.Sort(references)
+
+	return &Expression{source: source, variables: variables, references: references, program: program}, nil
+}
+
+func (e *Expression) String() string {
+	return e.source
+}
+
+// References returns the sorted names of the variables used in the expression
+func (e *Expression) References() []string {
+	return e.references
+}
+
+// typedValue converts the data to the go type matching the value type
+func typedValue(valueType ExpressionValueType, data string) (any, error) {
+	switch valueType {
+	case ExpressionValueTypeInt:
+		return strconv.ParseInt(data, 10, 64)
+	case ExpressionValueTypeDouble:
+		return strconv.ParseFloat(data, 64)
+	case ExpressionValueTypeBool:
+		return strconv.ParseBool(data)
+	case ExpressionValueTypeDuration:
+		return time.ParseDuration(data)
+	case ExpressionValueTypeDyn:
+		var value any
+		err := json.Unmarshal([]byte(data), &value)
+		return value, err
+	case ExpressionValueTypeStringList:
+		var value []string
+		err := json.Unmarshal([]byte(data), &value)
+		return value, err
+	case ExpressionValueTypeIntList:
+		var value []int64
+		err := json.Unmarshal([]byte(data), &value)
+		return value, err
+	default:
+		return data, nil
+	}
+}
+
+// Eval evaluates the expression against the data of the value variable. It returns whether the data is valid and the
+// result of the expression formatted as a string.
+func (e *Expression) Eval(ctx context.Context, data string) (bool, string, error) {
+	return e.EvalVariables(ctx, map[string]string{expressionValueVariable: data})
+}
+
+// EvalVariables evaluates the expression against the data of the variables, see Eval
+func (e *Expression) EvalVariables(ctx context.Context, data map[string]string) (bool, string, error) {
+	activation := make(map[string]any, len(data))
+	for name, variableData := range data {
+		valueType, ok := e.variables[name]
+		if !ok {
+			continue
+		}
+
+		value, err := typedValue(valueType, variableData)
+		if err != nil {
+			return false, "", fmt.Errorf("%w: %s is not a valid %s", ErrExpressionEvalError, name, valueType)
+		}
+
+		activation[name] = value
+	}
+
+	out, _, err := e.program.ContextEval(ctx, activation)
+	if err != nil {
+		return false, "", fmt.Errorf("%w: %w", ErrExpressionEvalError, err)
+	}
+
+	switch x := out.(type) {
+	case types.Bool:
+		return bool(x), strconv.FormatBool(bool(x)), nil
+	case types.String:
+		return x == "", string(x), nil
+	}
+
+	return false, "", fmt.Errorf("%w: expression must evaluate to bool or string, got %s", ErrExpressionEvalError, out.Type())
+}
@@ -0,0 +1,85 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/necroskillz/config-service/util/test"
+	"gotest.tools/v3/assert"
+)
+
+func TestExpression(t *testing.T) {
+	variables := map[string]ExpressionValueType{
+		"Enabled":     ExpressionValueTypeBool,
+		"TimeoutMs":   ExpressionValueTypeInt,
+		"MinPoolSize": ExpressionValueTypeInt,
+		"MaxPoolSize": ExpressionValueTypeInt,
+		"Hosts":       ExpressionValueTypeStringList,
+	}
+
+	t.Run("References", func(t *testing.T) {
+		type testCase struct {
+			source             string
+			expectedReferences []string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			expression, err := CompileExpressionWithVariables(tc.source, variables)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, expression.References(), tc.expectedReferences)
+		}
+
+		testCases := map[string]testCase{
+			"single":   {source: "TimeoutMs > 0", expectedReferences: []string{"TimeoutMs"}},
+			"sorted":   {source: "MinPoolSize <= MaxPoolSize", expectedReferences: []string{"MaxPoolSize", "MinPoolSize"}},
+			"repeated": {source: "TimeoutMs > 0 && TimeoutMs < 1000", expectedReferences: []string{"TimeoutMs"}},
+			"macro":    {source: `!Enabled || Hosts.all(h, h != "")`, expectedReferences: []string{"Enabled", "Hosts"}},
+			"none":     {source: "true", expectedReferences: []string{}},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+
+	t.Run("CompileExpressionWithVariables", func(t *testing.T) {
+		_, err := CompileExpressionWithVariables("Unknown > 0", variables)
+		assert.Error(t, err, "1:1: undeclared reference to 'Unknown' (in container '')")
+	})
+
+	t.Run("EvalVariables", func(t *testing.T) {
+		type testCase struct {
+			source         string
+			data           map[string]string
+			expectedValid  bool
+			expectedResult string
+			expectedErr    string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			expression, err := CompileExpressionWithVariables(tc.source, variables)
+			assert.NilError(t, err)
+
+			valid, result, err := expression.EvalVariables(context.Background(), tc.data)
+			if tc.expectedErr != "" {
+				assert.Error(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, valid, tc.expectedValid)
+			assert.Equal(t, result, tc.expectedResult)
+		}
+
+		testCases := map[string]testCase{
+			"satisfied":       {source: "MinPoolSize <= MaxPoolSize", data: map[string]string{"MinPoolSize": "5", "MaxPoolSize": "10"}, expectedValid: true, expectedResult: "true"},
+			"violated":        {source: "MinPoolSize <= MaxPoolSize", data: map[string]string{"MinPoolSize": "20", "MaxPoolSize": "10"}, expectedValid: false, expectedResult: "false"},
+			"implication off": {source: "!Enabled || TimeoutMs > 0", data: map[string]string{"Enabled": "false", "TimeoutMs": "0"}, expectedValid: true, expectedResult: "true"},
+			"implication on":  {source: "!Enabled || TimeoutMs > 0", data: map[string]string{"Enabled": "true", "TimeoutMs": "0"}, expectedValid: false, expectedResult: "false"},
+			"string result":   {source: `size(Hosts) > 0 ? "" : "at least one host is required"`, data: map[string]string{"Hosts": `[]`}, expectedValid: false, expectedResult: "at least one host is required"},
+			"unused data":     {source: "TimeoutMs > 0", data: map[string]string{"TimeoutMs": "1", "Other": "x"}, expectedValid: true, expectedResult: "true"},
+			"invalid data":    {source: "TimeoutMs > 0", data: map[string]string{"TimeoutMs": "abc"}, expectedErr: "unable to evaluate expression: TimeoutMs is not a valid int"},
+			"missing data":    {source: "TimeoutMs > 0", data: map[string]string{}, expectedErr: "unable to evaluate expression: no such attribute(s): TimeoutMs"},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+}
@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/santhosh-tekuri/jsonschema/v6"
 )
@@ -45,10 +48,23 @@ const (
 	RuleIDValidInteger    RuleID = "valid_integer"
 	RuleIDValidFloat      RuleID = "valid_float"
 	RuleIDValidRegex      RuleID = "valid_regex"
+	RuleIDOneOf           RuleID = "one_of"
+	RuleIDValidStringList RuleID = "valid_string_list"
+	RuleIDValidIntList    RuleID = "valid_int_list"
+	RuleIDMinItems        RuleID = "min_items"
+	RuleIDMaxItems        RuleID = "max_items"
+	RuleIDValidDuration   RuleID = "valid_duration"
+	RuleIDMinDuration     RuleID = "min_duration"
+	RuleIDMaxDuration     RuleID = "max_duration"
+	RuleIDValidURL        RuleID = "valid_url"
+	RuleIDValidExpression RuleID = "valid_expression"
+	RuleIDExpression      RuleID = "expression"
 )
 
 var (
-	ErrNumberParseError = errors.New("unable to parse number")
+	ErrNumberParseError   = errors.New("unable to parse number")
+	ErrListParseError     = errors.New("unable to parse list")
+	ErrDurationParseError = errors.New("unable to parse duration")
 )
 
 type RuleFunc func(ctx context.Context, value any, fieldName string, options ...any) error
@@ -105,6 +121,35 @@ func normalizeFloat(value any) (float64, error) {
 	return 0, fmt.Errorf("type %T cannot be converted to float64", value)
 }
 
+// normalizeListLength returns the number of items of a list encoded as a JSON array
+func normalizeListLength(value any) (int, error) {
+	switch x := value.(type) {
+	case string:
+		var items []json.RawMessage
+		if err := json.Unmarshal([]byte(x), &items); err != nil {
+			return 0, ErrListParseError
+		}
+		return len(items), nil
+	}
+
+	return 0, fmt.Errorf("type %T cannot be converted to list", value)
+}
+
+func normalizeDuration(value any) (time.Duration, error) {
+	switch x := value.(type) {
+	case time.Duration:
+		return x, nil
+	case string:
+		parsed, err := time.ParseDuration(x)
+		if err != nil {
+			return 0, ErrDurationParseError
+		}
+		return parsed, nil
+	}
+
+	return 0, fmt.Errorf("type %T cannot be converted to duration", value)
+}
+
 func param[T any](options []any, index int) (T, error) {
 	if index >= len(options) {
 		return *new(T), fmt.Errorf("required validator param at index %d not provided", index)
@@ -432,6 +477,268 @@ func (v *Validator) registerRules() {
 
 		return nil
 	})
+
+	v.registerRule(RuleIDOneOf, func(ctx context.Context, value any, fieldName string, options ...any) error {
+		values, err := param[[]string](options, 0)
+		if err != nil {
+			return err
+		}
+
+		errorText, err := param[string](options, 1)
+		if err != nil {
+			return err
+		}
+
+		switch x := value.(type) {
+		case string:
+			if x == "" {
+				return nil
+			}
+
+			if !slices.Contains(values, x) {
+				if errorText != "" {
+					return NewValidationError(fieldName, errorText)
+				}
+
+				return NewValidationError(fieldName, fmt.Sprintf("Field %s must be one of %s", fieldName, strings.Join(values, ", ")))
+			}
+		default:
+			return fmt.Errorf("invalid type for one of validator %T", value)
+		}
+
+		return nil
+	})
+
+	v.registerRule(RuleIDValidStringList, func(ctx context.Context, value any, fieldName string, options ...any) error {
+		switch x := value.(type) {
+		case string:
+			var items []string
+			if err := json.Unmarshal([]byte(x), &items); err != nil || items == nil {
+				return NewValidationError(fieldName, fmt.Sprintf("Field %s must be a JSON array of strings", fieldName))
+			}
+		default:
+			return fmt.Errorf("invalid type for valid string list validator %T", value)
+		}
+
+		return nil
+	})
+
+	v.registerRule(RuleIDValidIntList, func(ctx context.Context, value any, fieldName string, options ...any) error {
+		switch x := value.(type) {
+		case string:
+			var items []int64
+			if err := json.Unmarshal([]byte(x), &items); err != nil || items == nil {
+				return NewValidationError(fieldName, fmt.Sprintf("Field %s must be a JSON array of integers", fieldName))
+			}
+		default:
+			return fmt.Errorf("invalid type for valid integer list validator %T", value)
+		}
+
+		return nil
+	})
+
+	v.registerRule(RuleIDMinItems, func(ctx context.Context, value any, fieldName string, options ...any) error {
+		if value == nil || value == "" {
+			return nil
+		}
+
+		length, err := normalizeListLength(value)
+		if err != nil {
+			if errors.Is(err, ErrListParseError) {
+				return NewValidationError(fieldName, fmt.Sprintf("Field %s must be a JSON array", fieldName))
+			}
+
+			return err
+		}
+
+		min, err := param[int](options, 0)
+		if err != nil {
+			return err
+		}
+
+		if length < min {
+			return NewValidationError(fieldName, fmt.Sprintf("Field %s must have at least %d items", fieldName, min))
+		}
+
+		return nil
+	})
+
+	v.registerRule(RuleIDMaxItems, func(ctx context.Context, value any, fieldName string, options ...any) error {
+		if value == nil || value == "" {
+			return nil
+		}
+
+		length, err := normalizeListLength(value)
+		if err != nil {
+			if errors.Is(err, ErrListParseError) {
+				return NewValidationError(fieldName, fmt.Sprintf("Field %s must be a JSON array", fieldName))
+			}
+
+			return err
+		}
+
+		max, err := param[int](options, 0)
+		if err != nil {
+			return err
+		}
+
+		if length > max {
+			return NewValidationError(fieldName, fmt.Sprintf("Field %s must have at most %d items", fieldName, max))
+		}
+
+		return nil
+	})
+
+	v.registerRule(RuleIDValidDuration, func(ctx context.Context, value any, fieldName string, options ...any) error {
+		switch x := value.(type) {
+		case string:
+			if _, err := time.ParseDuration(x); err != nil {
+				return NewValidationError(fieldName, fmt.Sprintf("Field %s must be a valid duration, e.g. 1h30m", fieldName))
+			}
+		default:
+			return fmt.Errorf("invalid type for valid duration validator %T", value)
+		}
+
+		return nil
+	})
+
+	v.registerRule(RuleIDMinDuration, func(ctx context.Context, value any, fieldName string, options ...any) error {
+		if value == nil || value == "" {
+			return nil
+		}
+
+		duration, err := normalizeDuration(value)
+		if err != nil {
+			if errors.Is(err, ErrDurationParseError) {
+				return NewValidationError(fieldName, fmt.Sprintf("Field %s must be a valid duration, e.g. 1h30m", fieldName))
+			}
+
+			return err
+		}
+
+		min, err := param[time.Duration](options, 0)
+		if err != nil {
+			return err
+		}
+
+		if duration < min {
+			return NewValidationError(fieldName, fmt.Sprintf("Field %s must be at least %s", fieldName, min))
+		}
+
+		return nil
+	})
+
+	v.registerRule(RuleIDMaxDuration, func(ctx context.Context, value any, fieldName string, options ...any) error {
+		if value == nil || value == "" {
+			return nil
+		}
+
+		duration, err := normalizeDuration(value)
+		if err != nil {
+			if errors.Is(err, ErrDurationParseError) {
+				return NewValidationError(fieldName, fmt.Sprintf("Field %s must be a valid duration, e.g. 1h30m", fieldName))
+			}
+
+			return err
+		}
+
+		max, err := param[time.Duration](options, 0)
+		if err != nil {
+			return err
+		}
+
+		if duration > max {
+			return NewValidationError(fieldName, fmt.Sprintf("Field %s must be at most %s", fieldName, max))
+		}
+
+		return nil
+	})
+
+	v.registerRule(RuleIDValidURL, func(ctx context.Context, value any, fieldName string, options ...any) error {
+		switch x := value.(type) {
+		case string:
+			// only absolute URLs are valid, relative references have nothing to resolve against
+			parsed, err := url.Parse(x)
+			if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+				return NewValidationError(fieldName, fmt.Sprintf("Field %s must be a valid absolute URL", fieldName))
+			}
+		default:
+			return fmt.Errorf("invalid type for valid URL validator %T", value)
+		}
+
+		return nil
+	})
+
+	v.registerRule(RuleIDValidExpression, func(ctx context.Context, value any, fieldName string, options ...any) error {
+		valueType, err := param[ExpressionValueType](options, 0)
+		if err != nil {
+			return err
+		}
+
+		switch x := value.(type) {
+		case string:
+			if _, err := CompileExpression(x, valueType); err != nil {
+				return NewValidationError(fieldName, fmt.Sprintf("Field %s must be a valid CEL expression: %s", fieldName, err))
+			}
+		default:
+			return fmt.Errorf("invalid type for valid expression validator %T", value)
+		}
+
+		return nil
+	})
+
+	v.registerRule(RuleIDExpression, func(ctx context.Context, value any, fieldName string, options ...any) error {
+		expression, err := param[*Expression](options, 0)
+		if err != nil {
+			return err
+		}
+
+		errorText, err := param[string](options, 1)
+		if err != nil {
+			return err
+		}
+
+		hideResult, err := param[bool](options, 2)
+		if err != nil {
+			return err
+		}
+
+		switch x := value.(type) {
+		case string:
+			valid, result, err := expression.Eval(ctx, x)
+			if err != nil {
+				if errors.Is(err, ErrExpressionEvalError) {
+					if hideResult {
+						return NewValidationError(fieldName, fmt.Sprintf("Field %s could not be checked against %s", fieldName, expression))
+					}
+
+					return NewValidationError(fieldName, fmt.Sprintf("Field %s could not be checked against %s: %s", fieldName, expression, err))
+				}
+
+				return err
+			}
+
+			if valid {
+				return nil
+			}
+
+			if hideResult {
+				result = HiddenExpressionResult
+			}
+
+			if errorText != "" {
+				return NewValidationError(fieldName, strings.ReplaceAll(errorText, ExpressionResultPlaceholder, result))
+			}
+
+			if result != "false" && !hideResult {
+				return NewValidationError(fieldName, fmt.Sprintf("Field %s must satisfy %s: %s", fieldName, expression, result))
+			}
+
+			return NewValidationError(fieldName, fmt.Sprintf("Field %s must satisfy %s", fieldName, expression))
+		default:
+			return fmt.Errorf("invalid type for expression validator %T", value)
+		}
+	})
 }
 
 func (v *Validator) registerRule(id RuleID, rule RuleFunc) {
@@ -557,3 +864,55 @@ func (v *Context) ValidInteger() *Context {
 func (v *Context) ValidFloat() *Context {
 	return v.Rule(RuleIDValidFloat)
 }
+
+// OneOf validates the value is one of the allowed values, the error text replaces the generic message when set
+func (v *Context) OneOf(values []string, errorText string) *Context {
+	return v.Rule(RuleIDOneOf, values, errorText)
+}
+
+func (v *Context) ValidStringList() *Context {
+	return v.Rule(RuleIDValidStringList)
+}
+
+func (v *Context) ValidIntList() *Context {
+	return v.Rule(RuleIDValidIntList)
+}
+
+func (v *Context) MinItems(min int) *Context {
+	return v.Rule(RuleIDMinItems, min)
+}
+
+func (v *Context) MaxItems(max int) *Context {
+	return v.Rule(RuleIDMaxItems, max)
+}
+
+func (v *Context) ValidDuration() *Context {
+	return v.Rule(RuleIDValidDuration)
+}
+
+func (v *Context) MinDuration(min time.Duration) *Context {
+	return v.Rule(RuleIDMinDuration, min)
+}
+
+func (v *Context) MaxDuration(max time.Duration) *Context {
+	return v.Rule(RuleIDMaxDuration, max)
+}
+
+func (v *Context) ValidURL() *Context {
+	return v.Rule(RuleIDValidURL)
+}
+
+func (v *Context) ValidExpression(valueType ExpressionValueType) *Context {
+	return v.Rule(RuleIDValidExpression, valueType)
+}
+
+// Expression validates the value against the compiled expression, the error text can reference the expression result
+func (v *Context) Expression(expression *Expression, errorText string) *Context {
+	return v.Rule(RuleIDExpression, expression, errorText, false)
+}
+
+// SecretExpression is Expression for secret values, the expression result and evaluation errors can contain the value,
+// so they are not put in the error
+func (v *Context) SecretExpression(expression *Expression, errorText string) *Context {
+	return v.Rule(RuleIDExpression, expression, errorText, true)
+}
@@ -3,6 +3,7 @@ package validator
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/necroskillz/config-service/util/test"
 	"gotest.tools/v3/assert"
@@ -224,6 +225,32 @@ func TestValidator(t *testing.T) {
 		test.RunCases(t, run, testCases)
 	})
 
+	t.Run("OneOf", func(t *testing.T) {
+		type testCase struct {
+			value           any
+			values          []string
+			customErrorText string
+			expectError     bool
+			errorText       string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			err := validator.Validate(tc.value, testFieldName).OneOf(tc.values, tc.customErrorText).Error(context.Background())
+			assertValidatorError(t, err, tc.expectError, tc.errorText)
+		}
+
+		testCases := map[string]testCase{
+			"valid":                   {value: "safe", values: []string{"fast", "safe", "off"}, expectError: false},
+			"valid empty":             {value: "", values: []string{"fast", "safe", "off"}, expectError: false},
+			"invalid":                 {value: "slow", values: []string{"fast", "safe", "off"}, expectError: true, errorText: "Field FieldName must be one of fast, safe, off"},
+			"invalid case":            {value: "Fast", values: []string{"fast", "safe", "off"}, expectError: true, errorText: "Field FieldName must be one of fast, safe, off"},
+			"invalid with error text": {value: "slow", values: []string{"fast", "safe", "off"}, customErrorText: "Mode must be fast, safe or off", expectError: true, errorText: "Mode must be fast, safe or off"},
+			"wrong type":              {value: 1, values: []string{"fast"}, expectError: true, errorText: "invalid type for one of validator int"},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+
 	t.Run("ValidJson", func(t *testing.T) {
 		type testCase struct {
 			value       any
@@ -355,6 +382,277 @@ func TestValidator(t *testing.T) {
 			"wrong type": {value: 123, expectError: true, errorText: "invalid type for valid float validator int"},
 		}
 
+		test.RunCases(t, run, testCases)
+	})
+	t.Run("ValidStringList", func(t *testing.T) {
+		type testCase struct {
+			value       any
+			expectError bool
+			errorText   string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			err := validator.Validate(tc.value, testFieldName).ValidStringList().Error(context.Background())
+			assertValidatorError(t, err, tc.expectError, tc.errorText)
+		}
+
+		testCases := map[string]testCase{
+			"valid":          {value: `["a","b"]`, expectError: false},
+			"valid empty":    {value: `[]`, expectError: false},
+			"invalid items":  {value: `["a",1]`, expectError: true, errorText: "Field FieldName must be a JSON array of strings"},
+			"invalid object": {value: `{"a":"b"}`, expectError: true, errorText: "Field FieldName must be a JSON array of strings"},
+			"invalid null":   {value: `null`, expectError: true, errorText: "Field FieldName must be a JSON array of strings"},
+			"wrong type":     {value: 1, expectError: true, errorText: "invalid type for valid string list validator int"},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+
+	t.Run("ValidIntList", func(t *testing.T) {
+		type testCase struct {
+			value       any
+			expectError bool
+			errorText   string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			err := validator.Validate(tc.value, testFieldName).ValidIntList().Error(context.Background())
+			assertValidatorError(t, err, tc.expectError, tc.errorText)
+		}
+
+		testCases := map[string]testCase{
+			"valid":           {value: `[1,-2,3]`, expectError: false},
+			"valid empty":     {value: `[]`, expectError: false},
+			"invalid decimal": {value: `[1.5]`, expectError: true, errorText: "Field FieldName must be a JSON array of integers"},
+			"invalid string":  {value: `["1"]`, expectError: true, errorText: "Field FieldName must be a JSON array of integers"},
+			"invalid json":    {value: `1,2`, expectError: true, errorText: "Field FieldName must be a JSON array of integers"},
+			"wrong type":      {value: 1, expectError: true, errorText: "invalid type for valid integer list validator int"},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+
+	t.Run("MinItems", func(t *testing.T) {
+		type testCase struct {
+			value       any
+			min         int
+			expectError bool
+			errorText   string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			err := validator.Validate(tc.value, testFieldName).MinItems(tc.min).Error(context.Background())
+			assertValidatorError(t, err, tc.expectError, tc.errorText)
+		}
+
+		testCases := map[string]testCase{
+			"valid":             {value: `["a","b"]`, min: 1, expectError: false},
+			"valid same as min": {value: `[1,2]`, min: 2, expectError: false},
+			"valid empty":       {value: "", min: 1, expectError: false},
+			"invalid":           {value: `[]`, min: 1, expectError: true, errorText: "Field FieldName must have at least 1 items"},
+			"not a list":        {value: `"a"`, min: 1, expectError: true, errorText: "Field FieldName must be a JSON array"},
+			"wrong type":        {value: 1, min: 1, expectError: true, errorText: "type int cannot be converted to list"},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+
+	t.Run("MaxItems", func(t *testing.T) {
+		type testCase struct {
+			value       any
+			max         int
+			expectError bool
+			errorText   string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			err := validator.Validate(tc.value, testFieldName).MaxItems(tc.max).Error(context.Background())
+			assertValidatorError(t, err, tc.expectError, tc.errorText)
+		}
+
+		testCases := map[string]testCase{
+			"valid":             {value: `["a"]`, max: 2, expectError: false},
+			"valid same as max": {value: `[1,2]`, max: 2, expectError: false},
+			"invalid":           {value: `[1,2,3]`, max: 2, expectError: true, errorText: "Field FieldName must have at most 2 items"},
+			"not a list":        {value: `{}`, max: 2, expectError: true, errorText: "Field FieldName must be a JSON array"},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+
+	t.Run("ValidDuration", func(t *testing.T) {
+		type testCase struct {
+			value       any
+			expectError bool
+			errorText   string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			err := validator.Validate(tc.value, testFieldName).ValidDuration().Error(context.Background())
+			assertValidatorError(t, err, tc.expectError, tc.errorText)
+		}
+
+		testCases := map[string]testCase{
+			"valid":          {value: "1h30m", expectError: false},
+			"valid fraction": {value: "1.5s", expectError: false},
+			"valid zero":     {value: "0", expectError: false},
+			"invalid unit":   {value: "5 days", expectError: true, errorText: "Field FieldName must be a valid duration, e.g. 1h30m"},
+			"invalid number": {value: "300", expectError: true, errorText: "Field FieldName must be a valid duration, e.g. 1h30m"},
+			"wrong type":     {value: 300, expectError: true, errorText: "invalid type for valid duration validator int"},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+
+	t.Run("MinDuration", func(t *testing.T) {
+		type testCase struct {
+			value       any
+			min         time.Duration
+			expectError bool
+			errorText   string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			err := validator.Validate(tc.value, testFieldName).MinDuration(tc.min).Error(context.Background())
+			assertValidatorError(t, err, tc.expectError, tc.errorText)
+		}
+
+		testCases := map[string]testCase{
+			"valid":             {value: "2m", min: time.Minute, expectError: false},
+			"valid same as min": {value: "60s", min: time.Minute, expectError: false},
+			"valid duration":    {value: time.Hour, min: time.Minute, expectError: false},
+			"invalid":           {value: "30s", min: time.Minute, expectError: true, errorText: "Field FieldName must be at least 1m0s"},
+			"not a duration":    {value: "soon", min: time.Minute, expectError: true, errorText: "Field FieldName must be a valid duration, e.g. 1h30m"},
+			"wrong type":        {value: 60, min: time.Minute, expectError: true, errorText: "type int cannot be converted to duration"},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+
+	t.Run("MaxDuration", func(t *testing.T) {
+		type testCase struct {
+			value       any
+			max         time.Duration
+			expectError bool
+			errorText   string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			err := validator.Validate(tc.value, testFieldName).MaxDuration(tc.max).Error(context.Background())
+			assertValidatorError(t, err, tc.expectError, tc.errorText)
+		}
+
+		testCases := map[string]testCase{
+			"valid":             {value: "30s", max: time.Minute, expectError: false},
+			"valid same as max": {value: "1m", max: time.Minute, expectError: false},
+			"invalid":           {value: "1h", max: time.Minute, expectError: true, errorText: "Field FieldName must be at most 1m0s"},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+
+	t.Run("ValidURL", func(t *testing.T) {
+		type testCase struct {
+			value       any
+			expectError bool
+			errorText   string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			err := validator.Validate(tc.value, testFieldName).ValidURL().Error(context.Background())
+			assertValidatorError(t, err, tc.expectError, tc.errorText)
+		}
+
+		testCases := map[string]testCase{
+			"valid":            {value: "https://example.com/path?query=1", expectError: false},
+			"valid with port":  {value: "http://localhost:8080", expectError: false},
+			"invalid relative": {value: "/path", expectError: true, errorText: "Field FieldName must be a valid absolute URL"},
+			"invalid no host":  {value: "mailto:user@example.com", expectError: true, errorText: "Field FieldName must be a valid absolute URL"},
+			"invalid":          {value: "http://[::1", expectError: true, errorText: "Field FieldName must be a valid absolute URL"},
+			"wrong type":       {value: 1, expectError: true, errorText: "invalid type for valid URL validator int"},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+	t.Run("ValidExpression", func(t *testing.T) {
+		type testCase struct {
+			value       any
+			valueType   ExpressionValueType
+			expectError bool
+			errorText   string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			err := validator.Validate(tc.value, testFieldName).ValidExpression(tc.valueType).Error(context.Background())
+			assertValidatorError(t, err, tc.expectError, tc.errorText)
+		}
+
+		testCases := map[string]testCase{
+			"valid bool":         {value: "value % 1024 == 0", valueType: ExpressionValueTypeInt, expectError: false},
+			"valid string":       {value: `value.startsWith("a") ? "" : "must start with a"`, valueType: ExpressionValueTypeString, expectError: false},
+			"valid dyn":          {value: "value.max >= value.min", valueType: ExpressionValueTypeDyn, expectError: false},
+			"valid list":         {value: "value.all(x, x > 0)", valueType: ExpressionValueTypeIntList, expectError: false},
+			"valid duration":     {value: `value <= duration("1h")`, valueType: ExpressionValueTypeDuration, expectError: false},
+			"invalid syntax":     {value: "value >", valueType: ExpressionValueTypeInt, expectError: true, errorText: "Field FieldName must be a valid CEL expression: 1:8: Syntax error: mismatched input '<EOF>' expecting {'[', '{', '(', '.', '-', '!', 'true', 'false', 'null', NUM_FLOAT, NUM_INT, NUM_UINT, STRING, BYTES, IDENTIFIER}"},
+			"invalid type":       {value: `value.startsWith("a")`, valueType: ExpressionValueTypeInt, expectError: true, errorText: "Field FieldName must be a valid CEL expression: 1:17: found no matching overload for 'startsWith' applied to 'int.(string)'"},
+			"invalid output":     {value: "value + 1", valueType: ExpressionValueTypeInt, expectError: true, errorText: "Field FieldName must be a valid CEL expression: expression must evaluate to bool or string, got int"},
+			"unknown variable":   {value: "other > 1", valueType: ExpressionValueTypeInt, expectError: true, errorText: "Field FieldName must be a valid CEL expression: 1:1: undeclared reference to 'other' (in container '')"},
+			"unknown value type": {value: "true", valueType: "unknown", expectError: true, errorText: "Field FieldName must be a valid CEL expression: unsupported expression value type unknown"},
+			"wrong type":         {value: 1, valueType: ExpressionValueTypeInt, expectError: true, errorText: "invalid type for valid expression validator int"},
+		}
+
+		test.RunCases(t, run, testCases)
+	})
+
+	t.Run("Expression", func(t *testing.T) {
+		type testCase struct {
+			value       any
+			expression  string
+			valueType   ExpressionValueType
+			errorText   string
+			secret      bool
+			expectError bool
+			expectedErr string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			expression, err := CompileExpression(tc.expression, tc.valueType)
+			assert.NilError(t, err)
+
+			v := validator.Validate(tc.value, testFieldName)
+			if tc.secret {
+				v = v.SecretExpression(expression, tc.errorText)
+			} else {
+				v = v.Expression(expression, tc.errorText)
+			}
+
+			err = v.Error(context.Background())
+			assertValidatorError(t, err, tc.expectError, tc.expectedErr)
+		}
+
+		testCases := map[string]testCase{
+			"valid int":              {value: "2048", expression: "value % 1024 == 0", valueType: ExpressionValueTypeInt},
+			"invalid int":            {value: "1000", expression: "value % 1024 == 0", valueType: ExpressionValueTypeInt, expectError: true, expectedErr: "Field FieldName must satisfy value % 1024 == 0"},
+			"valid json":             {value: `{"min":1,"max":2}`, expression: "value.max >= value.min", valueType: ExpressionValueTypeDyn},
+			"invalid json":           {value: `{"min":3,"max":2}`, expression: "value.max >= value.min", valueType: ExpressionValueTypeDyn, expectError: true, errorText: "Max must not be less than min", expectedErr: "Max must not be less than min"},
+			"missing json field":     {value: `{"min":3}`, expression: "value.max >= value.min", valueType: ExpressionValueTypeDyn, expectError: true, expectedErr: "Field FieldName could not be checked against value.max >= value.min: unable to evaluate expression: no such key: max"},
+			"valid string result":    {value: "abc", expression: `value.startsWith("a") ? "" : "does not start with a"`, valueType: ExpressionValueTypeString},
+			"invalid string result":  {value: "xyz", expression: `value.startsWith("a") ? "" : "does not start with a"`, valueType: ExpressionValueTypeString, expectError: true, expectedErr: `Field FieldName must satisfy value.startsWith("a") ? "" : "does not start with a": does not start with a`},
+			"error text with result": {value: "xyz", expression: `value.startsWith("a") ? "" : value`, valueType: ExpressionValueTypeString, errorText: "Value {0} must start with a", expectError: true, expectedErr: "Value xyz must start with a"},
+			"valid list":             {value: `["a","b"]`, expression: "value.size() == 2", valueType: ExpressionValueTypeStringList},
+			"invalid int list":       {value: `[1,-2]`, expression: "value.all(x, x > 0)", valueType: ExpressionValueTypeIntList, expectError: true, expectedErr: "Field FieldName must satisfy value.all(x, x > 0)"},
+			"valid duration":         {value: "30m", expression: `value <= duration("1h")`, valueType: ExpressionValueTypeDuration},
+			"invalid duration":       {value: "2h", expression: `value <= duration("1h")`, valueType: ExpressionValueTypeDuration, expectError: true, expectedErr: `Field FieldName must satisfy value <= duration("1h")`},
+			"valid bool":             {value: "TRUE", expression: "value", valueType: ExpressionValueTypeBool},
+			"valid double":           {value: "1.5", expression: "value < 2.0", valueType: ExpressionValueTypeDouble},
+			"unparsable value":       {value: "abc", expression: "value > 1", valueType: ExpressionValueTypeInt, expectError: true, expectedErr: "Field FieldName could not be checked against value > 1: unable to evaluate expression: value is not a valid int"},
+			"wrong type":             {value: 1, expression: "value > 1", valueType: ExpressionValueTypeInt, expectError: true, expectedErr: "invalid type for expression validator int"},
+			"secret result":          {value: "hunter2", expression: `value.size() > 10 ? "" : value`, valueType: ExpressionValueTypeString, secret: true, expectError: true, expectedErr: `Field FieldName must satisfy value.size() > 10 ? "" : value`},
+			"secret error text":      {value: "hunter2", expression: `value.size() > 10 ? "" : value`, valueType: ExpressionValueTypeString, secret: true, errorText: "Value {0} is too short", expectError: true, expectedErr: "Value ******** is too short"},
+			"secret eval error":      {value: `{"a":"hunter2"}`, expression: "value.b == 1", valueType: ExpressionValueTypeDyn, secret: true, expectError: true, expectedErr: "Field FieldName could not be checked against value.b == 1"},
+			"invalid bool":           {value: "false", expression: "value", valueType: ExpressionValueTypeBool, expectError: true, expectedErr: "Field FieldName must satisfy value"},
+		}
+
 		test.RunCases(t, run, testCases)
 	})
 }
@@ -2,14 +2,17 @@ package configserviceclient
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log/slog"
+	"maps"
 	"reflect"
 	"time"
 
 	grpcgen "github.com/necroskillz/config-service/go-client/grpc/gen"
 	"github.com/necroskillz/config-service/go-client/internal"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 )
@@ -26,7 +29,7 @@ type Config struct {
 	Url string
 	// Name:Version of the services to fetch configuration for
 	Services map[string]int
-	// Interval at which to poll for configuration updates
+	// Interval at which to poll for configuration updates. When streaming is enabled, polling is only used while the stream is unavailable
 	PollingInterval time.Duration
 	// Interval at which to cleanup unused configuration snapshots
 	SnapshotCleanupInterval time.Duration
@@ -38,12 +41,16 @@ type Config struct {
 type options struct {
 	staticVariation           map[string]string
 	dynamicVariationResolvers map[string]PropertyResolverFunc
+	rolloutAttributeResolvers map[string]PropertyResolverFunc
 	features                  []Feature
 	changesetOverrider        func(ctx context.Context) *uint32
 	loggerFunc                func(ctx context.Context, level slog.Level, msg string, fields ...any)
 	productionMode            bool
+	streaming                 bool
 	fallbackFileLocation      string
 	overrides                 internal.Overrides
+	apiKey                    string
+	transportCredentials      credentials.TransportCredentials
 }
 
 // Option configures the ConfigClient
@@ -63,6 +70,14 @@ func WithDynamicVariationResolver(property string, resolver PropertyResolverFunc
 	}
 }
 
+// WithRolloutAttributeResolver sets a resolver of an attribute that rolled out values are bucketed by, e.g. user_id.
+// Values of the static variation and of dynamic variation properties can be used as rollout attributes without a resolver.
+func WithRolloutAttributeResolver(attribute string, resolver PropertyResolverFunc) Option {
+	return func(opts *options) {
+		opts.rolloutAttributeResolvers[attribute] = resolver
+	}
+}
+
 // WithFeatures registers features that can be bound
 func WithFeatures(features ...Feature) Option {
 	return func(opts *options) {
@@ -91,6 +106,13 @@ func WithProductionMode(productionMode bool) Option {
 	}
 }
 
+// WithStreaming enables or disables streaming of configuration updates. When enabled (default), the server pushes applied changesets to the client and polling is used only as a fallback.
+func WithStreaming(streaming bool) Option {
+	return func(opts *options) {
+		opts.streaming = streaming
+	}
+}
+
 // WithFallbackFileLocation sets the location (base directory) of the fallback file. The latest configuration is stored in a json file and can be loaded from there in case of a service outage.
 func WithFallbackFileLocation(fallbackFileLocation string) Option {
 	return func(opts *options) {
@@ -105,6 +127,30 @@ func WithOverride(feature string, key string, value any) Option {
 	}
 }
 
+// WithApiKey sets the API key of the client credential. The server only serves configuration of the services granted to the credential.
+func WithApiKey(apiKey string) Option {
+	return func(opts *options) {
+		opts.apiKey = apiKey
+	}
+}
+
+// WithTLS connects to the server over TLS, verifying the server certificate with the system root CAs
+func WithTLS() Option {
+	return WithTLSConfig(&tls.Config{})
+}
+
+// WithTLSConfig connects to the server over TLS with a custom configuration, e.g. a private root CA or a client certificate for mTLS
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return WithTransportCredentials(credentials.NewTLS(tlsConfig))
+}
+
+// WithTransportCredentials sets the transport credentials of the gRPC connection. By default, the connection is not encrypted.
+func WithTransportCredentials(transportCredentials credentials.TransportCredentials) Option {
+	return func(opts *options) {
+		opts.transportCredentials = transportCredentials
+	}
+}
+
 // ConfigClient provides access to configuration data
 type ConfigClient struct {
 	config                  *internal.Config
@@ -112,6 +158,7 @@ type ConfigClient struct {
 	snapshotManager         *internal.ConfigurationSnapshotManager
 	variationHierarchyStore *internal.VariationHierarchyStore
 	grpcConn                *grpc.ClientConn
+	subscriptions           *subscriptions
 }
 
 // New creates a new ConfigClient instance
@@ -123,9 +170,12 @@ func New(c Config, o ...Option) *ConfigClient {
 
 	opts := &options{
 		productionMode:            true,
+		streaming:                 true,
 		staticVariation:           make(map[string]string),
 		dynamicVariationResolvers: make(map[string]PropertyResolverFunc),
+		rolloutAttributeResolvers: make(map[string]PropertyResolverFunc),
 		overrides:                 make(internal.Overrides),
+		transportCredentials:      insecure.NewCredentials(),
 	}
 
 	for _, o := range o {
@@ -137,8 +187,10 @@ func New(c Config, o ...Option) *ConfigClient {
 		Services:                  services,
 		StaticVariation:           opts.staticVariation,
 		DynamicVariationResolvers: opts.dynamicVariationResolvers,
+		RolloutAttributeResolvers: opts.rolloutAttributeResolvers,
 		Features:                  opts.features,
 		ProductionMode:            opts.productionMode,
+		Streaming:                 opts.streaming,
 		ChangesetOverrider:        opts.changesetOverrider,
 		PollingInterval:           c.PollingInterval,
 		SnapshotCleanupInterval:   c.SnapshotCleanupInterval,
@@ -146,6 +198,8 @@ func New(c Config, o ...Option) *ConfigClient {
 		FallbackFileLocation:      opts.fallbackFileLocation,
 		Logger:                    internal.NewLogger(opts.loggerFunc),
 		Overrides:                 opts.overrides,
+		ApiKey:                    opts.apiKey,
+		TransportCredentials:      opts.transportCredentials,
 	}
 
 	if config.PollingInterval == 0 {
@@ -172,12 +226,13 @@ func New(c Config, o ...Option) *ConfigClient {
 	client := &ConfigClient{
 		config:                 config,
 		registeredFeatureTypes: registeredFeatureTypes,
+		subscriptions:          newSubscriptions(),
 	}
 
 	return client
 }
 
-// Start initializes the client and begins polling for configuration updates
+// Start initializes the client and begins watching for configuration updates
 func (c *ConfigClient) Start(ctx context.Context) error {
 	if c.config.Url == "" {
 		return fmt.Errorf("config service url is not set")
@@ -191,16 +246,20 @@ func (c *ConfigClient) Start(ctx context.Context) error {
 		return fmt.Errorf("config client already started")
 	}
 
-	conn, err := grpc.NewClient(
-		c.config.Url,
-		// TODO: add TLS support
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	dialOptions := []grpc.DialOption{
+		grpc.WithTransportCredentials(c.config.TransportCredentials),
 		grpc.WithKeepaliveParams(keepalive.ClientParameters{
 			Time:                5 * time.Minute,
 			Timeout:             10 * time.Second,
 			PermitWithoutStream: true,
 		}),
-	)
+	}
+
+	if c.config.ApiKey != "" {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(internal.NewApiKeyCredentials(c.config.ApiKey)))
+	}
+
+	conn, err := grpc.NewClient(c.config.Url, dialOptions...)
 	if err != nil {
 		return fmt.Errorf("failed to create grpc client: %w", err)
 	}
@@ -209,8 +268,15 @@ func (c *ConfigClient) Start(ctx context.Context) error {
 	configClient := grpcgen.NewConfigServiceClient(conn)
 	dataLoader := internal.NewConfigurationDataLoader(configClient, c.config)
 	variationHierarchyStore := internal.NewVariationHierarchyStore(dataLoader, c.config)
-	pollJob := internal.NewConfigurationPollJob(c.config, variationHierarchyStore, dataLoader)
-	snapshotManager := internal.NewConfigurationSnapshotManager(dataLoader, c.config, pollJob)
+
+	var poller internal.ConfigurationPoller
+	if c.config.Streaming {
+		poller = internal.NewConfigurationWatchJob(c.config, variationHierarchyStore, dataLoader)
+	} else {
+		poller = internal.NewConfigurationPollJob(c.config, variationHierarchyStore, dataLoader)
+	}
+
+	snapshotManager := internal.NewConfigurationSnapshotManager(dataLoader, c.config, poller, c.onLatestSnapshot)
 
 	c.variationHierarchyStore = variationHierarchyStore
 	c.snapshotManager = snapshotManager
@@ -224,11 +290,15 @@ func (c *ConfigClient) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to initialize configuration: %w", err)
 	}
 
+	c.subscriptions.start(c.notifySubscriptions)
+
 	return nil
 }
 
 // Stop gracefully shuts down the client
 func (c *ConfigClient) Stop(ctx context.Context) error {
+	c.subscriptions.stop()
+
 	err := c.snapshotManager.Shutdown(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to shutdown client: %w", err)
@@ -248,6 +318,10 @@ func (c *ConfigClient) BindFeature(ctx context.Context, out Feature) error {
 		return fmt.Errorf("failed to get configuration: %w", err)
 	}
 
+	return c.bindFeature(ctx, snapshot, out)
+}
+
+func (c *ConfigClient) bindFeature(ctx context.Context, snapshot *internal.ConfigurationSnapshot, out Feature) error {
 	variationHierarchy, err := c.variationHierarchyStore.GetVariationHierarchy(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get variation hierarchy: %w", err)
@@ -258,12 +332,16 @@ func (c *ConfigClient) BindFeature(ctx context.Context, out Feature) error {
 	}
 
 	variationWithParents := make(map[string][]string)
+	attributes := make(map[string]string)
+	maps.Copy(attributes, c.config.StaticVariation)
 	for property, resolver := range c.config.DynamicVariationResolvers {
 		value, err := resolver(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to get value for property %s: %w", property, err)
 		}
 
+		attributes[property] = value
+
 		parents, err := variationHierarchy.GetParents(property, value)
 		if err != nil {
 			return fmt.Errorf("failed resolve property %s with value %s: %w", property, value, err)
@@ -272,5 +350,14 @@ func (c *ConfigClient) BindFeature(ctx context.Context, out Feature) error {
 		variationWithParents[property] = parents
 	}
 
-	return snapshot.BindFeature(out, variationWithParents, c.config.Overrides)
+	for attribute, resolver := range c.config.RolloutAttributeResolvers {
+		value, err := resolver(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get value for rollout attribute %s: %w", attribute, err)
+		}
+
+		attributes[attribute] = value
+	}
+
+	return snapshot.BindFeature(out, variationWithParents, attributes, c.config.Overrides)
 }
@@ -0,0 +1,244 @@
+package configserviceclient
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/necroskillz/config-service/go-client/internal"
+)
+
+type subscription struct {
+	ctx     context.Context
+	current Feature
+	// version is incremented whenever current changes, under notifyMutex
+	version uint64
+	notify  func(old Feature, new Feature)
+	// deliverMutex serializes the callbacks of the subscription, they are called without holding notifyMutex, so
+	// callbacks can subscribe and unsubscribe
+	deliverMutex sync.Mutex
+	delivered    uint64
+	removed      atomic.Bool
+}
+
+// deliver calls the callback unless the subscription was removed or a newer version was already delivered
+func (sub *subscription) deliver(version uint64, old Feature, new Feature) {
+	sub.deliverMutex.Lock()
+	defer sub.deliverMutex.Unlock()
+
+	if sub.removed.Load() || version <= sub.delivered {
+		return
+	}
+
+	sub.delivered = version
+	sub.notify(old, new)
+}
+
+type notification struct {
+	sub     *subscription
+	version uint64
+	old     Feature
+	new     Feature
+}
+
+type subscriptions struct {
+	// notifyMutex serializes binding the features of the subscriptions and subscribing, so no changes are missed
+	notifyMutex sync.Mutex
+	mutex       sync.Mutex
+	items       map[*subscription]struct{}
+	latest      atomic.Pointer[internal.ConfigurationSnapshot]
+	changed     chan struct{}
+	cancel      context.CancelFunc
+}
+
+func newSubscriptions() *subscriptions {
+	return &subscriptions{
+		items:   make(map[*subscription]struct{}),
+		changed: make(chan struct{}, 1),
+	}
+}
+
+func (s *subscriptions) add(sub *subscription) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.items[sub] = struct{}{}
+}
+
+func (s *subscriptions) remove(sub *subscription) {
+	sub.removed.Store(true)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.items, sub)
+}
+
+func (s *subscriptions) list() []*subscription {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	items := make([]*subscription, 0, len(s.items))
+	for sub := range s.items {
+		items = append(items, sub)
+	}
+
+	return items
+}
+
+// publish records the new latest snapshot without blocking the caller. Snapshots published faster than they are
+// processed are skipped, only the latest one is compared to the current values.
+func (s *subscriptions) publish(snapshot *internal.ConfigurationSnapshot) {
+	s.latest.Store(snapshot)
+
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (s *subscriptions) start(process func(snapshot *internal.ConfigurationSnapshot)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.changed:
+				process(s.latest.Load())
+			}
+		}
+	}()
+}
+
+func (s *subscriptions) stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+// newFeatureOf returns a new zero value instance of the feature type
+func newFeatureOf(feature Feature) Feature {
+	return reflect.New(reflect.TypeOf(feature).Elem()).Interface().(Feature)
+}
+
+func (c *ConfigClient) subscribe(ctx context.Context, feature Feature, notifyCurrent bool, notify func(old Feature, new Feature)) (*subscription, error) {
+	if c.snapshotManager == nil {
+		return nil, fmt.Errorf("config client is not started")
+	}
+
+	if !c.registeredFeatureTypes[reflect.TypeOf(feature)] {
+		return nil, fmt.Errorf("feature type %T was not registered in Config.Features", feature)
+	}
+
+	c.subscriptions.notifyMutex.Lock()
+
+	current := newFeatureOf(feature)
+	if err := c.BindFeature(ctx, current); err != nil {
+		c.subscriptions.notifyMutex.Unlock()
+		return nil, err
+	}
+
+	sub := &subscription{
+		ctx:     ctx,
+		current: current,
+		version: 1,
+		notify:  notify,
+	}
+
+	c.subscriptions.add(sub)
+	c.subscriptions.notifyMutex.Unlock()
+
+	if notifyCurrent {
+		sub.deliver(1, nil, current)
+	}
+
+	return sub, nil
+}
+
+func (c *ConfigClient) onLatestSnapshot(ctx context.Context, snapshot *internal.ConfigurationSnapshot) {
+	c.subscriptions.publish(snapshot)
+}
+
+// notifySubscriptions binds the features of all subscriptions from the new latest snapshot and notifies subscribers whose values changed
+func (c *ConfigClient) notifySubscriptions(snapshot *internal.ConfigurationSnapshot) {
+	c.subscriptions.notifyMutex.Lock()
+
+	notifications := []notification{}
+	for _, sub := range c.subscriptions.list() {
+		next := newFeatureOf(sub.current)
+		if err := c.bindFeature(sub.ctx, snapshot, next); err != nil {
+			c.config.Logger.Error(sub.ctx, "failed to bind feature for change subscription", "feature", next.FeatureName(), "changeset_id", snapshot.ChangesetId, "error", err)
+			continue
+		}
+
+		if reflect.DeepEqual(sub.current, next) {
+			continue
+		}
+
+		notifications = append(notifications, notification{sub: sub, version: sub.version + 1, old: sub.current, new: next})
+		sub.current = next
+		sub.version++
+	}
+
+	c.subscriptions.notifyMutex.Unlock()
+
+	// callbacks are called without the lock, so they can subscribe and unsubscribe
+	for _, n := range notifications {
+		n.sub.deliver(n.version, n.old, n.new)
+	}
+}
+
+// OnChange registers a callback that is called with the old and new values of the feature whenever a new configuration
+// changes any of its bound values. Dynamic variation resolvers are called with a background context.
+// Callbacks are called sequentially from a single goroutine, so they should not block. They are called without holding
+// any locks, so they can subscribe and unsubscribe.
+// The returned function removes the callback.
+func (c *ConfigClient) OnChange(feature Feature, callback func(old Feature, new Feature)) (func(), error) {
+	sub, err := c.subscribe(context.Background(), feature, false, callback)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		c.subscriptions.remove(sub)
+	}, nil
+}
+
+// Watch returns a channel that receives the current value of the feature, and a new value whenever a new configuration
+// changes any of its bound values. If the receiver falls behind, only the latest value is kept.
+// Dynamic variation resolvers are called with ctx. The channel is closed when ctx is done.
+func (c *ConfigClient) Watch(ctx context.Context, feature Feature) (<-chan Feature, error) {
+	values := make(chan Feature, 1)
+
+	sub, err := c.subscribe(ctx, feature, true, func(old Feature, new Feature) {
+		// Drop the stale value the receiver has not picked up yet, this is the only sender so the send never blocks
+		select {
+		case <-values:
+		default:
+		}
+
+		values <- new
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		c.subscriptions.remove(sub)
+
+		// Wait for a notification that might still be in progress before closing the channel, removed subscriptions
+		// are not notified anymore
+		sub.deliverMutex.Lock()
+		close(values)
+		sub.deliverMutex.Unlock()
+	}()
+
+	return values, nil
+}
@@ -0,0 +1,225 @@
+package configserviceclient
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	grpcgen "github.com/necroskillz/config-service/go-client/grpc/gen"
+	"github.com/necroskillz/config-service/go-client/internal/test"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gotest.tools/v3/assert"
+)
+
+type subscriptionTestFeature struct {
+	Value string
+}
+
+func (f *subscriptionTestFeature) FeatureName() string {
+	return "TestService.SubscriptionTestFeature"
+}
+
+type unregisteredTestFeature struct{}
+
+func (f *unregisteredTestFeature) FeatureName() string {
+	return "TestService.UnregisteredTestFeature"
+}
+
+type changesetTestServer struct {
+	grpcgen.UnimplementedConfigServiceServer
+	mu         sync.Mutex
+	changesets []*grpcgen.GetConfigurationResponse
+}
+
+func (s *changesetTestServer) apply(configure func(builder *test.TestConfigurationReponseBuilder)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	builder := test.NewTestConfigurationReponseBuilder().WithChangesetId(uint32(len(s.changesets) + 1))
+	configure(builder)
+
+	response := builder.Response()
+	response.AppliedAt = timestamppb.Now()
+
+	s.changesets = append(s.changesets, response)
+}
+
+func (s *changesetTestServer) GetVariationHierarchy(ctx context.Context, req *grpcgen.GetVariationHierarchyRequest) (*grpcgen.GetVariationHierarchyResponse, error) {
+	return test.NewTestVariationHierarchyResponseBuilder().Response(), nil
+}
+
+func (s *changesetTestServer) GetConfiguration(ctx context.Context, req *grpcgen.GetConfigurationRequest) (*grpcgen.GetConfigurationResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.ChangesetId == nil {
+		return s.changesets[len(s.changesets)-1], nil
+	}
+
+	return s.changesets[*req.ChangesetId-1], nil
+}
+
+func (s *changesetTestServer) GetNextChangesets(ctx context.Context, req *grpcgen.GetNextChangesetsRequest) (*grpcgen.GetNextChangesetsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var changesetIds []uint32
+	for _, changeset := range s.changesets {
+		if changeset.ChangesetId > req.AfterChangesetId {
+			changesetIds = append(changesetIds, changeset.ChangesetId)
+		}
+	}
+
+	return &grpcgen.GetNextChangesetsResponse{ChangesetIds: changesetIds}, nil
+}
+
+func startChangesetTestServer(t *testing.T) (*changesetTestServer, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+
+	service := &changesetTestServer{}
+	server := grpc.NewServer()
+	grpcgen.RegisterConfigServiceServer(server, service)
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	return service, listener.Addr().String()
+}
+
+func withValue(value string) func(builder *test.TestConfigurationReponseBuilder) {
+	return func(builder *test.TestConfigurationReponseBuilder) {
+		builder.WithDefaultValue("TestService.SubscriptionTestFeature", "Value", "string", value)
+	}
+}
+
+func receive(t *testing.T, values <-chan Feature) *subscriptionTestFeature {
+	select {
+	case value := <-values:
+		return value.(*subscriptionTestFeature)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for feature value")
+		return nil
+	}
+}
+
+func TestClientSubscriptions(t *testing.T) {
+	server, address := startChangesetTestServer(t)
+	server.apply(withValue("a"))
+
+	client := New(Config{
+		Url:             address,
+		Services:        map[string]int{"TestService": 1},
+		PollingInterval: 10 * time.Millisecond,
+	}, WithStreaming(false), WithFeatures(&subscriptionTestFeature{}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	assert.NilError(t, client.Start(ctx))
+	defer client.Stop(context.Background())
+
+	type change struct {
+		Old string
+		New string
+	}
+
+	changes := make(chan change, 10)
+	unsubscribe, err := client.OnChange(&subscriptionTestFeature{}, func(old Feature, new Feature) {
+		changes <- change{Old: old.(*subscriptionTestFeature).Value, New: new.(*subscriptionTestFeature).Value}
+	})
+	assert.NilError(t, err)
+
+	watchCtx, stopWatching := context.WithCancel(ctx)
+	values, err := client.Watch(watchCtx, &subscriptionTestFeature{})
+	assert.NilError(t, err)
+
+	assert.Equal(t, receive(t, values).Value, "a")
+
+	server.apply(withValue("b"))
+	assert.Equal(t, receive(t, values).Value, "b")
+
+	// A changeset that doesn't change the bound values is not reported
+	server.apply(func(builder *test.TestConfigurationReponseBuilder) {
+		withValue("b")(builder)
+		builder.WithDefaultValue("TestService.OtherFeature", "Other", "string", "x")
+	})
+	server.apply(withValue("c"))
+	assert.Equal(t, receive(t, values).Value, "c")
+
+	unsubscribe()
+
+	stopWatching()
+	_, open := <-values
+	assert.Assert(t, !open)
+
+	close(changes)
+	var received []change
+	for c := range changes {
+		received = append(received, c)
+	}
+
+	assert.DeepEqual(t, received, []change{{Old: "a", New: "b"}, {Old: "b", New: "c"}})
+}
+
+func TestClientSubscriptionsUnregisteredFeature(t *testing.T) {
+	server, address := startChangesetTestServer(t)
+	server.apply(withValue("a"))
+
+	client := New(Config{
+		Url:      address,
+		Services: map[string]int{"TestService": 1},
+	}, WithStreaming(false), WithFeatures(&subscriptionTestFeature{}))
+
+	ctx := context.Background()
+
+	_, err := client.Watch(ctx, &subscriptionTestFeature{})
+	assert.ErrorContains(t, err, "config client is not started")
+
+	assert.NilError(t, client.Start(ctx))
+	defer client.Stop(ctx)
+
+	_, err = client.OnChange(&unregisteredTestFeature{}, func(old Feature, new Feature) {})
+	assert.ErrorContains(t, err, "was not registered")
+}
+
+func TestClientSubscriptionsSubscribeFromCallback(t *testing.T) {
+	server, address := startChangesetTestServer(t)
+	server.apply(withValue("a"))
+
+	client := New(Config{
+		Url:             address,
+		Services:        map[string]int{"TestService": 1},
+		PollingInterval: 10 * time.Millisecond,
+	}, WithStreaming(false), WithFeatures(&subscriptionTestFeature{}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	assert.NilError(t, client.Start(ctx))
+	defer client.Stop(context.Background())
+
+	values := make(chan Feature, 10)
+	var unsubscribe func()
+	unsubscribe, err := client.OnChange(&subscriptionTestFeature{}, func(old Feature, new Feature) {
+		// A callback replaces itself with another one
+		unsubscribe()
+
+		_, err := client.OnChange(&subscriptionTestFeature{}, func(old Feature, new Feature) {
+			values <- new
+		})
+		assert.Check(t, err)
+
+		values <- new
+	})
+	assert.NilError(t, err)
+
+	server.apply(withValue("b"))
+	assert.Equal(t, receive(t, values).Value, "b")
+
+	server.apply(withValue("c"))
+	assert.Equal(t, receive(t, values).Value, "c")
+}
@@ -0,0 +1,139 @@
+package configserviceclient
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	grpcgen "github.com/necroskillz/config-service/go-client/grpc/gen"
+	"github.com/necroskillz/config-service/go-client/internal/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gotest.tools/v3/assert"
+)
+
+type tlsTestServer struct {
+	grpcgen.UnimplementedConfigServiceServer
+	mu                sync.Mutex
+	clientCommonNames []string
+}
+
+func (s *tlsTestServer) recordPeer(ctx context.Context) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clientCommonNames = append(s.clientCommonNames, tlsInfo.State.VerifiedChains[0][0].Subject.CommonName)
+}
+
+func (s *tlsTestServer) GetVariationHierarchy(ctx context.Context, req *grpcgen.GetVariationHierarchyRequest) (*grpcgen.GetVariationHierarchyResponse, error) {
+	s.recordPeer(ctx)
+
+	return test.NewTestVariationHierarchyResponseBuilder().WithValue("env", "prod").Response(), nil
+}
+
+func (s *tlsTestServer) GetConfiguration(ctx context.Context, req *grpcgen.GetConfigurationRequest) (*grpcgen.GetConfigurationResponse, error) {
+	s.recordPeer(ctx)
+
+	response := test.NewTestConfigurationReponseBuilder().WithChangesetId(1).WithDefaultValue("Feature", "Key", "string", "value").Response()
+	response.AppliedAt = timestamppb.Now()
+
+	return response, nil
+}
+
+func startTLSTestServer(t *testing.T, tlsConfig *tls.Config) (*tlsTestServer, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+
+	service := &tlsTestServer{}
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	grpcgen.RegisterConfigServiceServer(server, service)
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	return service, listener.Addr().String()
+}
+
+func TestClientTLS(t *testing.T) {
+	ca := test.NewTestCertificateAuthority(t)
+	serverCertificate := ca.ServerCertificate(t)
+
+	type testCase struct {
+		clientAuth        tls.ClientAuthType
+		options           []Option
+		expectedError     bool
+		clientCommonNames []string
+	}
+
+	test.RunCases(t, func(t *testing.T, tc testCase) {
+		service, address := startTLSTestServer(t, &tls.Config{
+			Certificates: []tls.Certificate{serverCertificate},
+			ClientCAs:    ca.CertPool(),
+			ClientAuth:   tc.clientAuth,
+		})
+
+		client := New(Config{
+			Url:      address,
+			Services: map[string]int{"TestService": 1},
+		}, append(tc.options, WithStreaming(false))...)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		err := client.Start(ctx)
+		if tc.expectedError {
+			assert.Assert(t, err != nil)
+			return
+		}
+
+		assert.NilError(t, err)
+		assert.NilError(t, client.Stop(ctx))
+
+		assert.DeepEqual(t, service.clientCommonNames, tc.clientCommonNames)
+	}, map[string]testCase{
+		"server certificate signed by trusted CA": {
+			clientAuth: tls.NoClientCert,
+			options:    []Option{WithTLSConfig(&tls.Config{RootCAs: ca.CertPool()})},
+		},
+		"server certificate signed by unknown CA": {
+			clientAuth:    tls.NoClientCert,
+			options:       []Option{WithTLS()},
+			expectedError: true,
+		},
+		"plaintext client": {
+			clientAuth:    tls.NoClientCert,
+			expectedError: true,
+		},
+		"client certificate": {
+			clientAuth: tls.RequireAndVerifyClientCert,
+			options: []Option{WithTLSConfig(&tls.Config{
+				RootCAs:      ca.CertPool(),
+				Certificates: []tls.Certificate{ca.ClientCertificate(t, "test-client")},
+			})},
+			clientCommonNames: []string{"test-client", "test-client"},
+		},
+		"missing client certificate": {
+			clientAuth:    tls.RequireAndVerifyClientCert,
+			options:       []Option{WithTLSConfig(&tls.Config{RootCAs: ca.CertPool()})},
+			expectedError: true,
+		},
+		"transport credentials": {
+			clientAuth: tls.NoClientCert,
+			options:    []Option{WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: ca.CertPool()}))},
+		},
+	})
+}
@@ -20,8 +20,10 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	ConfigService_GetConfiguration_FullMethodName      = "/grpcgen.ConfigService/GetConfiguration"
+	ConfigService_GetConfigurationDelta_FullMethodName = "/grpcgen.ConfigService/GetConfigurationDelta"
 	ConfigService_GetNextChangesets_FullMethodName     = "/grpcgen.ConfigService/GetNextChangesets"
 	ConfigService_GetVariationHierarchy_FullMethodName = "/grpcgen.ConfigService/GetVariationHierarchy"
+	ConfigService_WatchConfiguration_FullMethodName    = "/grpcgen.ConfigService/WatchConfiguration"
 )
 
 // ConfigServiceClient is the client API for ConfigService service.
@@ -30,10 +32,14 @@ const (
 type ConfigServiceClient interface {
 	// Get configuration for specified services and variation
 	GetConfiguration(ctx context.Context, in *GetConfigurationRequest, opts ...grpc.CallOption) (*GetConfigurationResponse, error)
+	// Get only the changes in configuration between two changesets
+	GetConfigurationDelta(ctx context.Context, in *GetConfigurationDeltaRequest, opts ...grpc.CallOption) (*GetConfigurationDeltaResponse, error)
 	// Get changesets that happened after a specific changeset ID
 	GetNextChangesets(ctx context.Context, in *GetNextChangesetsRequest, opts ...grpc.CallOption) (*GetNextChangesetsResponse, error)
 	// Get variation hierarchy
 	GetVariationHierarchy(ctx context.Context, in *GetVariationHierarchyRequest, opts ...grpc.CallOption) (*GetVariationHierarchyResponse, error)
+	// Stream changesets applied after a specific changeset ID as they are applied
+	WatchConfiguration(ctx context.Context, in *WatchConfigurationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchConfigurationResponse], error)
 }
 
 type configServiceClient struct {
@@ -54,6 +60,16 @@ func (c *configServiceClient) GetConfiguration(ctx context.Context, in *GetConfi
 	return out, nil
 }
 
+func (c *configServiceClient) GetConfigurationDelta(ctx context.Context, in *GetConfigurationDeltaRequest, opts ...grpc.CallOption) (*GetConfigurationDeltaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConfigurationDeltaResponse)
+	err := c.cc.Invoke(ctx, ConfigService_GetConfigurationDelta_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *configServiceClient) GetNextChangesets(ctx context.Context, in *GetNextChangesetsRequest, opts ...grpc.CallOption) (*GetNextChangesetsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetNextChangesetsResponse)
@@ -74,16 +90,39 @@ func (c *configServiceClient) GetVariationHierarchy(ctx context.Context, in *Get
 	return out, nil
 }
 
+func (c *configServiceClient) WatchConfiguration(ctx context.Context, in *WatchConfigurationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchConfigurationResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ConfigService_ServiceDesc.Streams[0], ConfigService_WatchConfiguration_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchConfigurationRequest, WatchConfigurationResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ConfigService_WatchConfigurationClient = grpc.ServerStreamingClient[WatchConfigurationResponse]
+
 // ConfigServiceServer is the server API for ConfigService service.
 // All implementations must embed UnimplementedConfigServiceServer
 // for forward compatibility.
 type ConfigServiceServer interface {
 	// Get configuration for specified services and variation
 	GetConfiguration(context.Context, *GetConfigurationRequest) (*GetConfigurationResponse, error)
+	// Get only the changes in configuration between two changesets
+	GetConfigurationDelta(context.Context, *GetConfigurationDeltaRequest) (*GetConfigurationDeltaResponse, error)
 	// Get changesets that happened after a specific changeset ID
 	GetNextChangesets(context.Context, *GetNextChangesetsRequest) (*GetNextChangesetsResponse, error)
 	// Get variation hierarchy
 	GetVariationHierarchy(context.Context, *GetVariationHierarchyRequest) (*GetVariationHierarchyResponse, error)
+	// Stream changesets applied after a specific changeset ID as they are applied
+	WatchConfiguration(*WatchConfigurationRequest, grpc.ServerStreamingServer[WatchConfigurationResponse]) error
 	mustEmbedUnimplementedConfigServiceServer()
 }
 
@@ -97,12 +136,18 @@ type UnimplementedConfigServiceServer struct{}
 func (UnimplementedConfigServiceServer) GetConfiguration(context.Context, *GetConfigurationRequest) (*GetConfigurationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetConfiguration not implemented")
 }
+func (UnimplementedConfigServiceServer) GetConfigurationDelta(context.Context, *GetConfigurationDeltaRequest) (*GetConfigurationDeltaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfigurationDelta not implemented")
+}
 func (UnimplementedConfigServiceServer) GetNextChangesets(context.Context, *GetNextChangesetsRequest) (*GetNextChangesetsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNextChangesets not implemented")
 }
 func (UnimplementedConfigServiceServer) GetVariationHierarchy(context.Context, *GetVariationHierarchyRequest) (*GetVariationHierarchyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetVariationHierarchy not implemented")
 }
+func (UnimplementedConfigServiceServer) WatchConfiguration(*WatchConfigurationRequest, grpc.ServerStreamingServer[WatchConfigurationResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchConfiguration not implemented")
+}
 func (UnimplementedConfigServiceServer) mustEmbedUnimplementedConfigServiceServer() {}
 func (UnimplementedConfigServiceServer) testEmbeddedByValue()                       {}
 
@@ -142,6 +187,24 @@ func _ConfigService_GetConfiguration_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ConfigService_GetConfigurationDelta_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigurationDeltaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConfigServiceServer).GetConfigurationDelta(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConfigService_GetConfigurationDelta_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConfigServiceServer).GetConfigurationDelta(ctx, req.(*GetConfigurationDeltaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ConfigService_GetNextChangesets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetNextChangesetsRequest)
 	if err := dec(in); err != nil {
@@ -178,6 +241,17 @@ func _ConfigService_GetVariationHierarchy_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ConfigService_WatchConfiguration_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchConfigurationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConfigServiceServer).WatchConfiguration(m, &grpc.GenericServerStream[WatchConfigurationRequest, WatchConfigurationResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ConfigService_WatchConfigurationServer = grpc.ServerStreamingServer[WatchConfigurationResponse]
+
 // ConfigService_ServiceDesc is the grpc.ServiceDesc for ConfigService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -189,6 +263,10 @@ var ConfigService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetConfiguration",
 			Handler:    _ConfigService_GetConfiguration_Handler,
 		},
+		{
+			MethodName: "GetConfigurationDelta",
+			Handler:    _ConfigService_GetConfigurationDelta_Handler,
+		},
 		{
 			MethodName: "GetNextChangesets",
 			Handler:    _ConfigService_GetNextChangesets_Handler,
@@ -198,6 +276,12 @@ var ConfigService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ConfigService_GetVariationHierarchy_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchConfiguration",
+			Handler:       _ConfigService_WatchConfiguration_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "configuration.proto",
 }
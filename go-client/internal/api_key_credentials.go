@@ -0,0 +1,26 @@
+package internal
+
+import (
+	"context"
+)
+
+// ApiKeyMetadataKey is the metadata key the server reads the API key of the client from
+const ApiKeyMetadataKey = "x-api-key"
+
+// ApiKeyCredentials attaches the API key of the client to every request
+type ApiKeyCredentials struct {
+	apiKey string
+}
+
+func NewApiKeyCredentials(apiKey string) *ApiKeyCredentials {
+	return &ApiKeyCredentials{apiKey: apiKey}
+}
+
+func (c *ApiKeyCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{ApiKeyMetadataKey: c.apiKey}, nil
+}
+
+// RequireTransportSecurity returns false, so API keys can be used on plain text connections inside trusted networks
+func (c *ApiKeyCredentials) RequireTransportSecurity() bool {
+	return false
+}
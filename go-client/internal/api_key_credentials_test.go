@@ -0,0 +1,18 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestApiKeyCredentials(t *testing.T) {
+	credentials := NewApiKeyCredentials("csk_123456")
+
+	metadata, err := credentials.GetRequestMetadata(context.Background())
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, metadata, map[string]string{"x-api-key": "csk_123456"})
+	assert.Assert(t, !credentials.RequireTransportSecurity())
+}
@@ -3,6 +3,8 @@ package internal
 import (
 	"context"
 	"time"
+
+	"google.golang.org/grpc/credentials"
 )
 
 type Feature interface {
@@ -38,8 +40,10 @@ type Config struct {
 	Services                  []string
 	StaticVariation           map[string]string
 	DynamicVariationResolvers map[string]PropertyResolverFunc
+	RolloutAttributeResolvers map[string]PropertyResolverFunc
 	Features                  []Feature
 	ProductionMode            bool
+	Streaming                 bool
 	ChangesetOverrider        func(ctx context.Context) *uint32
 	Logger                    Logger
 	PollingInterval           time.Duration
@@ -47,6 +51,8 @@ type Config struct {
 	UnusedSnapshotExpiration  time.Duration
 	FallbackFileLocation      string
 	Overrides                 Overrides
+	ApiKey                    string
+	TransportCredentials      credentials.TransportCredentials
 }
 
 func (c *Config) IsFallbackFileEnabled() bool {
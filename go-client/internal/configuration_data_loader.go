@@ -3,6 +3,7 @@ package internal
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	grpcgen "github.com/necroskillz/config-service/go-client/grpc/gen"
 )
@@ -13,11 +14,18 @@ type ConfigurationDataLoader interface {
 	GetConfiguration(ctx context.Context, changesetID *uint32) (*ConfigurationSnapshot, error)
 	GetVariationHierarchy(ctx context.Context) (*VariationHierarchy, error)
 	GetNextChangesets(ctx context.Context, afterChangesetID uint32) ([]uint32, error)
+	WatchConfiguration(ctx context.Context, afterChangesetID uint32) (ChangesetStream, error)
+}
+
+type ChangesetStream interface {
+	Recv() ([]uint32, error)
 }
 
 type ConfigurationDataLoaderImpl struct {
-	configClient grpcgen.ConfigServiceClient
-	config       *Config
+	configClient      grpcgen.ConfigServiceClient
+	config            *Config
+	lastSnapshotMutex sync.Mutex
+	lastSnapshot      *ConfigurationSnapshot
 }
 
 func NewConfigurationDataLoader(configClient grpcgen.ConfigServiceClient, config *Config) ConfigurationDataLoader {
@@ -27,12 +35,32 @@ func NewConfigurationDataLoader(configClient grpcgen.ConfigServiceClient, config
 	}
 }
 
-func (c *ConfigurationDataLoaderImpl) GetConfiguration(ctx context.Context, changesetID *uint32) (*ConfigurationSnapshot, error) {
-	mode := ""
+func (c *ConfigurationDataLoaderImpl) mode() string {
 	if c.config.ProductionMode {
-		mode = "production"
+		return "production"
 	}
 
+	return ""
+}
+
+// GetConfiguration loads the configuration for the changeset. When a previously loaded snapshot is available,
+// only the delta is requested and applied to it, falling back to loading the full configuration if that fails.
+func (c *ConfigurationDataLoaderImpl) GetConfiguration(ctx context.Context, changesetID *uint32) (*ConfigurationSnapshot, error) {
+	c.lastSnapshotMutex.Lock()
+	previous := c.lastSnapshot
+	c.lastSnapshotMutex.Unlock()
+
+	if changesetID != nil && previous != nil && previous.ChangesetId != *changesetID {
+		snapshot, err := c.getConfigurationDelta(ctx, previous, *changesetID)
+		if err == nil {
+			return snapshot, nil
+		}
+
+		c.config.Logger.Debug(ctx, "failed to get configuration delta, loading full configuration", "changeset_id", *changesetID, "error", err)
+	}
+
+	mode := c.mode()
+
 	req := &grpcgen.GetConfigurationRequest{
 		Services:    c.config.Services,
 		Variation:   c.config.StaticVariation,
@@ -49,6 +77,47 @@ func (c *ConfigurationDataLoaderImpl) GetConfiguration(ctx context.Context, chan
 
 	snapshot.Validate(c.config.Features)
 
+	c.setLastSnapshot(snapshot)
+
+	return snapshot, nil
+}
+
+func (c *ConfigurationDataLoaderImpl) setLastSnapshot(snapshot *ConfigurationSnapshot) {
+	if len(snapshot.Errors) > 0 {
+		return
+	}
+
+	c.lastSnapshotMutex.Lock()
+	defer c.lastSnapshotMutex.Unlock()
+
+	c.lastSnapshot = snapshot
+}
+
+func (c *ConfigurationDataLoaderImpl) getConfigurationDelta(ctx context.Context, previous *ConfigurationSnapshot, changesetID uint32) (*ConfigurationSnapshot, error) {
+	mode := c.mode()
+
+	req := &grpcgen.GetConfigurationDeltaRequest{
+		Services:        c.config.Services,
+		Variation:       c.config.StaticVariation,
+		Mode:            &mode,
+		FromChangesetId: previous.ChangesetId,
+		ToChangesetId:   &changesetID,
+	}
+
+	res, err := c.configClient.GetConfigurationDelta(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configuration delta: %w", err)
+	}
+
+	snapshot, err := previous.Patch(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to patch configuration: %w", err)
+	}
+
+	snapshot.Validate(c.config.Features)
+
+	c.setLastSnapshot(snapshot)
+
 	return snapshot, nil
 }
 
@@ -84,3 +153,30 @@ func (c *ConfigurationDataLoaderImpl) GetNextChangesets(ctx context.Context, aft
 
 	return res.ChangesetIds, nil
 }
+
+type changesetStream struct {
+	stream grpcgen.ConfigService_WatchConfigurationClient
+}
+
+func (c *changesetStream) Recv() ([]uint32, error) {
+	res, err := c.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	return res.ChangesetIds, nil
+}
+
+func (c *ConfigurationDataLoaderImpl) WatchConfiguration(ctx context.Context, afterChangesetID uint32) (ChangesetStream, error) {
+	req := &grpcgen.WatchConfigurationRequest{
+		Services:         c.config.Services,
+		AfterChangesetId: afterChangesetID,
+	}
+
+	stream, err := c.configClient.WatchConfiguration(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch configuration: %w", err)
+	}
+
+	return &changesetStream{stream: stream}, nil
+}
@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"context"
+	"testing"
+
+	grpcgen "github.com/necroskillz/config-service/go-client/grpc/gen"
+	"github.com/necroskillz/config-service/go-client/internal/test"
+	"google.golang.org/grpc/codes"
+	"gotest.tools/v3/assert"
+)
+
+func TestConfigurationDataLoader(t *testing.T) {
+	type testFixture struct {
+		dataLoader ConfigurationDataLoader
+		grpc       *test.TestConfigClient
+		ctx        context.Context
+	}
+
+	setup := func(t *testing.T) *testFixture {
+		grpc := test.NewTestConfigGRPC(t)
+		logger := test.NewTestLogger(t)
+
+		config := &Config{
+			Logger:   NewLogger(logger.LogFn),
+			Services: []string{"service1:1"},
+			Features: []Feature{&TestFeature{}},
+		}
+
+		return &testFixture{
+			dataLoader: NewConfigurationDataLoader(grpc.Client(), config),
+			grpc:       grpc,
+			ctx:        context.Background(),
+		}
+	}
+
+	initialResponse := func() *grpcgen.GetConfigurationResponse {
+		return test.NewTestConfigurationReponseBuilder().
+			WithChangesetId(1).
+			WithDefaultValue("Feature1", "StringKey", DataTypeString, "test").
+			WithDefaultValue("Feature1", "IntKey", DataTypeInteger, "1").
+			WithDefaultValue("Feature1", "BoolKey", DataTypeBoolean, "true").
+			WithDefaultValue("Feature1", "DecimalKey", DataTypeDecimal, "1.0").
+			WithDefaultValue("Feature1", "JsonKey", DataTypeJson, "{\"field1\":\"test\"}").
+			WithDefaultValue("Feature1", "EnumKey", DataTypeEnum, "safe").
+			WithAllowedValues("Feature1", "EnumKey", "fast", "safe", "off").
+			Response()
+	}
+
+	t.Run("Patches previous snapshot with delta", func(t *testing.T) {
+		fixture := setup(t)
+
+		fixture.grpc.Server().ExpectUnary("grpcgen.ConfigService/GetConfiguration").Once().Return(initialResponse())
+		fixture.grpc.Server().ExpectUnary("grpcgen.ConfigService/GetConfigurationDelta").
+			Once().
+			Run(func(ctx context.Context, in any) (any, error) {
+				req := in.(*grpcgen.GetConfigurationDeltaRequest)
+				assert.Equal(t, req.FromChangesetId, uint32(1))
+				assert.Equal(t, *req.ToChangesetId, uint32(2))
+
+				return &grpcgen.GetConfigurationDeltaResponse{
+					FromChangesetId: 1,
+					ChangesetId:     2,
+					Features: []*grpcgen.FeatureDelta{
+						{
+							Name: "Feature1",
+							Keys: []*grpcgen.ConfigKeyDelta{
+								{Name: "StringKey", DataType: DataTypeString, Values: []*grpcgen.ConfigValue{{Data: "changed"}}},
+							},
+						},
+					},
+				}, nil
+			})
+
+		_, err := fixture.dataLoader.GetConfiguration(fixture.ctx, nil)
+		assert.NilError(t, err)
+
+		changesetID := uint32(2)
+		snapshot, err := fixture.dataLoader.GetConfiguration(fixture.ctx, &changesetID)
+		assert.NilError(t, err)
+
+		assert.Equal(t, snapshot.ChangesetId, uint32(2))
+		assert.DeepEqual(t, snapshot.Errors, []string{})
+
+		feature := TestFeature{}
+		err = snapshot.BindFeature(&feature, map[string][]string{}, map[string]string{}, Overrides{})
+		assert.NilError(t, err)
+
+		assert.Equal(t, feature.StringKey, "changed")
+		assert.Equal(t, feature.IntKey, 1)
+	})
+
+	t.Run("Falls back to full configuration when delta fails", func(t *testing.T) {
+		fixture := setup(t)
+
+		fixture.grpc.Server().ExpectUnary("grpcgen.ConfigService/GetConfiguration").Once().Return(initialResponse())
+		fixture.grpc.Server().ExpectUnary("grpcgen.ConfigService/GetConfigurationDelta").Once().ReturnError(codes.Unimplemented, "not implemented")
+		fixture.grpc.Server().ExpectUnary("grpcgen.ConfigService/GetConfiguration").Once().Return(initialResponse())
+
+		_, err := fixture.dataLoader.GetConfiguration(fixture.ctx, nil)
+		assert.NilError(t, err)
+
+		changesetID := uint32(2)
+		snapshot, err := fixture.dataLoader.GetConfiguration(fixture.ctx, &changesetID)
+		assert.NilError(t, err)
+
+		assert.Equal(t, snapshot.ChangesetId, uint32(1))
+	})
+}
@@ -44,6 +44,10 @@ func NewConfigurationPollJob(config *Config, variationHierarchyRefresher Variati
 }
 
 func (c *ConfigurationPollJob) Start(ctx context.Context, changesetID uint32) error {
+	return c.start(ctx, changesetID, c.pollJob)
+}
+
+func (c *ConfigurationPollJob) start(ctx context.Context, changesetID uint32, job func(ctx context.Context)) error {
 	if c.IsRunning() {
 		return errors.New("poller is already running")
 	}
@@ -51,9 +55,14 @@ func (c *ConfigurationPollJob) Start(ctx context.Context, changesetID uint32) er
 	c.lastChangesetID = changesetID
 	c.running.Store(true)
 
-	pollCtx, cancel := context.WithCancel(ctx)
+	jobCtx, cancel := context.WithCancel(ctx)
 	c.cancel = cancel
-	go c.pollJob(pollCtx)
+	go func() {
+		defer c.running.Store(false)
+		defer close(c.done)
+
+		job(jobCtx)
+	}()
 
 	return nil
 }
@@ -85,26 +94,32 @@ func (c *ConfigurationPollJob) poll(ctx context.Context) {
 
 	c.config.Logger.Debug(ctx, "poll: next changesets", "changesets", res)
 
-	if len(res) > 0 {
+	c.loadChangesets(ctx, "poll", res)
+}
+
+// loadChangesets publishes the latest valid snapshot out of the changesets (ordered from oldest to newest),
+// including any newer snapshots that have errors.
+func (c *ConfigurationPollJob) loadChangesets(ctx context.Context, source string, changesetIDs []uint32) {
+	if len(changesetIDs) > 0 {
 		err := c.variationHierarchyRefresher.Refresh(ctx)
 		if err != nil {
-			c.config.Logger.Error(ctx, "poll: failed to refresh variation hierarchy", "error", err)
+			c.config.Logger.Error(ctx, source+": failed to refresh variation hierarchy", "error", err)
 		} else {
-			c.config.Logger.Debug(ctx, "poll: variation hierarchy refreshed")
+			c.config.Logger.Debug(ctx, source+": variation hierarchy refreshed")
 		}
 
 	}
 
 	changesetIDUpdated := false
 
-	for _, changesetId := range slices.Backward(res) {
+	for _, changesetId := range slices.Backward(changesetIDs) {
 		snapshot, err := c.dataLoader.GetConfiguration(ctx, &changesetId)
 		if err != nil {
-			c.config.Logger.Error(ctx, "poll: failed to get configuration for changeset", "changeset_id", changesetId, "error", err)
+			c.config.Logger.Error(ctx, source+": failed to get configuration for changeset", "changeset_id", changesetId, "error", err)
 			continue
 		}
 
-		c.config.Logger.Debug(ctx, "poll: loaded configuration for changeset", "changeset_id", changesetId)
+		c.config.Logger.Debug(ctx, source+": loaded configuration for changeset", "changeset_id", changesetId)
 
 		if !changesetIDUpdated {
 			c.lastChangesetID = changesetId
@@ -120,9 +135,6 @@ func (c *ConfigurationPollJob) poll(ctx context.Context) {
 }
 
 func (c *ConfigurationPollJob) pollJob(ctx context.Context) {
-	defer c.running.Store(false)
-	defer close(c.done)
-
 	for {
 		select {
 		case <-ctx.Done():
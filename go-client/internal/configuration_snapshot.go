@@ -3,26 +3,83 @@ package internal
 import (
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"maps"
+	"net/url"
 	"reflect"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	grpcgen "github.com/necroskillz/config-service/go-client/grpc/gen"
 )
 
 const (
-	DataTypeString  = "string"
-	DataTypeInteger = "integer"
-	DataTypeBoolean = "boolean"
-	DataTypeDecimal = "decimal"
-	DataTypeJson    = "json"
+	DataTypeString      = "string"
+	DataTypeInteger     = "integer"
+	DataTypeBoolean     = "boolean"
+	DataTypeDecimal     = "decimal"
+	DataTypeJson        = "json"
+	DataTypeEnum        = "enum"
+	DataTypeSecret      = "secret"
+	DataTypeStringList  = "string_list"
+	DataTypeIntegerList = "integer_list"
+	DataTypeDuration    = "duration"
+	DataTypeURL         = "url"
+)
+
+var (
+	stringListType = reflect.TypeOf([]string{})
+	intListType    = reflect.TypeOf([]int{})
+	durationType   = reflect.TypeOf(time.Duration(0))
+	urlType        = reflect.TypeOf(&url.URL{})
 )
 
 type ValueSnapshot struct {
 	Data      string            `json:"data"`
 	Variation map[string]string `json:"variation"`
 	Rank      int32             `json:"rank"`
+	Rollout   *RolloutSnapshot  `json:"rollout,omitempty"`
+}
+
+// RolloutSnapshot limits a value to a percentage of the subjects, bucketed by the value of the attribute
+type RolloutSnapshot struct {
+	Percentage int32  `json:"percentage"`
+	Attribute  string `json:"attribute"`
+}
+
+func newValueSnapshot(value *grpcgen.ConfigValue) *ValueSnapshot {
+	snapshot := &ValueSnapshot{Data: value.Data, Variation: value.Variation, Rank: value.Rank}
+	if value.Rollout != nil {
+		snapshot.Rollout = &RolloutSnapshot{Percentage: value.Rollout.Percentage, Attribute: value.Rollout.Attribute}
+	}
+
+	return snapshot
+}
+
+// rolloutBucket deterministically assigns the attribute value to one of 100 buckets. The seed makes keys bucket
+// independently, so the same subjects do not end up in the rollout of every key.
+func rolloutBucket(seed string, attributeValue string) uint32 {
+	hash := fnv.New32a()
+	hash.Write([]byte(seed + ":" + attributeValue))
+
+	return hash.Sum32() % 100
+}
+
+// inRollout returns whether the value applies to the subject with the attributes. Values that are not rolled out
+// apply to every subject, rolled out values never apply to subjects without the attribute.
+func (v *ValueSnapshot) inRollout(attributes map[string]string, seed string) bool {
+	if v.Rollout == nil {
+		return true
+	}
+
+	attributeValue, ok := attributes[v.Rollout.Attribute]
+	if !ok {
+		return false
+	}
+
+	return rolloutBucket(seed, attributeValue) < uint32(max(v.Rollout.Percentage, 0))
 }
 
 func (v *ValueSnapshot) matchVariation(variationWithParents map[string][]string) bool {
@@ -38,35 +95,94 @@ func (v *ValueSnapshot) matchVariation(variationWithParents map[string][]string)
 }
 
 type KeySnapshot struct {
-	DataType string           `json:"dataType"`
-	Values   []*ValueSnapshot `json:"values"`
+	DataType string `json:"dataType"`
+	// AllowedValues are the values allowed by the enum validator of the key
+	AllowedValues []string         `json:"allowedValues,omitempty"`
+	Values        []*ValueSnapshot `json:"values"`
 }
 
 func NewKeySnapshot(key *grpcgen.ConfigKey) *KeySnapshot {
 	values := make([]*ValueSnapshot, len(key.Values))
 	for i, value := range key.Values {
-		values[i] = &ValueSnapshot{Data: value.Data, Variation: value.Variation, Rank: value.Rank}
+		values[i] = newValueSnapshot(value)
 	}
 
+	sortValues(values)
+
+	return &KeySnapshot{DataType: key.DataType, AllowedValues: key.AllowedValues, Values: values}
+}
+
+func sortValues(values []*ValueSnapshot) {
 	slices.SortFunc(values, func(i, j *ValueSnapshot) int {
 		return int(j.Rank - i.Rank)
 	})
+}
+
+func variationKey(variation map[string]string) string {
+	parts := make([]string, 0, len(variation))
+	for _, property := range slices.Sorted(maps.Keys(variation)) {
+		parts = append(parts, property+"="+variation[property])
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// valueKey identifies the value by its variation, rank and whether it is rolled out. Values stored with different
+// variations can have the same variation once the variation of the client is resolved, they have a different rank.
+// A rolled out value can share the variation and rank with the value it falls back to.
+func valueKey(variation map[string]string, rank int32, rollout bool) string {
+	key := strconv.Itoa(int(rank)) + "|" + variationKey(variation)
+	if rollout {
+		return key + "|rollout"
+	}
+
+	return key
+}
+
+// patch returns a new key snapshot with added or changed values replaced and removed values dropped. Values are
+// identified by valueKey.
+func (k *KeySnapshot) patch(delta *grpcgen.ConfigKeyDelta) *KeySnapshot {
+	replaced := make(map[string]bool, len(delta.Values)+len(delta.RemovedValues))
+	for _, value := range delta.Values {
+		replaced[valueKey(value.Variation, value.Rank, value.Rollout != nil)] = true
+	}
+
+	for _, value := range delta.RemovedValues {
+		replaced[valueKey(value.Variation, value.Rank, value.Rollout)] = true
+	}
+
+	values := make([]*ValueSnapshot, 0, len(delta.Values))
+	if k != nil {
+		for _, value := range k.Values {
+			if !replaced[valueKey(value.Variation, value.Rank, value.Rollout != nil)] {
+				values = append(values, value)
+			}
+		}
+	}
+
+	for _, value := range delta.Values {
+		values = append(values, newValueSnapshot(value))
+	}
+
+	sortValues(values)
 
-	return &KeySnapshot{DataType: key.DataType, Values: values}
+	return &KeySnapshot{DataType: delta.DataType, AllowedValues: delta.AllowedValues, Values: values}
 }
 
-func (k *KeySnapshot) getValues(variationWithParents map[string][]string) []*ValueSnapshot {
+// getValues returns the values that apply to the subject, the highest ranked one or all of them from the lowest rank for
+// json keys. Rolled out values the subject is not bucketed into are skipped in favor of lower ranked values.
+func (k *KeySnapshot) getValues(variationWithParents map[string][]string, attributes map[string]string, seed string) []*ValueSnapshot {
 	values := make([]*ValueSnapshot, 0, len(k.Values))
 
 	if k.DataType == "json" {
 		for _, value := range slices.Backward(k.Values) {
-			if value.matchVariation(variationWithParents) {
+			if value.matchVariation(variationWithParents) && value.inRollout(attributes, seed) {
 				values = append(values, value)
 			}
 		}
 	} else {
 		for _, value := range k.Values {
-			if value.matchVariation(variationWithParents) {
+			if value.matchVariation(variationWithParents) && value.inRollout(attributes, seed) {
 				values = append(values, value)
 
 				return values
@@ -110,6 +226,53 @@ func NewConfigurationSnapshot(response *grpcgen.GetConfigurationResponse) *Confi
 	return snapshot
 }
 
+// Patch returns a new snapshot with the delta applied. The snapshot itself is not modified and unchanged keys are shared between both snapshots.
+func (c *ConfigurationSnapshot) Patch(delta *grpcgen.GetConfigurationDeltaResponse) (*ConfigurationSnapshot, error) {
+	if delta.FromChangesetId != c.ChangesetId {
+		return nil, fmt.Errorf("delta from changeset %d cannot be applied to configuration for changeset %d", delta.FromChangesetId, c.ChangesetId)
+	}
+
+	features := maps.Clone(c.Features)
+	if features == nil {
+		features = make(map[string]map[string]*KeySnapshot, len(delta.Features))
+	}
+
+	for _, featureName := range delta.RemovedFeatures {
+		delete(features, featureName)
+	}
+
+	for _, featureDelta := range delta.Features {
+		keys := maps.Clone(features[featureDelta.Name])
+		if keys == nil {
+			keys = make(map[string]*KeySnapshot, len(featureDelta.Keys))
+		}
+
+		for _, keyName := range featureDelta.RemovedKeys {
+			delete(keys, keyName)
+		}
+
+		for _, keyDelta := range featureDelta.Keys {
+			keys[keyDelta.Name] = keys[keyDelta.Name].patch(keyDelta)
+		}
+
+		features[featureDelta.Name] = keys
+	}
+
+	snapshot := &ConfigurationSnapshot{
+		ChangesetId: delta.ChangesetId,
+		Features:    features,
+		Errors:      []string{},
+		Warnings:    []string{},
+	}
+
+	if delta.AppliedAt != nil {
+		appliedAt := delta.AppliedAt.AsTime()
+		snapshot.AppliedAt = &appliedAt
+	}
+
+	return snapshot, nil
+}
+
 type FeatureField struct {
 	Value reflect.Value
 	Field reflect.StructField
@@ -136,7 +299,7 @@ func getFeatureFields(feature Feature) ([]FeatureField, error) {
 
 func validateDataType(keyName string, dataType string, fieldType reflect.Type) error {
 	switch dataType {
-	case DataTypeString:
+	case DataTypeString, DataTypeEnum, DataTypeSecret:
 		if fieldType.Kind() != reflect.String {
 			return fmt.Errorf("field %s is defined as %s, but configuration type %s requires string", keyName, fieldType.Kind(), dataType)
 		}
@@ -154,6 +317,22 @@ func validateDataType(keyName string, dataType string, fieldType reflect.Type) e
 		}
 	case DataTypeJson:
 		return nil
+	case DataTypeStringList:
+		if fieldType != stringListType {
+			return fmt.Errorf("field %s is defined as %s, but configuration type %s requires %s", keyName, fieldType, dataType, stringListType)
+		}
+	case DataTypeIntegerList:
+		if fieldType != intListType {
+			return fmt.Errorf("field %s is defined as %s, but configuration type %s requires %s", keyName, fieldType, dataType, intListType)
+		}
+	case DataTypeDuration:
+		if fieldType != durationType {
+			return fmt.Errorf("field %s is defined as %s, but configuration type %s requires %s", keyName, fieldType, dataType, durationType)
+		}
+	case DataTypeURL:
+		if fieldType != urlType {
+			return fmt.Errorf("field %s is defined as %s, but configuration type %s requires %s", keyName, fieldType, dataType, urlType)
+		}
 	default:
 		return fmt.Errorf("field %s is defined as %s, but configuration type %s is not supported", keyName, fieldType.Name(), dataType)
 	}
@@ -217,7 +396,9 @@ func (c *ConfigurationSnapshot) Validate(features []Feature) {
 	}
 }
 
-func (c *ConfigurationSnapshot) BindFeature(feature Feature, variationWithParents map[string][]string, overrides Overrides) error {
+// BindFeature sets the fields of the feature to the values that apply to the variation. Rolled out values are bucketed
+// by the attributes of the subject.
+func (c *ConfigurationSnapshot) BindFeature(feature Feature, variationWithParents map[string][]string, attributes map[string]string, overrides Overrides) error {
 	featureName := feature.FeatureName()
 	configFeature, ok := c.Features[featureName]
 	if !ok {
@@ -244,7 +425,7 @@ func (c *ConfigurationSnapshot) BindFeature(feature Feature, variationWithParent
 				return fmt.Errorf("key %s not found in configuration", fieldName)
 			}
 
-			values := key.getValues(variationWithParents)
+			values := key.getValues(variationWithParents, attributes, featureName+"."+fieldName)
 			if len(values) == 0 {
 				return fmt.Errorf("no value found for key %s", fieldName)
 			}
@@ -275,7 +456,7 @@ func (c *ConfigurationSnapshot) BindFeature(feature Feature, variationWithParent
 				data = values[0].Data
 			}
 
-			if err := c.setFieldValue(field, key.DataType, data); err != nil {
+			if err := c.setFieldValue(field, key, data); err != nil {
 				return fmt.Errorf("failed to set field %s: %w", fieldName, err)
 			}
 		}
@@ -284,13 +465,18 @@ func (c *ConfigurationSnapshot) BindFeature(feature Feature, variationWithParent
 	return nil
 }
 
-func (c *ConfigurationSnapshot) setFieldValue(field FeatureField, dataType string, value string) error {
+func (c *ConfigurationSnapshot) setFieldValue(field FeatureField, key *KeySnapshot, value string) error {
 	if !field.Value.CanSet() {
 		return fmt.Errorf("field %s is not settable", field.Field.Name)
 	}
 
-	switch dataType {
-	case DataTypeString:
+	switch key.DataType {
+	case DataTypeString, DataTypeSecret:
+		field.Value.SetString(value)
+	case DataTypeEnum:
+		if len(key.AllowedValues) > 0 && !slices.Contains(key.AllowedValues, value) {
+			return fmt.Errorf("invalid enum value: %s, expected one of %s", value, strings.Join(key.AllowedValues, ", "))
+		}
 		field.Value.SetString(value)
 	case DataTypeInteger:
 		intValue, err := strconv.Atoi(value)
@@ -316,8 +502,26 @@ func (c *ConfigurationSnapshot) setFieldValue(field FeatureField, dataType strin
 			return fmt.Errorf("failed to unmarshal JSON: %w", err)
 		}
 		field.Value.Set(newValue.Elem())
+	case DataTypeStringList, DataTypeIntegerList:
+		newValue := reflect.New(field.Field.Type)
+		if err := json.Unmarshal([]byte(value), newValue.Interface()); err != nil {
+			return fmt.Errorf("invalid list value: %s for field %s: %w", value, field.Field.Name, err)
+		}
+		field.Value.Set(newValue.Elem())
+	case DataTypeDuration:
+		durationValue, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration value: %s for field %s", value, field.Field.Name)
+		}
+		field.Value.SetInt(int64(durationValue))
+	case DataTypeURL:
+		urlValue, err := url.Parse(value)
+		if err != nil {
+			return fmt.Errorf("invalid URL value: %s for field %s", value, field.Field.Name)
+		}
+		field.Value.Set(reflect.ValueOf(urlValue))
 	default:
-		return fmt.Errorf("unsupported data type: %s", dataType)
+		return fmt.Errorf("unsupported data type: %s", key.DataType)
 	}
 	return nil
 }
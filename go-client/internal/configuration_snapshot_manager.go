@@ -5,29 +5,35 @@ import (
 	"fmt"
 )
 
+// LatestSnapshotListener is called when a new valid snapshot becomes the latest configuration
+type LatestSnapshotListener func(ctx context.Context, snapshot *ConfigurationSnapshot)
+
 type ConfigurationSnapshotManager struct {
-	config       *Config
-	dataLoader   ConfigurationDataLoader
-	store        *ConfigurationSnapshotStore
-	fallbackFile *ConfigurationFallbackFile
-	poller       ConfigurationPoller
-	cleaner      *ConfigurationSnapshotCleaner
+	config           *Config
+	dataLoader       ConfigurationDataLoader
+	store            *ConfigurationSnapshotStore
+	fallbackFile     *ConfigurationFallbackFile
+	poller           ConfigurationPoller
+	cleaner          *ConfigurationSnapshotCleaner
+	onLatestSnapshot LatestSnapshotListener
 }
 
 func NewConfigurationSnapshotManager(
 	dataLoader ConfigurationDataLoader,
 	config *Config,
 	poller ConfigurationPoller,
+	onLatestSnapshot LatestSnapshotListener,
 ) *ConfigurationSnapshotManager {
 	store := NewConfigurationSnapshotStore(config)
 
 	manager := &ConfigurationSnapshotManager{
-		dataLoader:   dataLoader,
-		config:       config,
-		store:        store,
-		fallbackFile: NewConfigurationFallbackFile(config),
-		poller:       poller,
-		cleaner:      NewConfigurationSnapshotCleaner(config, store),
+		dataLoader:       dataLoader,
+		config:           config,
+		store:            store,
+		fallbackFile:     NewConfigurationFallbackFile(config),
+		poller:           poller,
+		cleaner:          NewConfigurationSnapshotCleaner(config, store),
+		onLatestSnapshot: onLatestSnapshot,
 	}
 
 	go func() {
@@ -175,6 +181,11 @@ func (c *ConfigurationSnapshotManager) storeSnapshot(ctx context.Context, option
 	if len(options.Snapshot.Warnings) > 0 {
 		c.config.Logger.Warn(ctx, "configuration for changeset has warnings", "changeset_id", options.Snapshot.ChangesetId, "warnings", options.Snapshot.Warnings)
 	}
+
+	isLatest := options.Snapshot.AppliedAt != nil && !options.IsOverriden && len(options.Snapshot.Errors) == 0
+	if isLatest && c.onLatestSnapshot != nil {
+		c.onLatestSnapshot(ctx, options.Snapshot)
+	}
 }
 
 func (c *ConfigurationSnapshotManager) ensureJobsRunning(ctx context.Context) {
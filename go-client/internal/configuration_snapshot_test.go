@@ -2,8 +2,11 @@ package internal
 
 import (
 	"fmt"
+	"net/url"
 	"testing"
+	"time"
 
+	grpcgen "github.com/necroskillz/config-service/go-client/grpc/gen"
 	"github.com/necroskillz/config-service/go-client/internal/test"
 	"gotest.tools/v3/assert"
 )
@@ -12,18 +15,32 @@ type TestJSONStruct struct {
 	Field1 string
 }
 
+type TestMode string
+
 type TestFeature struct {
 	StringKey  string
 	IntKey     int
 	BoolKey    bool
 	DecimalKey float64
 	JsonKey    TestJSONStruct
+	EnumKey    TestMode
 }
 
 func (f *TestFeature) FeatureName() string {
 	return "Feature1"
 }
 
+type TypedFeature struct {
+	StringListKey  []string
+	IntegerListKey []int
+	DurationKey    time.Duration
+	URLKey         *url.URL
+}
+
+func (f *TypedFeature) FeatureName() string {
+	return "Typed"
+}
+
 type NonStructFeature string
 
 func (f NonStructFeature) FeatureName() string {
@@ -45,7 +62,17 @@ func TestConfigurationSnapshot(t *testing.T) {
 			WithDefaultValue("Feature1", "IntKey", DataTypeInteger, "1").
 			WithDefaultValue("Feature1", "BoolKey", DataTypeBoolean, "true").
 			WithDefaultValue("Feature1", "DecimalKey", DataTypeDecimal, "1.0").
-			WithDefaultValue("Feature1", "JsonKey", DataTypeJson, "{\"field1\":\"test\"}")
+			WithDefaultValue("Feature1", "JsonKey", DataTypeJson, "{\"field1\":\"test\"}").
+			WithDefaultValue("Feature1", "EnumKey", DataTypeEnum, "safe").
+			WithAllowedValues("Feature1", "EnumKey", "fast", "safe", "off")
+	}
+
+	TypedResponse := func() *test.TestConfigurationReponseBuilder {
+		return test.NewTestConfigurationReponseBuilder().
+			WithDefaultValue("Typed", "StringListKey", DataTypeStringList, `["a","b"]`).
+			WithDefaultValue("Typed", "IntegerListKey", DataTypeIntegerList, `[1,2,3]`).
+			WithDefaultValue("Typed", "DurationKey", DataTypeDuration, "1m30s").
+			WithDefaultValue("Typed", "URLKey", DataTypeURL, "https://example.com/api")
 	}
 
 	t.Run("Validate", func(t *testing.T) {
@@ -114,6 +141,8 @@ func TestConfigurationSnapshot(t *testing.T) {
 				"bool":    {keyName: "StringKey", configurationDataType: DataTypeBoolean, actualDataType: "string"},
 				"decimal": {keyName: "StringKey", configurationDataType: DataTypeDecimal, actualDataType: "string"},
 				"string":  {keyName: "IntKey", configurationDataType: DataTypeString, actualDataType: "int"},
+				"enum":    {keyName: "IntKey", configurationDataType: DataTypeEnum, actualDataType: "int", errorMessage: "field IntKey is defined as int, but configuration type enum requires string"},
+				"secret":  {keyName: "IntKey", configurationDataType: DataTypeSecret, actualDataType: "int", errorMessage: "field IntKey is defined as int, but configuration type secret requires string"},
 				"unknown": {keyName: "StringKey", configurationDataType: "unknown", errorMessage: "field StringKey is defined as string, but configuration type unknown is not supported"},
 			}
 
@@ -185,13 +214,27 @@ func TestConfigurationSnapshot(t *testing.T) {
 
 			feature := TestFeature{}
 
-			snapshot.BindFeature(&feature, map[string][]string{}, Overrides{})
+			snapshot.BindFeature(&feature, map[string][]string{}, map[string]string{}, Overrides{})
 
 			assert.Equal(t, feature.StringKey, "test")
 			assert.Equal(t, feature.IntKey, 1)
 			assert.Equal(t, feature.BoolKey, true)
 			assert.Equal(t, feature.DecimalKey, 1.0)
 			assert.DeepEqual(t, feature.JsonKey, TestJSONStruct{Field1: "test"})
+			assert.Equal(t, feature.EnumKey, TestMode("safe"))
+		})
+
+		t.Run("Error - Enum value not allowed", func(t *testing.T) {
+			response := DefaultResponse().
+				WithoutKey("Feature1", "EnumKey").
+				WithDefaultValue("Feature1", "EnumKey", DataTypeEnum, "slow").
+				WithAllowedValues("Feature1", "EnumKey", "fast", "safe", "off").
+				Response()
+			snapshot := NewConfigurationSnapshot(response)
+			feature := &TestFeature{}
+
+			err := snapshot.BindFeature(feature, map[string][]string{}, map[string]string{}, Overrides{})
+			assert.ErrorContains(t, err, "failed to set field EnumKey: invalid enum value: slow, expected one of fast, safe, off")
 		})
 
 		t.Run("Error - Feature not found", func(t *testing.T) {
@@ -199,7 +242,7 @@ func TestConfigurationSnapshot(t *testing.T) {
 			snapshot := NewConfigurationSnapshot(response)
 			feature := &TestFeature{}
 
-			err := snapshot.BindFeature(feature, map[string][]string{}, Overrides{})
+			err := snapshot.BindFeature(feature, map[string][]string{}, map[string]string{}, Overrides{})
 			assert.ErrorContains(t, err, "feature Feature1 not found in configuration")
 		})
 
@@ -214,7 +257,7 @@ func TestConfigurationSnapshot(t *testing.T) {
 				},
 			}
 
-			err := snapshot.BindFeature(feature, map[string][]string{}, overrides)
+			err := snapshot.BindFeature(feature, map[string][]string{}, map[string]string{}, overrides)
 			assert.ErrorContains(t, err, "field StringKey is defined as string, but override value is int")
 		})
 
@@ -223,7 +266,7 @@ func TestConfigurationSnapshot(t *testing.T) {
 			snapshot := NewConfigurationSnapshot(response)
 			feature := &TestFeature{}
 
-			err := snapshot.BindFeature(feature, map[string][]string{}, Overrides{})
+			err := snapshot.BindFeature(feature, map[string][]string{}, map[string]string{}, Overrides{})
 			assert.ErrorContains(t, err, "key StringKey not found in configuration")
 		})
 
@@ -239,7 +282,7 @@ func TestConfigurationSnapshot(t *testing.T) {
 				"env": {"dev"},
 			}
 
-			err := snapshot.BindFeature(feature, variation, Overrides{})
+			err := snapshot.BindFeature(feature, variation, map[string]string{}, Overrides{})
 			assert.ErrorContains(t, err, "no value found for key StringKey")
 		})
 
@@ -260,7 +303,7 @@ func TestConfigurationSnapshot(t *testing.T) {
 				snapshot := NewConfigurationSnapshot(response)
 				feature := &TestFeature{}
 
-				err := snapshot.BindFeature(feature, map[string][]string{}, Overrides{})
+				err := snapshot.BindFeature(feature, map[string][]string{}, map[string]string{}, Overrides{})
 				assert.ErrorContains(t, err, tc.expectedError)
 			}
 
@@ -275,6 +318,51 @@ func TestConfigurationSnapshot(t *testing.T) {
 			test.RunCases(t, run, cases)
 		})
 
+		t.Run("Typed - Valid", func(t *testing.T) {
+			snapshot := NewConfigurationSnapshot(TypedResponse().Response())
+
+			feature := TypedFeature{}
+
+			err := snapshot.BindFeature(&feature, map[string][]string{}, map[string]string{}, Overrides{})
+			assert.NilError(t, err)
+
+			assert.DeepEqual(t, feature.StringListKey, []string{"a", "b"})
+			assert.DeepEqual(t, feature.IntegerListKey, []int{1, 2, 3})
+			assert.Equal(t, feature.DurationKey, 90*time.Second)
+			assert.Equal(t, feature.URLKey.String(), "https://example.com/api")
+		})
+
+		t.Run("Typed - Error - Invalid Data Type Values", func(t *testing.T) {
+			type testCase struct {
+				keyName       string
+				dataType      string
+				value         string
+				expectedError string
+			}
+
+			run := func(t *testing.T, tc testCase) {
+				response := TypedResponse().
+					WithoutKey("Typed", tc.keyName).
+					WithDefaultValue("Typed", tc.keyName, tc.dataType, tc.value).
+					Response()
+
+				snapshot := NewConfigurationSnapshot(response)
+				feature := &TypedFeature{}
+
+				err := snapshot.BindFeature(feature, map[string][]string{}, map[string]string{}, Overrides{})
+				assert.ErrorContains(t, err, tc.expectedError)
+			}
+
+			cases := map[string]testCase{
+				"invalid string list":  {keyName: "StringListKey", dataType: DataTypeStringList, value: "[1]", expectedError: "invalid list value"},
+				"invalid integer list": {keyName: "IntegerListKey", dataType: DataTypeIntegerList, value: `["a"]`, expectedError: "invalid list value"},
+				"invalid duration":     {keyName: "DurationKey", dataType: DataTypeDuration, value: "5 days", expectedError: "invalid duration value"},
+				"invalid url":          {keyName: "URLKey", dataType: DataTypeURL, value: "http://[::1", expectedError: "invalid URL value"},
+			}
+
+			test.RunCases(t, run, cases)
+		})
+
 		t.Run("Valid with overrides", func(t *testing.T) {
 			response := DefaultResponse().Response()
 			snapshot := NewConfigurationSnapshot(response)
@@ -287,7 +375,7 @@ func TestConfigurationSnapshot(t *testing.T) {
 				},
 			}
 
-			err := snapshot.BindFeature(&feature, map[string][]string{}, overrides)
+			err := snapshot.BindFeature(&feature, map[string][]string{}, map[string]string{}, overrides)
 			assert.NilError(t, err)
 
 			assert.Equal(t, feature.StringKey, "overridden")
@@ -309,7 +397,7 @@ func TestConfigurationSnapshot(t *testing.T) {
 				"env": {"dev"},
 			}
 
-			err := snapshot.BindFeature(&feature, variation, Overrides{})
+			err := snapshot.BindFeature(&feature, variation, map[string]string{}, Overrides{})
 			assert.NilError(t, err)
 
 			assert.Equal(t, feature.StringKey, "dev_value")
@@ -328,7 +416,7 @@ func TestConfigurationSnapshot(t *testing.T) {
 				"env": {"dev"},
 			}
 
-			err := snapshot.BindFeature(&feature, variation, Overrides{})
+			err := snapshot.BindFeature(&feature, variation, map[string]string{}, Overrides{})
 			assert.NilError(t, err)
 
 			assert.Equal(t, feature.StringKey, "dev_value")
@@ -346,7 +434,7 @@ func TestConfigurationSnapshot(t *testing.T) {
 				"env": {"qa", "qa1"},
 			}
 
-			err := snapshot.BindFeature(&feature, variation, Overrides{})
+			err := snapshot.BindFeature(&feature, variation, map[string]string{}, Overrides{})
 			assert.NilError(t, err)
 
 			assert.Equal(t, feature.StringKey, "qa_value")
@@ -365,7 +453,7 @@ func TestConfigurationSnapshot(t *testing.T) {
 				"env": {"qa", "qa1"},
 			}
 
-			err := snapshot.BindFeature(&feature, variation, Overrides{})
+			err := snapshot.BindFeature(&feature, variation, map[string]string{}, Overrides{})
 			assert.NilError(t, err)
 
 			assert.Equal(t, feature.StringKey, "qa1_value")
@@ -383,7 +471,7 @@ func TestConfigurationSnapshot(t *testing.T) {
 				"env": {"dev"},
 			}
 
-			err := snapshot.BindFeature(&feature, variation, Overrides{})
+			err := snapshot.BindFeature(&feature, variation, map[string]string{}, Overrides{})
 			assert.NilError(t, err)
 
 			assert.Equal(t, feature.StringKey, "test")
@@ -407,7 +495,7 @@ func TestConfigurationSnapshot(t *testing.T) {
 				"env": {"dev"},
 			}
 
-			err := snapshot.BindFeature(&feature, variation, Overrides{})
+			err := snapshot.BindFeature(&feature, variation, map[string]string{}, Overrides{})
 			assert.ErrorContains(t, err, "failed to unmarshal JSON:")
 		})
 
@@ -423,8 +511,263 @@ func TestConfigurationSnapshot(t *testing.T) {
 				"env": {"dev"},
 			}
 
-			err := snapshot.BindFeature(&feature, variation, Overrides{})
+			err := snapshot.BindFeature(&feature, variation, map[string]string{}, Overrides{})
 			assert.ErrorContains(t, err, "failed to unmarshal JSON:")
 		})
 	})
+
+	t.Run("BindFeature - Rollout", func(t *testing.T) {
+		type testCase struct {
+			percentage    int32
+			attributes    map[string]string
+			expectedValue string
+		}
+
+		run := func(t *testing.T, tc testCase) {
+			response := DefaultResponse().
+				WithRolloutValue("Feature1", "StringKey", DataTypeString, "rollout_value", nil, 1, tc.percentage, "user_id").
+				Response()
+
+			snapshot := NewConfigurationSnapshot(response)
+			feature := TestFeature{}
+
+			err := snapshot.BindFeature(&feature, map[string][]string{}, tc.attributes, Overrides{})
+			assert.NilError(t, err)
+
+			assert.Equal(t, feature.StringKey, tc.expectedValue)
+		}
+
+		// user-2 falls into bucket 12 and user-1 into bucket 69 of Feature1.StringKey
+		cases := map[string]testCase{
+			"In rollout":        {percentage: 50, attributes: map[string]string{"user_id": "user-2"}, expectedValue: "rollout_value"},
+			"Not in rollout":    {percentage: 50, attributes: map[string]string{"user_id": "user-1"}, expectedValue: "test"},
+			"Full rollout":      {percentage: 100, attributes: map[string]string{"user_id": "user-1"}, expectedValue: "rollout_value"},
+			"Zero rollout":      {percentage: 0, attributes: map[string]string{"user_id": "user-2"}, expectedValue: "test"},
+			"Missing attribute": {percentage: 100, attributes: map[string]string{"tenant_id": "user-2"}, expectedValue: "test"},
+		}
+
+		test.RunCases(t, run, cases)
+
+		t.Run("Deterministic bucket", func(t *testing.T) {
+			assert.Equal(t, rolloutBucket("Feature1.StringKey", "user-2"), uint32(12))
+			assert.Equal(t, rolloutBucket("Feature1.StringKey", "user-1"), uint32(69))
+		})
+
+		t.Run("Falls back to lower ranked variation", func(t *testing.T) {
+			response := DefaultResponse().
+				WithDynamicVariationValue("Feature1", "StringKey", DataTypeString, "qa_value", map[string]string{"env": "qa"}, 1).
+				WithRolloutValue("Feature1", "StringKey", DataTypeString, "rollout_value", map[string]string{"env": "qa1"}, 2, 50, "user_id").
+				Response()
+
+			snapshot := NewConfigurationSnapshot(response)
+			feature := TestFeature{}
+
+			variation := map[string][]string{
+				"env": {"qa", "qa1"},
+			}
+
+			err := snapshot.BindFeature(&feature, variation, map[string]string{"user_id": "user-1"}, Overrides{})
+			assert.NilError(t, err)
+
+			assert.Equal(t, feature.StringKey, "qa_value")
+		})
+
+		t.Run("JSON merging skips values not in rollout", func(t *testing.T) {
+			response := DefaultResponse().
+				WithRolloutValue("Feature1", "JsonKey", DataTypeJson, "{\"field1\":\"rollout\"}", nil, 1, 0, "user_id").
+				Response()
+
+			snapshot := NewConfigurationSnapshot(response)
+			feature := TestFeature{}
+
+			err := snapshot.BindFeature(&feature, map[string][]string{}, map[string]string{"user_id": "user-2"}, Overrides{})
+			assert.NilError(t, err)
+
+			assert.DeepEqual(t, feature.JsonKey, TestJSONStruct{Field1: "test"})
+		})
+	})
+
+	t.Run("Patch", func(t *testing.T) {
+		t.Run("Applies added, changed and removed keys and values", func(t *testing.T) {
+			response := DefaultResponse().
+				WithChangesetId(1).
+				WithDynamicVariationValue("Feature1", "StringKey", DataTypeString, "dev_value", map[string]string{"env": "dev"}, 1).
+				WithDynamicVariationValue("Feature1", "StringKey", DataTypeString, "qa_value", map[string]string{"env": "qa"}, 1).
+				WithDefaultValue("Feature2", "StringKey", DataTypeString, "removed").
+				Response()
+
+			snapshot := NewConfigurationSnapshot(response)
+
+			patched, err := snapshot.Patch(&grpcgen.GetConfigurationDeltaResponse{
+				FromChangesetId: 1,
+				ChangesetId:     2,
+				Features: []*grpcgen.FeatureDelta{
+					{
+						Name: "Feature1",
+						Keys: []*grpcgen.ConfigKeyDelta{
+							{
+								Name:     "StringKey",
+								DataType: DataTypeString,
+								Values: []*grpcgen.ConfigValue{
+									{Data: "changed", Variation: map[string]string{"env": "dev"}, Rank: 1},
+									{Data: "prod_value", Variation: map[string]string{"env": "prod"}, Rank: 1},
+								},
+								RemovedValues: []*grpcgen.RemovedConfigValue{
+									{Variation: map[string]string{"env": "qa"}, Rank: 1},
+								},
+							},
+							{
+								Name:     "NewKey",
+								DataType: DataTypeInteger,
+								Values:   []*grpcgen.ConfigValue{{Data: "5"}},
+							},
+						},
+						RemovedKeys: []string{"BoolKey"},
+					},
+				},
+				RemovedFeatures: []string{"Feature2"},
+			})
+			assert.NilError(t, err)
+
+			assert.Equal(t, patched.ChangesetId, uint32(2))
+			assert.Assert(t, patched.Features["Feature2"] == nil)
+			assert.Assert(t, patched.Features["Feature1"]["BoolKey"] == nil)
+			assert.DeepEqual(t, patched.Features["Feature1"]["NewKey"], &KeySnapshot{DataType: DataTypeInteger, Values: []*ValueSnapshot{{Data: "5"}}})
+			assert.Equal(t, patched.Features["Feature1"]["IntKey"], snapshot.Features["Feature1"]["IntKey"])
+
+			values := map[string]string{}
+			for _, value := range patched.Features["Feature1"]["StringKey"].Values {
+				values[variationKey(value.Variation)] = value.Data
+			}
+
+			assert.DeepEqual(t, values, map[string]string{"": "test", "env=dev": "changed", "env=prod": "prod_value"})
+			assert.Equal(t, patched.Features["Feature1"]["StringKey"].Values[2].Data, "test")
+
+			// original snapshot is not modified
+			assert.Equal(t, len(snapshot.Features["Feature1"]["StringKey"].Values), 3)
+			assert.Assert(t, snapshot.Features["Feature2"] != nil)
+			assert.Assert(t, snapshot.Features["Feature1"]["BoolKey"] != nil)
+		})
+
+		t.Run("Rolled out values are patched separately from the value they fall back to", func(t *testing.T) {
+			response := DefaultResponse().
+				WithChangesetId(1).
+				WithRolloutValue("Feature1", "StringKey", DataTypeString, "rollout_value", nil, 1, 10, "user_id").
+				Response()
+
+			snapshot := NewConfigurationSnapshot(response)
+
+			patched, err := snapshot.Patch(&grpcgen.GetConfigurationDeltaResponse{
+				FromChangesetId: 1,
+				ChangesetId:     2,
+				Features: []*grpcgen.FeatureDelta{
+					{
+						Name: "Feature1",
+						Keys: []*grpcgen.ConfigKeyDelta{
+							{
+								Name:     "StringKey",
+								DataType: DataTypeString,
+								Values: []*grpcgen.ConfigValue{
+									{Data: "rollout_value", Rank: 1, Rollout: &grpcgen.Rollout{Percentage: 20, Attribute: "user_id"}},
+								},
+							},
+						},
+					},
+				},
+			})
+			assert.NilError(t, err)
+
+			assert.DeepEqual(t, patched.Features["Feature1"]["StringKey"].Values, []*ValueSnapshot{
+				{Data: "rollout_value", Rank: 1, Rollout: &RolloutSnapshot{Percentage: 20, Attribute: "user_id"}},
+				{Data: "test"},
+			})
+
+			patched, err = patched.Patch(&grpcgen.GetConfigurationDeltaResponse{
+				FromChangesetId: 2,
+				ChangesetId:     3,
+				Features: []*grpcgen.FeatureDelta{
+					{
+						Name: "Feature1",
+						Keys: []*grpcgen.ConfigKeyDelta{
+							{
+								Name:          "StringKey",
+								DataType:      DataTypeString,
+								RemovedValues: []*grpcgen.RemovedConfigValue{{Rank: 1, Rollout: true}},
+							},
+						},
+					},
+				},
+			})
+			assert.NilError(t, err)
+
+			assert.DeepEqual(t, patched.Features["Feature1"]["StringKey"].Values, []*ValueSnapshot{{Data: "test"}})
+		})
+
+		t.Run("Rolled out values with the same variation are told apart by rank", func(t *testing.T) {
+			// both rollouts are stored with different variations which are resolved by the variation of the client
+			response := DefaultResponse().
+				WithChangesetId(1).
+				WithRolloutValue("Feature1", "StringKey", DataTypeString, "env_rollout", nil, 1, 10, "user_id").
+				WithRolloutValue("Feature1", "StringKey", DataTypeString, "domain_rollout", nil, 3, 10, "user_id").
+				Response()
+
+			snapshot := NewConfigurationSnapshot(response)
+
+			patched, err := snapshot.Patch(&grpcgen.GetConfigurationDeltaResponse{
+				FromChangesetId: 1,
+				ChangesetId:     2,
+				Features: []*grpcgen.FeatureDelta{
+					{
+						Name: "Feature1",
+						Keys: []*grpcgen.ConfigKeyDelta{
+							{
+								Name:     "StringKey",
+								DataType: DataTypeString,
+								Values: []*grpcgen.ConfigValue{
+									{Data: "domain_rollout_changed", Rank: 3, Rollout: &grpcgen.Rollout{Percentage: 10, Attribute: "user_id"}},
+								},
+							},
+						},
+					},
+				},
+			})
+			assert.NilError(t, err)
+
+			assert.DeepEqual(t, patched.Features["Feature1"]["StringKey"].Values, []*ValueSnapshot{
+				{Data: "domain_rollout_changed", Rank: 3, Rollout: &RolloutSnapshot{Percentage: 10, Attribute: "user_id"}},
+				{Data: "env_rollout", Rank: 1, Rollout: &RolloutSnapshot{Percentage: 10, Attribute: "user_id"}},
+				{Data: "test"},
+			})
+
+			patched, err = patched.Patch(&grpcgen.GetConfigurationDeltaResponse{
+				FromChangesetId: 2,
+				ChangesetId:     3,
+				Features: []*grpcgen.FeatureDelta{
+					{
+						Name: "Feature1",
+						Keys: []*grpcgen.ConfigKeyDelta{
+							{
+								Name:          "StringKey",
+								DataType:      DataTypeString,
+								RemovedValues: []*grpcgen.RemovedConfigValue{{Rank: 1, Rollout: true}},
+							},
+						},
+					},
+				},
+			})
+			assert.NilError(t, err)
+
+			assert.DeepEqual(t, patched.Features["Feature1"]["StringKey"].Values, []*ValueSnapshot{
+				{Data: "domain_rollout_changed", Rank: 3, Rollout: &RolloutSnapshot{Percentage: 10, Attribute: "user_id"}},
+				{Data: "test"},
+			})
+		})
+
+		t.Run("Error - Delta from different changeset", func(t *testing.T) {
+			snapshot := NewConfigurationSnapshot(DefaultResponse().WithChangesetId(1).Response())
+
+			_, err := snapshot.Patch(&grpcgen.GetConfigurationDeltaResponse{FromChangesetId: 2, ChangesetId: 3})
+			assert.Error(t, err, "delta from changeset 2 cannot be applied to configuration for changeset 1")
+		})
+	})
 }
@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ ConfigurationPoller = (*ConfigurationWatchJob)(nil)
+
+// ConfigurationWatchJob receives applied changesets over a server stream. While the stream is unavailable,
+// it falls back to polling every PollingInterval until it is able to reconnect.
+type ConfigurationWatchJob struct {
+	*ConfigurationPollJob
+}
+
+func NewConfigurationWatchJob(config *Config, variationHierarchyRefresher VariationHierarchyRefresher, dataLoader ConfigurationDataLoader) *ConfigurationWatchJob {
+	return &ConfigurationWatchJob{
+		ConfigurationPollJob: NewConfigurationPollJob(config, variationHierarchyRefresher, dataLoader),
+	}
+}
+
+func (c *ConfigurationWatchJob) Start(ctx context.Context, changesetID uint32) error {
+	return c.start(ctx, changesetID, c.watchJob)
+}
+
+func (c *ConfigurationWatchJob) watch(ctx context.Context) error {
+	stream, err := c.dataLoader.WatchConfiguration(ctx, c.lastChangesetID)
+	if err != nil {
+		return err
+	}
+
+	for {
+		changesetIDs, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		c.config.Logger.Debug(ctx, "watch: next changesets", "changesets", changesetIDs)
+
+		c.loadChangesets(ctx, "watch", changesetIDs)
+	}
+}
+
+func (c *ConfigurationWatchJob) watchJob(ctx context.Context) {
+	for {
+		c.config.Logger.Debug(ctx, "watch: starting stream")
+
+		err := c.watch(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if status.Code(err) == codes.Unimplemented {
+			c.config.Logger.Warn(ctx, "watch: server does not support streaming, falling back to polling")
+
+			c.pollJob(ctx)
+
+			return
+		}
+
+		c.config.Logger.Error(ctx, "watch: stream failed, falling back to polling", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.config.PollingInterval):
+			c.poll(ctx)
+		}
+	}
+}
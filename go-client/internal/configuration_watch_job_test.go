@@ -0,0 +1,149 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/necroskillz/config-service/go-client/internal/test"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/v3/assert"
+)
+
+func TestConfigurationWatchJob(t *testing.T) {
+	type testFixture struct {
+		watchJob                    *ConfigurationWatchJob
+		ctx                         context.Context
+		variationHierarchyRefresher *MockVariationHierarchyRefresher
+		dataLoaderMock              *MockConfigurationDataLoader
+		logger                      *test.TestLogger
+	}
+
+	setup := func(t *testing.T) *testFixture {
+		logger := test.NewTestLogger(t)
+		variationHierarchyRefresher := NewMockVariationHierarchyRefresher(t)
+		dataLoaderMock := NewMockConfigurationDataLoader(t)
+
+		config := &Config{
+			Logger:          NewLogger(logger.LogFn),
+			PollingInterval: 10 * time.Millisecond,
+			Services:        []string{"service1", "service2"},
+		}
+
+		watchJob := NewConfigurationWatchJob(config, variationHierarchyRefresher, dataLoaderMock)
+
+		return &testFixture{watchJob: watchJob, ctx: context.Background(), variationHierarchyRefresher: variationHierarchyRefresher, dataLoaderMock: dataLoaderMock, logger: logger}
+	}
+
+	// blockingStream returns the given changesets and then blocks until the watch is cancelled
+	blockingStream := func(t *testing.T, ctx context.Context, changesetIDs ...[]uint32) ChangesetStream {
+		stream := NewMockChangesetStream(t)
+
+		for _, ids := range changesetIDs {
+			stream.EXPECT().Recv().Return(ids, nil).Once()
+		}
+
+		stream.EXPECT().Recv().RunAndReturn(func() ([]uint32, error) {
+			<-ctx.Done()
+
+			return nil, ctx.Err()
+		}).Maybe()
+
+		return stream
+	}
+
+	t.Run("Start stop", func(t *testing.T) {
+		fixture := setup(t)
+
+		fixture.dataLoaderMock.EXPECT().WatchConfiguration(mock.Anything, uint32(1)).RunAndReturn(func(ctx context.Context, afterChangesetID uint32) (ChangesetStream, error) {
+			return blockingStream(t, ctx), nil
+		})
+
+		err := fixture.watchJob.Start(fixture.ctx, 1)
+		assert.NilError(t, err)
+		assert.Assert(t, fixture.watchJob.IsRunning())
+
+		err = fixture.watchJob.Start(fixture.ctx, 1)
+		assert.Error(t, err, "poller is already running")
+
+		fixture.watchJob.Stop()
+		<-fixture.watchJob.Done()
+
+		assert.Assert(t, !fixture.watchJob.IsRunning())
+	})
+
+	t.Run("Publishes snapshots received from stream", func(t *testing.T) {
+		fixture := setup(t)
+
+		snapshot := &ConfigurationSnapshot{ChangesetId: 3}
+		changesetID := uint32(3)
+
+		fixture.dataLoaderMock.EXPECT().WatchConfiguration(mock.Anything, uint32(1)).RunAndReturn(func(ctx context.Context, afterChangesetID uint32) (ChangesetStream, error) {
+			return blockingStream(t, ctx, []uint32{2, 3}), nil
+		})
+		fixture.variationHierarchyRefresher.EXPECT().Refresh(mock.Anything).Return(nil)
+		fixture.dataLoaderMock.EXPECT().GetConfiguration(mock.Anything, &changesetID).Return(snapshot, nil)
+
+		err := fixture.watchJob.Start(fixture.ctx, 1)
+		assert.NilError(t, err)
+
+		assert.DeepEqual(t, <-fixture.watchJob.Snapshots(), snapshot)
+
+		fixture.watchJob.Stop()
+		<-fixture.watchJob.Done()
+	})
+
+	t.Run("Falls back to polling when streaming is not supported", func(t *testing.T) {
+		fixture := setup(t)
+
+		snapshot := &ConfigurationSnapshot{ChangesetId: 2}
+		changesetID := uint32(2)
+
+		stream := NewMockChangesetStream(t)
+		stream.EXPECT().Recv().Return(nil, status.Error(codes.Unimplemented, "unknown method WatchConfiguration"))
+
+		fixture.dataLoaderMock.EXPECT().WatchConfiguration(mock.Anything, uint32(1)).Return(stream, nil).Once()
+		fixture.dataLoaderMock.EXPECT().GetNextChangesets(mock.Anything, uint32(1)).Return([]uint32{2}, nil).Once()
+		fixture.dataLoaderMock.EXPECT().GetNextChangesets(mock.Anything, uint32(2)).Return([]uint32{}, nil).Maybe()
+		fixture.variationHierarchyRefresher.EXPECT().Refresh(mock.Anything).Return(nil)
+		fixture.dataLoaderMock.EXPECT().GetConfiguration(mock.Anything, &changesetID).Return(snapshot, nil)
+
+		err := fixture.watchJob.Start(fixture.ctx, 1)
+		assert.NilError(t, err)
+
+		assert.DeepEqual(t, <-fixture.watchJob.Snapshots(), snapshot)
+
+		fixture.watchJob.Stop()
+		<-fixture.watchJob.Done()
+
+		fixture.logger.AssertLog(t, test.WithMessage("watch: server does not support streaming, falling back to polling"))
+	})
+
+	t.Run("Polls and reconnects when stream fails", func(t *testing.T) {
+		fixture := setup(t)
+
+		snapshot := &ConfigurationSnapshot{ChangesetId: 2}
+		changesetID := uint32(2)
+
+		fixture.dataLoaderMock.EXPECT().WatchConfiguration(mock.Anything, uint32(1)).Return(nil, errors.New("connection refused")).Once()
+		fixture.dataLoaderMock.EXPECT().GetNextChangesets(mock.Anything, uint32(1)).Return([]uint32{2}, nil).Once()
+		fixture.variationHierarchyRefresher.EXPECT().Refresh(mock.Anything).Return(nil)
+		fixture.dataLoaderMock.EXPECT().GetConfiguration(mock.Anything, &changesetID).Return(snapshot, nil)
+		fixture.dataLoaderMock.EXPECT().WatchConfiguration(mock.Anything, uint32(2)).RunAndReturn(func(ctx context.Context, afterChangesetID uint32) (ChangesetStream, error) {
+			return blockingStream(t, ctx), nil
+		}).Maybe()
+
+		err := fixture.watchJob.Start(fixture.ctx, 1)
+		assert.NilError(t, err)
+
+		assert.DeepEqual(t, <-fixture.watchJob.Snapshots(), snapshot)
+
+		fixture.watchJob.Stop()
+		<-fixture.watchJob.Done()
+
+		fixture.logger.AssertLog(t, test.WithMessage("watch: stream failed, falling back to polling"))
+	})
+}
@@ -306,6 +306,156 @@ func (_c *MockConfigurationDataLoader_GetVariationHierarchy_Call) RunAndReturn(r
 	return _c
 }
 
+// WatchConfiguration provides a mock function for the type MockConfigurationDataLoader
+func (_mock *MockConfigurationDataLoader) WatchConfiguration(ctx context.Context, afterChangesetID uint32) (ChangesetStream, error) {
+	ret := _mock.Called(ctx, afterChangesetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchConfiguration")
+	}
+
+	var r0 ChangesetStream
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32) (ChangesetStream, error)); ok {
+		return returnFunc(ctx, afterChangesetID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint32) ChangesetStream); ok {
+		r0 = returnFunc(ctx, afterChangesetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(ChangesetStream)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint32) error); ok {
+		r1 = returnFunc(ctx, afterChangesetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockConfigurationDataLoader_WatchConfiguration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchConfiguration'
+type MockConfigurationDataLoader_WatchConfiguration_Call struct {
+	*mock.Call
+}
+
+// WatchConfiguration is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterChangesetID uint32
+func (_e *MockConfigurationDataLoader_Expecter) WatchConfiguration(ctx interface{}, afterChangesetID interface{}) *MockConfigurationDataLoader_WatchConfiguration_Call {
+	return &MockConfigurationDataLoader_WatchConfiguration_Call{Call: _e.mock.On("WatchConfiguration", ctx, afterChangesetID)}
+}
+
+func (_c *MockConfigurationDataLoader_WatchConfiguration_Call) Run(run func(ctx context.Context, afterChangesetID uint32)) *MockConfigurationDataLoader_WatchConfiguration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint32
+		if args[1] != nil {
+			arg1 = args[1].(uint32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockConfigurationDataLoader_WatchConfiguration_Call) Return(changesetStream ChangesetStream, err error) *MockConfigurationDataLoader_WatchConfiguration_Call {
+	_c.Call.Return(changesetStream, err)
+	return _c
+}
+
+func (_c *MockConfigurationDataLoader_WatchConfiguration_Call) RunAndReturn(run func(ctx context.Context, afterChangesetID uint32) (ChangesetStream, error)) *MockConfigurationDataLoader_WatchConfiguration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockChangesetStream creates a new instance of MockChangesetStream. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockChangesetStream(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockChangesetStream {
+	mock := &MockChangesetStream{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockChangesetStream is an autogenerated mock type for the ChangesetStream type
+type MockChangesetStream struct {
+	mock.Mock
+}
+
+type MockChangesetStream_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockChangesetStream) EXPECT() *MockChangesetStream_Expecter {
+	return &MockChangesetStream_Expecter{mock: &_m.Mock}
+}
+
+// Recv provides a mock function for the type MockChangesetStream
+func (_mock *MockChangesetStream) Recv() ([]uint32, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Recv")
+	}
+
+	var r0 []uint32
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]uint32, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []uint32); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint32)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChangesetStream_Recv_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Recv'
+type MockChangesetStream_Recv_Call struct {
+	*mock.Call
+}
+
+// Recv is a helper method to define mock.On call
+func (_e *MockChangesetStream_Expecter) Recv() *MockChangesetStream_Recv_Call {
+	return &MockChangesetStream_Recv_Call{Call: _e.mock.On("Recv")}
+}
+
+func (_c *MockChangesetStream_Recv_Call) Run(run func()) *MockChangesetStream_Recv_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockChangesetStream_Recv_Call) Return(uint32s []uint32, err error) *MockChangesetStream_Recv_Call {
+	_c.Call.Return(uint32s, err)
+	return _c
+}
+
+func (_c *MockChangesetStream_Recv_Call) RunAndReturn(run func() ([]uint32, error)) *MockChangesetStream_Recv_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockConfigurationPoller creates a new instance of MockConfigurationPoller. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockConfigurationPoller(t interface {
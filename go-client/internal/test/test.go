@@ -111,6 +111,25 @@ func (b *TestConfigurationReponseBuilder) WithDynamicVariationValue(featureName
 	return b
 }
 
+func (b *TestConfigurationReponseBuilder) WithRolloutValue(featureName string, keyName string, dataType string, data string, variation map[string]string, rank int32, percentage int32, attribute string) *TestConfigurationReponseBuilder {
+	b.WithKey(featureName, keyName, dataType)
+
+	b.keys[featureName][keyName].Values = append(b.keys[featureName][keyName].Values, &grpcgen.ConfigValue{
+		Data:      data,
+		Variation: variation,
+		Rank:      rank,
+		Rollout:   &grpcgen.Rollout{Percentage: percentage, Attribute: attribute},
+	})
+
+	return b
+}
+
+func (b *TestConfigurationReponseBuilder) WithAllowedValues(featureName string, keyName string, allowedValues ...string) *TestConfigurationReponseBuilder {
+	b.keys[featureName][keyName].AllowedValues = allowedValues
+
+	return b
+}
+
 func (b *TestConfigurationReponseBuilder) WithChangesetId(changesetId uint32) *TestConfigurationReponseBuilder {
 	b.response.ChangesetId = changesetId
 